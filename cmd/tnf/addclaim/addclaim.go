@@ -109,5 +109,15 @@ func NewCommand() *cobra.Command {
 		return nil
 	}
 	addclaim.AddCommand(claimAddFile)
+
+	claimDiff.Flags().StringVarP(
+		&DiffOutput, "output", "o", diffOutputText,
+		"output format, text or json",
+	)
+	claimDiff.Flags().BoolVar(
+		&DiffIgnoreRemoved, "ignore-removed", false,
+		"do not count the tests that passed in the old claim and are missing from the new one as regressions",
+	)
+	addclaim.AddCommand(claimDiff)
 	return addclaim
 }
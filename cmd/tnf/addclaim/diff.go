@@ -0,0 +1,63 @@
+package claim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/claimdiff"
+)
+
+const (
+	diffOutputText = "text"
+	diffOutputJSON = "json"
+)
+
+var (
+	DiffOutput string
+	// DiffIgnoreRemoved tells not to count the tests that passed in the old claim and are missing from the new one
+	// as regressions, e.g. when the two runs selected different tests.
+	DiffIgnoreRemoved bool
+
+	claimDiff = &cobra.Command{
+		Use:   "diff <old claim file> <new claim file>",
+		Short: "Compare two claim files, exiting with an error when a test regressed",
+		Args:  cobra.ExactArgs(2), //nolint:gomnd // old and new claims
+		RunE:  claimCompare,
+	}
+)
+
+func claimCompare(cmd *cobra.Command, args []string) error {
+	if DiffOutput != diffOutputText && DiffOutput != diffOutputJSON {
+		return fmt.Errorf("unsupported output format %q, expected %q or %q", DiffOutput, diffOutputText, diffOutputJSON)
+	}
+	claims := make([]*claim.Root, len(args))
+	for i, claimFile := range args {
+		dat, err := os.ReadFile(claimFile)
+		if err != nil {
+			log.Fatalf("Error reading claim file :%v", err)
+		}
+		claims[i] = readClaim(&dat)
+	}
+
+	diff := claimdiff.Compare(claims[0].Claim, claims[1].Claim, DiffIgnoreRemoved)
+	if DiffOutput == diffOutputJSON {
+		payload, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to generate the claim diff: %v", err)
+		}
+		fmt.Println(string(payload))
+	} else if err := diff.WriteText(os.Stdout); err != nil {
+		return err
+	}
+
+	if n := len(diff.Regressions()); n > 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("%d tests regressed from %s to %s", n, args[0], args[1])
+	}
+	return nil
+}
@@ -219,6 +219,10 @@ func outputTestCases() {
 			fmt.Fprintf(os.Stdout, "Result Type|%s\n", identifiers.Catalog[k.identifier].Type)
 			fmt.Fprintf(os.Stdout, "Suggested Remediation|%s\n", strings.ReplaceAll(identifiers.Catalog[k.identifier].Remediation, "\n", " "))
 			fmt.Fprintf(os.Stdout, "Best Practice Reference|%s\n", strings.ReplaceAll(identifiers.Catalog[k.identifier].BestPracticeReference, "\n", " "))
+			fmt.Fprintf(os.Stdout, "Intrusive|%t\n", identifiers.Catalog[k.identifier].IntrusionSettings.ModifiesSystem)
+			if required := identifiers.RequiredCapabilities(k.identifier); len(required) > 0 {
+				fmt.Fprintf(os.Stdout, "Required Capabilities|%v\n", required)
+			}
 		}
 	}
 	fmt.Println()
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/report"
+)
+
+var (
+	claimPath string
+	HTMLPath  string
+	SARIFPath string
+
+	reportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Generates an HTML report and/or a SARIF log from a claim file",
+		RunE:  runReport,
+	}
+)
+
+func runReport(cmd *cobra.Command, args []string) error {
+	if HTMLPath == "" && SARIFPath == "" {
+		return errors.New("at least one of --html and --sarif is required")
+	}
+	contents, err := os.ReadFile(claimPath)
+	if err != nil {
+		return err
+	}
+	var root claim.Root
+	if err = json.Unmarshal(contents, &root); err != nil {
+		return err
+	}
+	if root.Claim == nil {
+		return errors.New("no claim found in " + claimPath)
+	}
+	r, err := report.New(root.Claim, claimPath)
+	if err != nil {
+		return err
+	}
+
+	if HTMLPath != "" {
+		if err = writeFile(HTMLPath, r.WriteHTML); err != nil {
+			return err
+		}
+	}
+	if SARIFPath != "" {
+		if err = writeFile(SARIFPath, r.WriteSARIF); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func NewCommand() *cobra.Command {
+	reportCmd.Flags().StringVarP(
+		&claimPath, "claim", "c", "",
+		"Path to the claim file",
+	)
+	reportCmd.Flags().StringVar(
+		&HTMLPath, "html", "",
+		"Path to the HTML report to write",
+	)
+	reportCmd.Flags().StringVar(
+		&SARIFPath, "sarif", "",
+		"Path to the SARIF log to write",
+	)
+	err := reportCmd.MarkFlagRequired("claim")
+	if err != nil {
+		return nil
+	}
+	return reportCmd
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
@@ -14,9 +15,27 @@ import (
 // There are external and internal endpoints. External doesn't need authentication
 // Here we are using only External endpoint to collect published containers and operator information
 
-const apiContainerCatalogExternalBaseEndPoint = "https://catalog.redhat.com/api/containers/v1"
-const apiOperatorCatalogExternalBaseEndPoint = "https://catalog.redhat.com/api/containers/v1/operators"
-const apiCatalogByRepositoriesBaseEndPoint = "https://catalog.redhat.com/api/containers/v1/repositories/registry/registry.access.redhat.com/repository"
+// DefaultCatalogURL is the base URL of the external catalog API. The links between its records are relative to it.
+const DefaultCatalogURL = "https://catalog.redhat.com/api/containers"
+
+const (
+	apiContainerCatalogPath      = "/v1"
+	apiOperatorCatalogPath       = "/v1/operators"
+	apiCatalogByRepositoriesPath = "/v1/repositories/registry/registry.access.redhat.com/repository"
+	vulnerabilitiesPageSize      = 100
+	// defaultImageTag is the tag of the images looked up without tag nor digest.
+	defaultImageTag = "latest"
+	// imageArchitecture is the architecture of the images looked up.
+	imageArchitecture = "amd64"
+)
+
+// Severities of the vulnerabilities, from the most to the least severe.
+const (
+	SeverityCritical  = "Critical"
+	SeverityImportant = "Important"
+	SeverityModerate  = "Moderate"
+	SeverityLow       = "Low"
+)
 
 var (
 	dataKey = "data"
@@ -28,9 +47,19 @@ type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// ContainerCatalog looks container images up in the certified container catalog, either the catalog API or an
+// offline snapshot of it.
+type ContainerCatalog interface {
+	GetContainerCatalogEntry(id configsections.ContainerImageIdentifier) (*ContainerCatalogEntry, error)
+	GetVulnerabilities(entry *ContainerCatalogEntry) ([]ContainerImageVulnerability, error)
+	GetRPMManifest(entry *ContainerCatalogEntry) (*ContainerImageRPMManifest, error)
+}
+
 // CertAPIClient is http client to handle `pyxis` rest api
 type CertAPIClient struct {
 	Client HTTPClient
+	// BaseURL is the base URL of the catalog API, DefaultCatalogURL when empty.
+	BaseURL string
 }
 
 // NewHTTPClient return new http client
@@ -38,115 +67,158 @@ func NewHTTPClient() CertAPIClient {
 	return CertAPIClient{Client: &http.Client{}}
 }
 
+func (api CertAPIClient) baseURL() string {
+	if api.BaseURL == "" {
+		return DefaultCatalogURL
+	}
+	return api.BaseURL
+}
+
 type catalogQueryResponse struct {
 	Page     uint `json:"page"`
 	PageSize uint `json:"page_size"`
 	Total    uint `json:"total"`
 }
 
+// CatalogLink links a record of the catalog to a related one, relative to the base URL of the catalog API.
+type CatalogLink struct {
+	Href string `json:"href"`
+}
+
+// ContainerImageFreshnessGrade is the health index of an image over a period of time, from A to F.  The grade
+// worsens as the security fixes the image is missing age.  The last grade has no end date.
 type ContainerImageFreshnessGrade struct {
-	// CreationDate time.Time `json:"creation_date"`
-	Grade string `json:"grade"`
-	// StartDate    time.Time `json:"start_date"`
+	CreationDate time.Time `json:"creation_date"`
+	Grade        string    `json:"grade"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date,omitempty"`
+}
+
+// ContainerImageBrew is the build of an image.
+type ContainerImageBrew struct {
+	Build          string    `json:"build"`
+	CompletionDate time.Time `json:"completion_date"`
+	Nvra           string    `json:"nvra"`
+	Package        string    `json:"package"`
+}
+
+// ContainerImageLabel is a label of an image.
+type ContainerImageLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ContainerImageLayerSize is the uncompressed size of a layer of an image.
+type ContainerImageLayerSize struct {
+	LayerID   string `json:"layer_id"`
+	SizeBytes int    `json:"size_bytes"`
+}
+
+// ContainerImageParsedData is the metadata read from an image.
+type ContainerImageParsedData struct {
+	Architecture           string                    `json:"architecture"`
+	Command                string                    `json:"command"`
+	Comment                string                    `json:"comment"`
+	Created                time.Time                 `json:"created"`
+	DockerVersion          string                    `json:"docker_version"`
+	EnvVariables           []string                  `json:"env_variables"`
+	Labels                 []ContainerImageLabel     `json:"labels"`
+	Layers                 []string                  `json:"layers"`
+	Os                     string                    `json:"os"`
+	Size                   int                       `json:"size"`
+	UncompressedLayerSizes []ContainerImageLayerSize `json:"uncompressed_layer_sizes"`
+	UncompressedSizeBytes  int                       `json:"uncompressed_size_bytes"`
+	User                   string                    `json:"user"`
+}
+
+// ContainerImageAdvisoryRPMs lists the advisories fixed by an RPM.
+type ContainerImageAdvisoryRPMs struct {
+	AdvisoryIds []string `json:"advisory_ids"`
+	Nvra        string   `json:"nvra"`
 }
+
+// ContainerImageComparison compares an image with the previous one of its repository.
+type ContainerImageComparison struct {
+	AdvisoryRpmMapping []ContainerImageAdvisoryRPMs `json:"advisory_rpm_mapping"`
+	Reason             string                       `json:"reason"`
+	ReasonText         string                       `json:"reason_text"`
+	Rpms               struct {
+		Downgrade []string `json:"downgrade"`
+		New       []string `json:"new"`
+		Remove    []string `json:"remove"`
+		Upgrade   []string `json:"upgrade"`
+	} `json:"rpms"`
+	WithNvr string `json:"with_nvr"`
+}
+
+// ContainerImageSignature lists the tags of an image signed with a key.
+type ContainerImageSignature struct {
+	KeyLongID string   `json:"key_long_id"`
+	Tags      []string `json:"tags"`
+}
+
+// ContainerImageTag is a tag of an image in a repository.
+type ContainerImageTag struct {
+	Links struct {
+		TagHistory CatalogLink `json:"tag_history"`
+	} `json:"_links"`
+	AddedDate time.Time `json:"added_date"`
+	Name      string    `json:"name"`
+}
+
+// ContainerImageRepository is a repository an image is pushed to.
+type ContainerImageRepository struct {
+	Links struct {
+		ImageAdvisory CatalogLink `json:"image_advisory"`
+		Repository    CatalogLink `json:"repository"`
+	} `json:"_links"`
+	Comparison            ContainerImageComparison  `json:"comparison"`
+	ContentAdvisoryIds    []string                  `json:"content_advisory_ids"`
+	ImageAdvisoryID       string                    `json:"image_advisory_id"`
+	ManifestListDigest    string                    `json:"manifest_list_digest"`
+	ManifestSchema2Digest string                    `json:"manifest_schema2_digest"`
+	Published             bool                      `json:"published"`
+	PublishedDate         time.Time                 `json:"published_date"`
+	PushDate              time.Time                 `json:"push_date"`
+	Registry              string                    `json:"registry"`
+	Repository            string                    `json:"repository"`
+	Signatures            []ContainerImageSignature `json:"signatures"`
+	Tags                  []ContainerImageTag       `json:"tags"`
+}
+
+// HasTag returns whether the image is tagged tag in the repository.
+func (r *ContainerImageRepository) HasTag(tag string) bool {
+	for i := range r.Tags {
+		if r.Tags[i].Name == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainerCatalogEntry is the record of an image in the catalog.
 type ContainerCatalogEntry struct {
-	ID string `json:"_id"`
-	/*Links struct {
-		RpmManifest struct {
-			Href string `json:"href"`
-		} `json:"rpm_manifest"`
-		Vulnerabilities struct {
-			Href string `json:"href"`
-		} `json:"vulnerabilities"`
+	ID    string `json:"_id"`
+	Links struct {
+		RpmManifest     CatalogLink `json:"rpm_manifest"`
+		Vulnerabilities CatalogLink `json:"vulnerabilities"`
 	} `json:"_links"`
-	Architecture string `json:"architecture"`
-	Brew         struct {
-		Build          string    `json:"build"`
-		CompletionDate time.Time `json:"completion_date"`
-		Nvra           string    `json:"nvra"`
-		Package        string    `json:"package"`
-	} `json:"brew"`
-	Certified       bool      `json:"certified"`
-	ContentSets     []string  `json:"content_sets"`
-	CpeIds          []string  `json:"cpe_ids"`
-	CreationDate    time.Time `json:"creation_date"`
-	DockerImageID   string    `json:"docker_image_id"`*/
-	FreshnessGrades []ContainerImageFreshnessGrade `json:"freshness_grades"`
-	/*
-		ImageID        string    `json:"image_id"`
-		LastUpdateDate time.Time `json:"last_update_date"`
-		ObjectType     string    `json:"object_type"`
-		ParsedData     struct {
-			Architecture  string    `json:"architecture"`
-			Command       string    `json:"command"`
-			Comment       string    `json:"comment"`
-			Created       time.Time `json:"created"`
-			DockerVersion string    `json:"docker_version"`
-			EnvVariables  []string  `json:"env_variables"`
-			Labels        []struct {
-				Name  string `json:"name"`
-				Value string `json:"value"`
-			} `json:"labels"`
-			Layers                 []string `json:"layers"`
-			Os                     string   `json:"os"`
-			Size                   int      `json:"size"`
-			UncompressedLayerSizes []struct {
-				LayerID   string `json:"layer_id"`
-				SizeBytes int    `json:"size_bytes"`
-			} `json:"uncompressed_layer_sizes"`
-			UncompressedSizeBytes int    `json:"uncompressed_size_bytes"`
-			User                  string `json:"user"`
-		} `json:"parsed_data"`
-		Repositories []struct {
-			Links struct {
-				ImageAdvisory struct {
-					Href string `json:"href"`
-				} `json:"image_advisory"`
-				Repository struct {
-					Href string `json:"href"`
-				} `json:"repository"`
-			} `json:"_links"`
-			Comparison struct {
-				AdvisoryRpmMapping []struct {
-					AdvisoryIds []string `json:"advisory_ids"`
-					Nvra        string   `json:"nvra"`
-				} `json:"advisory_rpm_mapping"`
-				Reason     string `json:"reason"`
-				ReasonText string `json:"reason_text"`
-				Rpms       struct {
-					Downgrade []interface{} `json:"downgrade"`
-					New       []string      `json:"new"`
-					Remove    []string      `json:"remove"`
-					Upgrade   []string      `json:"upgrade"`
-				} `json:"rpms"`
-				WithNvr string `json:"with_nvr"`
-			} `json:"comparison"`
-			ContentAdvisoryIds    []string  `json:"content_advisory_ids"`
-			ImageAdvisoryID       string    `json:"image_advisory_id"`
-			ManifestListDigest    string    `json:"manifest_list_digest"`
-			ManifestSchema2Digest string    `json:"manifest_schema2_digest"`
-			Published             bool      `json:"published"`
-			PublishedDate         time.Time `json:"published_date"`
-			PushDate              time.Time `json:"push_date"`
-			Registry              string    `json:"registry"`
-			Repository            string    `json:"repository"`
-			Signatures            []struct {
-				KeyLongID string   `json:"key_long_id"`
-				Tags      []string `json:"tags"`
-			} `json:"signatures"`
-			Tags []struct {
-				Links struct {
-					TagHistory struct {
-						Href string `json:"href"`
-					} `json:"tag_history"`
-				} `json:"_links"`
-				AddedDate time.Time `json:"added_date"`
-				Name      string    `json:"name"`
-			} `json:"tags"`
-		} `json:"repositories"`
-		SumLayerSizeBytes      int    `json:"sum_layer_size_bytes"`
-		TopLayerID             string `json:"top_layer_id"`
-		UncompressedTopLayerID string `json:"uncompressed_top_layer_id"`*/
+	Architecture           string                         `json:"architecture"`
+	Brew                   ContainerImageBrew             `json:"brew"`
+	Certified              bool                           `json:"certified"`
+	ContentSets            []string                       `json:"content_sets"`
+	CpeIds                 []string                       `json:"cpe_ids"`
+	CreationDate           time.Time                      `json:"creation_date"`
+	DockerImageID          string                         `json:"docker_image_id"`
+	FreshnessGrades        []ContainerImageFreshnessGrade `json:"freshness_grades"`
+	ImageID                string                         `json:"image_id"`
+	LastUpdateDate         time.Time                      `json:"last_update_date"`
+	ObjectType             string                         `json:"object_type"`
+	ParsedData             ContainerImageParsedData       `json:"parsed_data"`
+	Repositories           []ContainerImageRepository     `json:"repositories"`
+	SumLayerSizeBytes      int                            `json:"sum_layer_size_bytes"`
+	TopLayerID             string                         `json:"top_layer_id"`
+	UncompressedTopLayerID string                         `json:"uncompressed_top_layer_id"`
 }
 
 func (e ContainerCatalogEntry) GetBestFreshnessGrade() string {
@@ -159,6 +231,85 @@ func (e ContainerCatalogEntry) GetBestFreshnessGrade() string {
 	return grade
 }
 
+// GetFreshnessGrade returns the grade of the image at the given time, "F" if no grade covers it.
+func (e ContainerCatalogEntry) GetFreshnessGrade(at time.Time) string {
+	for _, g := range e.FreshnessGrades {
+		if !at.Before(g.StartDate) && (g.EndDate.IsZero() || at.Before(g.EndDate)) {
+			return g.Grade
+		}
+	}
+	return "F"
+}
+
+// GetPublishedRepository returns the record of the repository, e.g. "rhel8/nginx-120", the image is published in, nil
+// if it is not.
+func (e ContainerCatalogEntry) GetPublishedRepository(repository string) *ContainerImageRepository {
+	for i := range e.Repositories {
+		if e.Repositories[i].Repository == repository && e.Repositories[i].Published {
+			return &e.Repositories[i]
+		}
+	}
+	return nil
+}
+
+// VulnerablePackage lists the RPMs of an image affected by a vulnerability, built from the same source RPM.
+type VulnerablePackage struct {
+	RpmNvra   []string `json:"rpm_nvra"`
+	SrpmNevra string   `json:"srpm_nevra"`
+}
+
+// ContainerImageVulnerability is a vulnerability, i.e. a CVE, affecting an image.
+type ContainerImageVulnerability struct {
+	CveID        string              `json:"cve_id"`
+	Severity     string              `json:"severity"`
+	PublicDate   time.Time           `json:"public_date"`
+	AdvisoryID   string              `json:"advisory_id"`
+	AdvisoryType string              `json:"advisory_type"`
+	Packages     []VulnerablePackage `json:"packages"`
+}
+
+// GetAffectedRPMs returns the RPMs affected by the vulnerability, limited to the ones in the manifest if not nil.
+func (v *ContainerImageVulnerability) GetAffectedRPMs(manifest *ContainerImageRPMManifest) []string {
+	rpms := []string{}
+	for _, p := range v.Packages {
+		for _, nvra := range p.RpmNvra {
+			if manifest == nil || manifest.HasRPM(nvra) {
+				rpms = append(rpms, nvra)
+			}
+		}
+	}
+	return rpms
+}
+
+// ContainerImageRPM is an RPM installed in an image.
+type ContainerImageRPM struct {
+	Architecture string `json:"architecture"`
+	Gpg          string `json:"gpg"`
+	Name         string `json:"name"`
+	Nvra         string `json:"nvra"`
+	Release      string `json:"release"`
+	SrpmName     string `json:"srpm_name"`
+	Summary      string `json:"summary"`
+	Version      string `json:"version"`
+}
+
+// ContainerImageRPMManifest lists the RPMs installed in an image.
+type ContainerImageRPMManifest struct {
+	ID      string              `json:"_id"`
+	ImageID string              `json:"image_id"`
+	Rpms    []ContainerImageRPM `json:"rpms"`
+}
+
+// HasRPM returns whether the RPM of the given name-version-release.architecture is installed in the image.
+func (m *ContainerImageRPMManifest) HasRPM(nvra string) bool {
+	for i := range m.Rpms {
+		if m.Rpms[i].Nvra == nvra {
+			return true
+		}
+	}
+	return false
+}
+
 type containerCatalogQueryResponse struct {
 	catalogQueryResponse
 	Data []ContainerCatalogEntry `json:"data"`
@@ -166,7 +317,7 @@ type containerCatalogQueryResponse struct {
 
 // GetContainerCatalogEntry gets the container image entry with highest freshness grade
 func (api CertAPIClient) GetContainerCatalogEntry(id configsections.ContainerImageIdentifier) (*ContainerCatalogEntry, error) {
-	responseData, err := api.getRequest(CreateContainerCatalogQueryURL(id))
+	responseData, err := api.getRequest(createContainerCatalogQueryURL(api.baseURL(), id))
 	if err == nil {
 		var response containerCatalogQueryResponse
 		err = json.Unmarshal(responseData, &response)
@@ -177,22 +328,63 @@ func (api CertAPIClient) GetContainerCatalogEntry(id configsections.ContainerIma
 	return nil, err
 }
 
+// CreateContainerCatalogQueryURL returns the URL of the external catalog API query of the image.
 func CreateContainerCatalogQueryURL(id configsections.ContainerImageIdentifier) string {
+	return createContainerCatalogQueryURL(DefaultCatalogURL, id)
+}
+
+func createContainerCatalogQueryURL(baseURL string, id configsections.ContainerImageIdentifier) string {
 	var url string
-	const defaultTag = "latest"
-	const arch = "amd64"
+	endPoint := baseURL + apiCatalogByRepositoriesPath
 	if id.Digest == "" {
 		if id.Tag == "" {
-			id.Tag = defaultTag
+			id.Tag = defaultImageTag
 		}
 		url = fmt.Sprintf("%s/%s/%s/images?filter=architecture==%s;repositories.repository==%s/%s;repositories.tags.name==%s",
-			apiCatalogByRepositoriesBaseEndPoint, id.Repository, id.Name, arch, id.Repository, id.Name, id.Tag)
+			endPoint, id.Repository, id.Name, imageArchitecture, id.Repository, id.Name, id.Tag)
 	} else {
-		url = fmt.Sprintf("%s/%s/%s/images?filter=architecture==%s;image_id==%s", apiCatalogByRepositoriesBaseEndPoint, id.Repository, id.Name, arch, id.Digest)
+		url = fmt.Sprintf("%s/%s/%s/images?filter=architecture==%s;image_id==%s", endPoint, id.Repository, id.Name, imageArchitecture, id.Digest)
 	}
 	return url
 }
 
+type vulnerabilitiesQueryResponse struct {
+	catalogQueryResponse
+	Data []ContainerImageVulnerability `json:"data"`
+}
+
+// GetVulnerabilities gets the vulnerabilities affecting the image, following the vulnerabilities link of its entry
+// page by page.
+func (api CertAPIClient) GetVulnerabilities(entry *ContainerCatalogEntry) ([]ContainerImageVulnerability, error) {
+	if entry.Links.Vulnerabilities.Href == "" {
+		return nil, fmt.Errorf("image %s has no vulnerabilities link", entry.ID)
+	}
+	vulnerabilities := []ContainerImageVulnerability{}
+	for page := 0; ; page++ {
+		url := fmt.Sprintf("%s%s?page_size=%d&page=%d", api.baseURL(), entry.Links.Vulnerabilities.Href, vulnerabilitiesPageSize, page)
+		var response vulnerabilitiesQueryResponse
+		if err := api.getJSON(url, &response); err != nil {
+			return nil, err
+		}
+		vulnerabilities = append(vulnerabilities, response.Data...)
+		if len(response.Data) == 0 || uint(len(vulnerabilities)) >= response.Total {
+			return vulnerabilities, nil
+		}
+	}
+}
+
+// GetRPMManifest gets the RPMs installed in the image, following the RPM manifest link of its entry.
+func (api CertAPIClient) GetRPMManifest(entry *ContainerCatalogEntry) (*ContainerImageRPMManifest, error) {
+	if entry.Links.RpmManifest.Href == "" {
+		return nil, fmt.Errorf("image %s has no RPM manifest link", entry.ID)
+	}
+	var manifest ContainerImageRPMManifest
+	if err := api.getJSON(api.baseURL()+entry.Links.RpmManifest.Href, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
 // IsOperatorCertified get operator bundle by package name and check if package details is present
 // If present then returns `true` as certified operators.
 func (api CertAPIClient) IsOperatorCertified(org, packageName string) (bool, error) {
@@ -209,7 +401,7 @@ func (api CertAPIClient) IsOperatorCertified(org, packageName string) (bool, err
 // GetImageByID get container image data for the given container Id.  Returns (response, error).
 func (api CertAPIClient) GetImageByID(id string) (string, error) {
 	var response string
-	url := fmt.Sprintf("%s/images/id/%s", apiContainerCatalogExternalBaseEndPoint, id)
+	url := fmt.Sprintf("%s%s/images/id/%s", api.baseURL(), apiContainerCatalogPath, id)
 	responseData, err := api.getRequest(url)
 	if err == nil {
 		response = string(responseData)
@@ -221,7 +413,7 @@ func (api CertAPIClient) GetImageByID(id string) (string, error) {
 // Returns (ImageID, error).
 func (api CertAPIClient) GetOperatorBundleIDByPackageName(org, name string) (string, error) {
 	var imageID string
-	url := fmt.Sprintf("%s/bundles?page_size=1&organization=%s&package=%s", apiOperatorCatalogExternalBaseEndPoint, org, name)
+	url := fmt.Sprintf("%s%s/bundles?page_size=1&organization=%s&package=%s", api.baseURL(), apiOperatorCatalogPath, org, name)
 	responseData, err := api.getRequest(url)
 	if err == nil {
 		imageID, err = api.getIDFromResponse(responseData)
@@ -247,6 +439,30 @@ func (api CertAPIClient) getRequest(url string) ([]byte, error) {
 	return response, nil
 }
 
+// getJSON a http call to rest api, unmarshalling the response into v.  Unlike getRequest, it fails on an error status.
+func (api CertAPIClient) getJSON(url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("request %s failed with status %d", url, resp.StatusCode)
+	}
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(response, v); err != nil {
+		return fmt.Errorf("error unmarshalling the response of %s: %v", url, err)
+	}
+	return nil
+}
+
 // getIDFromResponse searches for first occurrence of id and return. Returns (id and error).
 func (api CertAPIClient) getIDFromResponse(response []byte) (string, error) {
 	var data interface{}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/test-network-function/test-network-function/internal/api"
@@ -14,6 +18,7 @@ import (
 
 const (
 	id                 = "5ea8cf595a13466876a10215"
+	imageID            = "61ba0db5d095e30ed5db6330"
 	imageName          = "nginx-120"
 	marketPlaceOrg     = "redhat-marketplace"
 	packageName        = "amq-streams"
@@ -482,15 +487,14 @@ var (
 		name           string
 		id             string
 		expectedError  error
-		expectedResult *api.ContainerCatalogEntry
+		expectedID     string
+		expectedGrade  string
 		responseData   string
 		responseStatus int
 	}{
-		{repository: repository, name: imageName, expectedError: nil, id: "",
-			expectedResult: &api.ContainerCatalogEntry{ID: "61ba0db5d095e30ed5db6330",
-				FreshnessGrades: []api.ContainerImageFreshnessGrade{{Grade: "A"}}},
+		{repository: repository, name: imageName, expectedError: nil, id: "", expectedID: imageID, expectedGrade: "A",
 			responseData: jsonResponseFound, responseStatus: http.StatusAccepted},
-		{repository: unKnownRepository, name: unKnownImageName, expectedError: nil, id: "", expectedResult: nil,
+		{repository: unKnownRepository, name: unKnownImageName, expectedError: nil, id: "", expectedID: "",
 			responseData: jsonResponseNotFound, responseStatus: http.StatusAccepted},
 	}
 
@@ -544,8 +548,13 @@ func TestApiClient_GetContainerCatalogEntry(t *testing.T) {
 	for _, c := range containerTestCases {
 		GetDoFunc = getDoFunc(c.responseData, c.responseStatus) //nolint:bodyclose
 		result, err := client.GetContainerCatalogEntry(configsections.ContainerImageIdentifier{Repository: c.repository, Name: c.name})
-		assert.Equal(t, c.expectedResult, result)
 		assert.Equal(t, c.expectedError, err)
+		if c.expectedID == "" {
+			assert.Nil(t, result)
+		} else {
+			assert.Equal(t, c.expectedID, result.ID)
+			assert.Equal(t, c.expectedGrade, result.GetBestFreshnessGrade())
+		}
 	}
 }
 
@@ -594,3 +603,109 @@ func TestCertApiClient_Find(t *testing.T) {
 		assert.Equal(t, id, fmt.Sprintf("%v", val))
 	}
 }
+
+// newCatalogServer starts a stand-in of the catalog API serving the nginx-120 image, its two pages of vulnerabilities
+// and its RPM manifest.
+func newCatalogServer(t *testing.T) *httptest.Server {
+	vulnerabilityPages := []string{
+		`{"data": [{"cve_id": "CVE-2021-3712", "severity": "Moderate", "packages": [{"rpm_nvra": ["openssl-libs-1.1.1k-4.el8.x86_64"]}]}],
+		  "page": 0, "page_size": 1, "total": 2}`,
+		`{"data": [{"cve_id": "CVE-2021-43527", "severity": "Critical", "advisory_id": "RHSA-2021:4903",
+		  "packages": [{"rpm_nvra": ["nss-3.67.0-6.el8_4.x86_64", "nss-util-3.67.0-6.el8_4.x86_64"]}]}],
+		  "page": 1, "page_size": 1, "total": 2}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/repositories/registry/registry.access.redhat.com/repository/rhel8/nginx-120/images":
+			if !strings.Contains(r.URL.RawQuery, "repositories.tags.name==1-7") {
+				fmt.Fprint(w, jsonResponseNotFound)
+				return
+			}
+			fmt.Fprint(w, jsonResponseFound)
+		case "/v1/images/id/" + imageID + "/vulnerabilities":
+			page, err := strconv.Atoi(r.URL.Query().Get("page"))
+			if err != nil || page >= len(vulnerabilityPages) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, vulnerabilityPages[page])
+		case "/v1/images/id/" + imageID + "/rpm-manifest":
+			fmt.Fprint(w, `{"_id": "61ba0db9", "image_id": "`+imageID+`", "rpms": [{"name": "nss", "nvra": "nss-3.67.0-6.el8_4.x86_64"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCertAPIClient_CatalogServer(t *testing.T) {
+	server := newCatalogServer(t)
+	catalog := api.CertAPIClient{Client: server.Client(), BaseURL: server.URL}
+
+	entry, err := catalog.GetContainerCatalogEntry(configsections.ContainerImageIdentifier{Repository: repository, Name: imageName, Tag: "1-7"})
+	assert.Nil(t, err)
+	assert.Equal(t, imageID, entry.ID)
+	assert.Equal(t, "amd64", entry.Architecture)
+	assert.Equal(t, time.Date(2021, 12, 15, 15, 45, 57, 616000000, time.UTC), entry.CreationDate.UTC())
+	assert.Len(t, entry.Repositories, 2)
+	published := entry.GetPublishedRepository("rhel8/nginx-120")
+	assert.NotNil(t, published)
+	assert.Equal(t, "199E2F91FD431D51", published.Signatures[0].KeyLongID)
+
+	vulnerabilities, err := catalog.GetVulnerabilities(entry)
+	assert.Nil(t, err)
+	assert.Len(t, vulnerabilities, 2)
+	assert.Equal(t, "CVE-2021-43527", vulnerabilities[1].CveID)
+	assert.Equal(t, api.SeverityCritical, vulnerabilities[1].Severity)
+
+	manifest, err := catalog.GetRPMManifest(entry)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nss-3.67.0-6.el8_4.x86_64"}, vulnerabilities[1].GetAffectedRPMs(manifest))
+	assert.Equal(t, []string{"nss-3.67.0-6.el8_4.x86_64", "nss-util-3.67.0-6.el8_4.x86_64"}, vulnerabilities[1].GetAffectedRPMs(nil))
+
+	entry, err = catalog.GetContainerCatalogEntry(configsections.ContainerImageIdentifier{Repository: repository, Name: imageName})
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+
+	missing := &api.ContainerCatalogEntry{ID: "missing"}
+	missing.Links.RpmManifest.Href = "/v1/images/id/missing/rpm-manifest"
+	_, err = catalog.GetRPMManifest(missing)
+	assert.NotNil(t, err)
+	_, err = catalog.GetVulnerabilities(missing)
+	assert.NotNil(t, err)
+}
+
+func TestContainerCatalogEntry_GetFreshnessGrade(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2022, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	entry := api.ContainerCatalogEntry{FreshnessGrades: []api.ContainerImageFreshnessGrade{
+		{Grade: "A", StartDate: day(1), EndDate: day(10)},
+		{Grade: "C", StartDate: day(10), EndDate: day(20)},
+		{Grade: "D", StartDate: day(20)},
+	}}
+	testCases := []struct {
+		at            time.Time
+		expectedGrade string
+	}{
+		{at: day(1), expectedGrade: "A"},
+		{at: day(10), expectedGrade: "C"},
+		{at: day(25), expectedGrade: "D"},
+		{at: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), expectedGrade: "F"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedGrade, entry.GetFreshnessGrade(tc.at))
+	}
+	assert.Equal(t, "A", entry.GetBestFreshnessGrade())
+}
+
+func TestContainerCatalogEntry_GetPublishedRepository(t *testing.T) {
+	entry := api.ContainerCatalogEntry{Repositories: []api.ContainerImageRepository{
+		{Repository: "rhel8/nginx-118", Published: false},
+		{Repository: "rhel8/nginx-120", Published: true},
+	}}
+	assert.Equal(t, &entry.Repositories[1], entry.GetPublishedRepository("rhel8/nginx-120"))
+	assert.Nil(t, entry.GetPublishedRepository("rhel8/nginx-118"))
+	assert.Nil(t, entry.GetPublishedRepository("rhel8/nginx-116"))
+}
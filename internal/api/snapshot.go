@@ -0,0 +1,112 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+// SnapshotImage is the record of an image in an offline snapshot of the catalog.  Besides the fields of the catalog
+// record, it holds the vulnerabilities and the RPM manifest of the image, which the catalog API serves separately.
+type SnapshotImage struct {
+	ContainerCatalogEntry
+	Vulnerabilities []ContainerImageVulnerability `json:"vulnerabilities"`
+	RPMManifest     *ContainerImageRPMManifest    `json:"rpm_manifest,omitempty"`
+}
+
+// CatalogSnapshot is an offline snapshot of the catalog, for clusters that cannot reach the catalog API.  It has the
+// layout of the response to an image query of the catalog API, so that the responses for the images of the CNF can be
+// saved as is and completed with their vulnerabilities and RPM manifests.
+type CatalogSnapshot struct {
+	Images []SnapshotImage `json:"data"`
+}
+
+// LoadCatalogSnapshot reads the snapshot of the catalog saved in file.
+func LoadCatalogSnapshot(file string) (*CatalogSnapshot, error) {
+	payload, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot CatalogSnapshot
+	if err = json.Unmarshal(payload, &snapshot); err != nil {
+		return nil, fmt.Errorf("error reading the catalog snapshot %s: %v", file, err)
+	}
+	return &snapshot, nil
+}
+
+// matches returns whether the image is the one the catalog API returns for id, i.e. an amd64 image with the digest
+// of id if any, else with the tag of id in its repository.
+func (i *SnapshotImage) matches(id configsections.ContainerImageIdentifier) bool {
+	if i.Architecture != imageArchitecture {
+		return false
+	}
+	if id.Digest != "" {
+		return i.ImageID == id.Digest
+	}
+	tag := id.Tag
+	if tag == "" {
+		tag = defaultImageTag
+	}
+	for j := range i.Repositories {
+		if i.Repositories[j].Repository == id.Repository+"/"+id.Name && i.Repositories[j].HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContainerCatalogEntry gets the first image of the snapshot matching id, nil if none.
+func (s *CatalogSnapshot) GetContainerCatalogEntry(id configsections.ContainerImageIdentifier) (*ContainerCatalogEntry, error) {
+	for i := range s.Images {
+		if s.Images[i].matches(id) {
+			return &s.Images[i].ContainerCatalogEntry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *CatalogSnapshot) getImage(entry *ContainerCatalogEntry) *SnapshotImage {
+	for i := range s.Images {
+		if s.Images[i].ID == entry.ID {
+			return &s.Images[i]
+		}
+	}
+	return nil
+}
+
+// GetVulnerabilities gets the vulnerabilities of the image from the snapshot.  It fails if the snapshot does not list
+// them, as opposed to listing none.
+func (s *CatalogSnapshot) GetVulnerabilities(entry *ContainerCatalogEntry) ([]ContainerImageVulnerability, error) {
+	image := s.getImage(entry)
+	if image == nil || image.Vulnerabilities == nil {
+		return nil, fmt.Errorf("the catalog snapshot has no vulnerabilities for image %s", entry.ID)
+	}
+	return image.Vulnerabilities, nil
+}
+
+// GetRPMManifest gets the RPMs installed in the image from the snapshot.
+func (s *CatalogSnapshot) GetRPMManifest(entry *ContainerCatalogEntry) (*ContainerImageRPMManifest, error) {
+	image := s.getImage(entry)
+	if image == nil || image.RPMManifest == nil {
+		return nil, fmt.Errorf("the catalog snapshot has no RPM manifest for image %s", entry.ID)
+	}
+	return image.RPMManifest, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package api_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/internal/api"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestCatalogSnapshot(t *testing.T) {
+	snapshot, err := api.LoadCatalogSnapshot(filepath.Join("testdata", "catalog_snapshot.json"))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		id         configsections.ContainerImageIdentifier
+		expectedID string
+	}{
+		{id: configsections.ContainerImageIdentifier{Repository: "rhel8", Name: "nginx-120", Tag: "1-7"}, expectedID: "61ba0db5d095e30ed5db6330"},
+		{id: configsections.ContainerImageIdentifier{Repository: "rhel8", Name: "nginx-120"}, expectedID: "61ba0db5d095e30ed5db6330"},
+		{id: configsections.ContainerImageIdentifier{Repository: "rhel8", Name: "nginx-120", Tag: "1-5"}, expectedID: ""},
+		{id: configsections.ContainerImageIdentifier{Repository: "rhel8", Name: "nginx-118", Tag: "1-7"}, expectedID: ""},
+		{id: configsections.ContainerImageIdentifier{Repository: "rhel8", Name: "nginx-118",
+			Digest: "sha256:45b23dee08af5e43a7fea6c4cf9c25ccf269ee113168c19722f87876677c5cb2"}, expectedID: "61ba0db5d095e30ed5db6332"},
+		// Only amd64 images are looked up, as with the catalog API.
+		{id: configsections.ContainerImageIdentifier{Repository: "rhel8", Name: "nginx-120",
+			Digest: "sha256:d1c5a1d6d1a5c2d5e5f5a5b5c5d5e5f5a5b5c5d5e5f5a5b5c5d5e5f5a5b5c5d5"}, expectedID: ""},
+	}
+	for _, tc := range testCases {
+		entry, err := snapshot.GetContainerCatalogEntry(tc.id)
+		assert.Nil(t, err)
+		if tc.expectedID == "" {
+			assert.Nil(t, entry)
+		} else {
+			assert.Equal(t, tc.expectedID, entry.ID)
+		}
+	}
+
+	entry, _ := snapshot.GetContainerCatalogEntry(testCases[0].id)
+	vulnerabilities, err := snapshot.GetVulnerabilities(entry)
+	assert.Nil(t, err)
+	assert.Equal(t, "CVE-2021-43527", vulnerabilities[0].CveID)
+	manifest, err := snapshot.GetRPMManifest(entry)
+	assert.Nil(t, err)
+	assert.True(t, manifest.HasRPM("nss-3.67.0-6.el8_4.x86_64"))
+
+	entry, _ = snapshot.GetContainerCatalogEntry(testCases[4].id)
+	vulnerabilities, err = snapshot.GetVulnerabilities(entry)
+	assert.Nil(t, err)
+	assert.Empty(t, vulnerabilities)
+	_, err = snapshot.GetRPMManifest(entry)
+	assert.NotNil(t, err)
+
+	_, err = snapshot.GetVulnerabilities(&api.ContainerCatalogEntry{ID: "missing"})
+	assert.NotNil(t, err)
+	_, err = api.LoadCatalogSnapshot(filepath.Join("testdata", "missing.json"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package availability
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Sample is the state of a podset observed at a given time.
+type Sample struct {
+	Time time.Time
+	// Ready is the number of ready pods.
+	Ready int
+	// Probed is the number of pods probed for connectivity, Reachable the number of them that answered.
+	Probed    int
+	Reachable int
+}
+
+// Unavailable returns why no pod of the podset could serve at the time of s, or an empty string when some could.  A
+// sample without probed pods is judged on readiness only.
+func (s *Sample) Unavailable() string {
+	if s.Ready == 0 {
+		return "no pod ready"
+	}
+	if s.Probed > 0 && s.Reachable == 0 {
+		return fmt.Sprintf("none of the %d pods answered", s.Probed)
+	}
+	return ""
+}
+
+// Gap is a period during which no pod of a podset could serve.
+type Gap struct {
+	Start time.Time
+	End   time.Time
+	// Reason is why no pod could serve at the start of the gap.
+	Reason string
+}
+
+func (g *Gap) String() string {
+	return fmt.Sprintf("%s from %s to %s (%s)", g.Reason, g.Start.Format(time.RFC3339), g.End.Format(time.RFC3339),
+		g.End.Sub(g.Start))
+}
+
+// Gaps returns the gaps in samples, taken in order.  A gap lasts from the first sample it is observed at to the next
+// sample some pod could serve at, or to the last sample.
+func Gaps(samples []Sample) []Gap {
+	var gaps []Gap
+	var current *Gap
+	for i := range samples {
+		s := &samples[i]
+		reason := s.Unavailable()
+		switch {
+		case reason != "" && current == nil:
+			current = &Gap{Start: s.Time, End: s.Time, Reason: reason}
+		case reason != "":
+			current.End = s.Time
+		case current != nil:
+			current.End = s.Time
+			gaps = append(gaps, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		gaps = append(gaps, *current)
+	}
+	return gaps
+}
+
+// Reason returns a one-line summary of the gaps, for the claim.
+func Reason(gaps []Gap) string {
+	reasons := make([]string, len(gaps))
+	for i := range gaps {
+		reasons[i] = gaps[i].String()
+	}
+	return strings.Join(reasons, "; ")
+}
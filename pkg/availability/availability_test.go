@@ -0,0 +1,64 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnavailable(t *testing.T) {
+	testCases := []struct {
+		sample   Sample
+		expected string
+	}{
+		{sample: Sample{Ready: 2, Probed: 2, Reachable: 1}},
+		{sample: Sample{Ready: 1}},
+		{sample: Sample{Ready: 0, Probed: 1, Reachable: 1}, expected: "no pod ready"},
+		{sample: Sample{Ready: 2, Probed: 2}, expected: "none of the 2 pods answered"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.sample.Unavailable())
+	}
+}
+
+func TestGaps(t *testing.T) {
+	start := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	samples := []Sample{
+		{Time: at(0), Ready: 2, Probed: 2, Reachable: 2},
+		{Time: at(5), Ready: 2, Probed: 2},
+		{Time: at(10), Ready: 0},
+		{Time: at(15), Ready: 1, Probed: 1, Reachable: 1},
+		{Time: at(20), Ready: 1, Probed: 2, Reachable: 1},
+		{Time: at(25), Ready: 0},
+	}
+	gaps := Gaps(samples)
+	assert.Equal(t, []Gap{
+		{Start: at(5), End: at(15), Reason: "none of the 2 pods answered"},
+		{Start: at(25), End: at(25), Reason: "no pod ready"},
+	}, gaps)
+	assert.Equal(t, "none of the 2 pods answered from 2022-03-01T10:00:05Z to 2022-03-01T10:00:15Z (10s); "+
+		"no pod ready from 2022-03-01T10:00:25Z to 2022-03-01T10:00:25Z (0s)", Reason(gaps))
+
+	assert.Empty(t, Gaps(samples[:1]))
+	assert.Empty(t, Gaps(nil))
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package availability
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotRecovered is the recovery time of a podset that did not recover before the samples ended.
+const NotRecovered time.Duration = -1
+
+// Recovery is how long a podset took to recover from a disruption.
+type Recovery struct {
+	// Disruption describes the disruption, e.g. "pod tnf/test-0 deleted".
+	Disruption string
+	// Ready is the time from the disruption until all the replicas were ready again, Reachable the time until all
+	// the probed pods answered as well.  They are NotRecovered when that did not happen.
+	Ready     time.Duration
+	Reachable time.Duration
+	// Probed tells whether the connectivity of the pods was probed, Reachable is ignored otherwise.
+	Probed bool
+	// Observed is the time from the disruption to the last sample.
+	Observed time.Duration
+}
+
+// NewRecovery measures the recovery of a podset from the disruption described by disruption, which happened at
+// start, from the samples taken after it, in order.  The podset is ready once replicas pods are ready, and reachable
+// once all the pods probed then answered.
+func NewRecovery(disruption string, start time.Time, samples []Sample, replicas int, probed bool) Recovery {
+	r := Recovery{Disruption: disruption, Ready: NotRecovered, Reachable: NotRecovered, Probed: probed}
+	for i := range samples {
+		s := &samples[i]
+		r.Observed = s.Time.Sub(start)
+		if s.Ready < replicas {
+			continue
+		}
+		if r.Ready == NotRecovered {
+			r.Ready = r.Observed
+		}
+		if s.Reachable == s.Probed {
+			r.Reachable = r.Observed
+			break
+		}
+	}
+	return r
+}
+
+// Recovered returns whether the podset recovered from the disruption.
+func (r *Recovery) Recovered() bool {
+	return r.Ready != NotRecovered && (!r.Probed || r.Reachable != NotRecovered)
+}
+
+func (r *Recovery) String() string {
+	switch {
+	case r.Ready == NotRecovered:
+		return fmt.Sprintf("%s: not ready after %s", r.Disruption, r.Observed.Round(time.Second))
+	case !r.Probed:
+		return fmt.Sprintf("%s: ready after %s, connectivity not probed", r.Disruption, r.Ready.Round(time.Second))
+	case r.Reachable == NotRecovered:
+		return fmt.Sprintf("%s: ready after %s, not reachable after %s", r.Disruption, r.Ready.Round(time.Second),
+			r.Observed.Round(time.Second))
+	}
+	return fmt.Sprintf("%s: ready after %s, reachable after %s", r.Disruption, r.Ready.Round(time.Second),
+		r.Reachable.Round(time.Second))
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecovery(t *testing.T) {
+	start := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	testCases := []struct {
+		samples   []Sample
+		probed    bool
+		expected  Recovery
+		recovered bool
+		reason    string
+	}{
+		{
+			samples: []Sample{
+				{Time: at(2), Ready: 1, Probed: 1, Reachable: 1},
+				{Time: at(4), Ready: 2, Probed: 2, Reachable: 1},
+				{Time: at(6), Ready: 2, Probed: 2, Reachable: 2},
+			},
+			probed:    true,
+			expected:  Recovery{Disruption: "pod tnf/test-0 deleted", Ready: 4 * time.Second, Reachable: 6 * time.Second, Probed: true, Observed: 6 * time.Second},
+			recovered: true,
+			reason:    "pod tnf/test-0 deleted: ready after 4s, reachable after 6s",
+		},
+		{
+			samples:   []Sample{{Time: at(2), Ready: 1}, {Time: at(4), Ready: 2}},
+			expected:  Recovery{Disruption: "pod tnf/test-0 deleted", Ready: 4 * time.Second, Reachable: 4 * time.Second, Observed: 4 * time.Second},
+			recovered: true,
+			reason:    "pod tnf/test-0 deleted: ready after 4s, connectivity not probed",
+		},
+		{
+			samples:  []Sample{{Time: at(2), Ready: 1, Probed: 1}, {Time: at(300), Ready: 2, Probed: 2, Reachable: 1}},
+			probed:   true,
+			expected: Recovery{Disruption: "pod tnf/test-0 deleted", Ready: 300 * time.Second, Reachable: NotRecovered, Probed: true, Observed: 300 * time.Second},
+			reason:   "pod tnf/test-0 deleted: ready after 5m0s, not reachable after 5m0s",
+		},
+		{
+			samples:  []Sample{{Time: at(2), Ready: 1}, {Time: at(300), Ready: 1}},
+			expected: Recovery{Disruption: "pod tnf/test-0 deleted", Ready: NotRecovered, Reachable: NotRecovered, Observed: 300 * time.Second},
+			reason:   "pod tnf/test-0 deleted: not ready after 5m0s",
+		},
+	}
+	for _, tc := range testCases {
+		r := NewRecovery("pod tnf/test-0 deleted", start, tc.samples, 2, tc.probed)
+		assert.Equal(t, tc.expected, r)
+		assert.Equal(t, tc.recovered, r.Recovered())
+		assert.Equal(t, tc.reason, r.String())
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claimdiff
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+)
+
+const (
+	statePassed  = "passed"
+	stateSkipped = "skipped"
+	statePending = "pending"
+	// stateMissing is reported when a test has no result with a state.
+	stateMissing = "unknown"
+	// stateRemoved is the new state of a test that passed in the old claim and is missing from the new one.
+	stateRemoved = "removed"
+
+	nodeSummaryField = "nodeSummary"
+	cniPluginsField  = "cniPlugins"
+	csiDriverField   = "csiDriver"
+)
+
+// TestChange is a test whose state differs between the two claims.
+type TestChange struct {
+	TestID   string `json:"testID"`
+	OldState string `json:"oldState"`
+	NewState string `json:"newState"`
+	// Regression is set when the test passed in the old claim but not in the new one, including when the new claim
+	// does not have it.
+	Regression bool `json:"regression"`
+}
+
+// FieldDiff is a value that differs between the two claims. Old or New is empty when the value is missing.
+type FieldDiff struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// ListDiff holds the names found in only one of the two claims.
+type ListDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// NodesDiff holds the differences in the nodes section of the claims.
+type NodesDiff struct {
+	// CniPlugins holds the CNI plugins whose version changed, keyed by plugin name.
+	CniPlugins []FieldDiff `json:"cniPlugins,omitempty"`
+	CsiDrivers ListDiff    `json:"csiDrivers"`
+	Nodes      ListDiff    `json:"nodes"`
+	// NodeInfo holds the changed status.nodeInfo fields of the nodes found in both claims, as "node/field".
+	NodeInfo []FieldDiff `json:"nodeInfo,omitempty"`
+}
+
+// Diff holds the differences between two claims.
+type Diff struct {
+	ChangedTests []TestChange `json:"changedTests,omitempty"`
+	NewTests     []string     `json:"newTests,omitempty"`
+	RemovedTests []string     `json:"removedTests,omitempty"`
+	Versions     []FieldDiff  `json:"versions,omitempty"`
+	Nodes        NodesDiff    `json:"nodes"`
+}
+
+// Compare returns the differences from oldClaim to newClaim. A test that passed in oldClaim and is missing from
+// newClaim, e.g. because it was not selected, is a regression unless ignoreRemoved is set; it is listed with the
+// removed tests either way.
+func Compare(oldClaim, newClaim *claim.Claim, ignoreRemoved bool) *Diff {
+	diff := &Diff{}
+	diff.compareTests(oldClaim.Results, newClaim.Results, ignoreRemoved)
+	diff.Versions = compareVersions(oldClaim.Versions, newClaim.Versions)
+	diff.Nodes = compareNodes(oldClaim.Nodes, newClaim.Nodes)
+	return diff
+}
+
+// Regressions returns the tests that passed in the old claim but not in the new one, or are missing from it.
+func (d *Diff) Regressions() []TestChange {
+	var regressions []TestChange
+	for _, c := range d.ChangedTests {
+		if c.Regression {
+			regressions = append(regressions, c)
+		}
+	}
+	return regressions
+}
+
+func (d *Diff) compareTests(oldResults, newResults map[string]interface{}, ignoreRemoved bool) {
+	oldStates := testStates(oldResults)
+	newStates := testStates(newResults)
+	for _, id := range sortedKeys(newStates) {
+		oldState, ok := oldStates[id]
+		if !ok {
+			d.NewTests = append(d.NewTests, id)
+			continue
+		}
+		if newState := newStates[id]; newState != oldState {
+			d.ChangedTests = append(d.ChangedTests, TestChange{
+				TestID:     id,
+				OldState:   oldState,
+				NewState:   newState,
+				Regression: oldState == statePassed,
+			})
+		}
+	}
+	for _, id := range sortedKeys(oldStates) {
+		if _, ok := newStates[id]; ok {
+			continue
+		}
+		d.RemovedTests = append(d.RemovedTests, id)
+		if oldState := oldStates[id]; oldState == statePassed && !ignoreRemoved {
+			d.ChangedTests = append(d.ChangedTests, TestChange{TestID: id, OldState: oldState, NewState: stateRemoved, Regression: true})
+		}
+	}
+	sort.Slice(d.ChangedTests, func(i, j int) bool {
+		return d.ChangedTests[i].TestID < d.ChangedTests[j].TestID
+	})
+}
+
+// testStates maps each test of the claim results to its state. A test with several results gets the worst
+// state: a failure of any kind over skipped/pending over passed.
+func testStates(results map[string]interface{}) map[string]string {
+	states := map[string]string{}
+	for id, testResults := range results {
+		state := stateMissing
+		list, _ := testResults.([]interface{})
+		for _, r := range list {
+			result, _ := r.(map[string]interface{})
+			s, ok := result["state"].(string)
+			if !ok {
+				continue
+			}
+			if state == stateMissing || stateRank(s) > stateRank(state) {
+				state = s
+			}
+		}
+		states[id] = state
+	}
+	return states
+}
+
+func stateRank(state string) int {
+	switch state {
+	case statePassed:
+		return 0
+	case stateSkipped, statePending:
+		return 1
+	default:
+		return 2 //nolint:gomnd // failed, panicked, interrupted...
+	}
+}
+
+func compareVersions(oldVersions, newVersions *claim.Versions) []FieldDiff {
+	if oldVersions == nil {
+		oldVersions = &claim.Versions{}
+	}
+	if newVersions == nil {
+		newVersions = &claim.Versions{}
+	}
+	var diffs []FieldDiff
+	for _, v := range []FieldDiff{
+		{Name: "tnf", Old: oldVersions.Tnf, New: newVersions.Tnf},
+		{Name: "ocp", Old: oldVersions.Ocp, New: newVersions.Ocp},
+		{Name: "k8s", Old: oldVersions.K8s, New: newVersions.K8s},
+		{Name: "ocClient", Old: oldVersions.OcClient, New: newVersions.OcClient},
+	} {
+		if v.Old != v.New {
+			diffs = append(diffs, v)
+		}
+	}
+	return diffs
+}
+
+func compareNodes(oldNodes, newNodes map[string]interface{}) NodesDiff {
+	diff := NodesDiff{}
+
+	oldPlugins := cniPluginVersions(oldNodes[cniPluginsField])
+	newPlugins := cniPluginVersions(newNodes[cniPluginsField])
+	diff.CniPlugins = compareMaps(oldPlugins, newPlugins)
+
+	diff.CsiDrivers = compareNames(itemsByName(oldNodes[csiDriverField]), itemsByName(newNodes[csiDriverField]))
+
+	oldSummary := itemsByName(oldNodes[nodeSummaryField])
+	newSummary := itemsByName(newNodes[nodeSummaryField])
+	diff.Nodes = compareNames(oldSummary, newSummary)
+	for _, name := range sortedKeys(newSummary) {
+		oldNode, ok := oldSummary[name]
+		if !ok {
+			continue
+		}
+		for _, d := range compareMaps(nodeInfo(oldNode), nodeInfo(newSummary[name])) {
+			d.Name = name + "/" + d.Name
+			diff.NodeInfo = append(diff.NodeInfo, d)
+		}
+	}
+	return diff
+}
+
+// cniPluginVersions maps the plugins listed by the diagnostic suite to their version.
+func cniPluginVersions(plugins interface{}) map[string]string {
+	versions := map[string]string{}
+	list, _ := plugins.([]interface{})
+	for _, p := range list {
+		plugin, _ := p.(map[string]interface{})
+		name, _ := plugin["name"].(string)
+		if name == "" {
+			continue
+		}
+		versions[name] = fmt.Sprint(plugin["version"])
+	}
+	return versions
+}
+
+// itemsByName indexes the items of an `oc get -o json` list output by metadata.name.
+func itemsByName(list interface{}) map[string]map[string]interface{} {
+	byName := map[string]map[string]interface{}{}
+	l, _ := list.(map[string]interface{})
+	items, _ := l["items"].([]interface{})
+	for _, i := range items {
+		item, _ := i.(map[string]interface{})
+		metadata, _ := item["metadata"].(map[string]interface{})
+		if name, _ := metadata["name"].(string); name != "" {
+			byName[name] = item
+		}
+	}
+	return byName
+}
+
+// nodeInfo returns the status.nodeInfo fields of a node, e.g. kubeletVersion or kernelVersion.
+func nodeInfo(node map[string]interface{}) map[string]string {
+	fields := map[string]string{}
+	status, _ := node["status"].(map[string]interface{})
+	info, _ := status["nodeInfo"].(map[string]interface{})
+	for k, v := range info {
+		fields[k] = fmt.Sprint(v)
+	}
+	return fields
+}
+
+func compareNames(oldItems, newItems map[string]map[string]interface{}) ListDiff {
+	diff := ListDiff{}
+	for _, name := range sortedKeys(newItems) {
+		if _, ok := oldItems[name]; !ok {
+			diff.Added = append(diff.Added, name)
+		}
+	}
+	for _, name := range sortedKeys(oldItems) {
+		if _, ok := newItems[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	return diff
+}
+
+func compareMaps(oldMap, newMap map[string]string) []FieldDiff {
+	keys := map[string]bool{}
+	for k := range oldMap {
+		keys[k] = true
+	}
+	for k := range newMap {
+		keys[k] = true
+	}
+	var diffs []FieldDiff
+	for _, k := range sortedKeys(keys) {
+		if oldMap[k] != newMap[k] {
+			diffs = append(diffs, FieldDiff{Name: k, Old: oldMap[k], New: newMap[k]})
+		}
+	}
+	return diffs
+}
+
+// sortedKeys returns the keys of a map indexed by strings, in order.
+func sortedKeys(m interface{}) []string {
+	mapKeys := reflect.ValueOf(m).MapKeys()
+	keys := make([]string, 0, len(mapKeys))
+	for _, k := range mapKeys {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteText writes a human readable report of the differences to w.
+func (d *Diff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "%s:\n", title)
+		for _, l := range lines {
+			fmt.Fprintf(&b, "  %s\n", l)
+		}
+	}
+	fieldLines := func(diffs []FieldDiff) []string {
+		var lines []string
+		for _, f := range diffs {
+			lines = append(lines, fmt.Sprintf("%s: %s -> %s", f.Name, orNone(f.Old), orNone(f.New)))
+		}
+		return lines
+	}
+
+	var changes []string
+	for _, c := range d.ChangedTests {
+		line := fmt.Sprintf("%s: %s -> %s", c.TestID, c.OldState, c.NewState)
+		if c.Regression {
+			line += " (REGRESSION)"
+		}
+		changes = append(changes, line)
+	}
+	section("Changed tests", changes)
+	section("New tests", d.NewTests)
+	section("Removed tests", d.RemovedTests)
+	section("Versions", fieldLines(d.Versions))
+	section("CNI plugins", fieldLines(d.Nodes.CniPlugins))
+	section("Added CSI drivers", d.Nodes.CsiDrivers.Added)
+	section("Removed CSI drivers", d.Nodes.CsiDrivers.Removed)
+	section("Added nodes", d.Nodes.Nodes.Added)
+	section("Removed nodes", d.Nodes.Nodes.Removed)
+	section("Node info", fieldLines(d.Nodes.NodeInfo))
+	if b.Len() == 0 {
+		b.WriteString("No differences\n")
+	} else {
+		fmt.Fprintf(&b, "%d tests regressed\n", len(d.Regressions()))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
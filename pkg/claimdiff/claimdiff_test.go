@@ -0,0 +1,118 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claimdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+)
+
+const (
+	testDataPath = "testdata"
+)
+
+func loadClaim(t *testing.T, name string) *claim.Claim {
+	contents, err := os.ReadFile(path.Join(testDataPath, name))
+	assert.Nil(t, err)
+	var root claim.Root
+	assert.Nil(t, json.Unmarshal(contents, &root))
+	return root.Claim
+}
+
+func TestCompare(t *testing.T) {
+	diff := Compare(loadClaim(t, "old-claim.json"), loadClaim(t, "new-claim.json"), false)
+
+	assert.Equal(t, []TestChange{
+		{TestID: "lifecycle-lifecycle-container-shutdown", OldState: "passed", NewState: "failed", Regression: true},
+		{TestID: "lifecycle-lifecycle-pod-owner-type", OldState: "failed", NewState: "passed"},
+		{TestID: "networking-networking-icmpv4-connectivity", OldState: "passed", NewState: "skipped", Regression: true},
+		{TestID: "platform-alteration-platform-alteration-boot-params", OldState: "passed", NewState: "removed", Regression: true},
+	}, diff.ChangedTests)
+	assert.Equal(t, []string{"observability-observability-container-logging"}, diff.NewTests)
+	assert.Equal(t, []string{"platform-alteration-platform-alteration-boot-params"}, diff.RemovedTests)
+	assert.Equal(t, []FieldDiff{
+		{Name: "ocp", Old: "4.9.0", New: "4.10.0"},
+		{Name: "k8s", Old: "v1.22.1", New: "v1.23.3"},
+		{Name: "ocClient", Old: "4.9.0", New: "4.10.0"},
+	}, diff.Versions)
+	assert.Equal(t, NodesDiff{
+		CniPlugins: []FieldDiff{{Name: "ovn-k8s-cni-overlay", Old: "0.4.0", New: "1.0.0"}},
+		CsiDrivers: ListDiff{Added: []string{"efs.csi.aws.com"}},
+		Nodes:      ListDiff{Added: []string{"worker-1"}, Removed: []string{"worker-0"}},
+		NodeInfo: []FieldDiff{
+			{Name: "master-0/kernelVersion", Old: "4.18.0-305.el8.x86_64", New: "4.18.0-348.el8.x86_64"},
+			{Name: "master-0/kubeletVersion", Old: "v1.22.1", New: "v1.23.3"},
+		},
+	}, diff.Nodes)
+	assert.Len(t, diff.Regressions(), 3)
+}
+
+func TestCompareIgnoreRemoved(t *testing.T) {
+	diff := Compare(loadClaim(t, "old-claim.json"), loadClaim(t, "new-claim.json"), true)
+	assert.Equal(t, []TestChange{
+		{TestID: "lifecycle-lifecycle-container-shutdown", OldState: "passed", NewState: "failed", Regression: true},
+		{TestID: "lifecycle-lifecycle-pod-owner-type", OldState: "failed", NewState: "passed"},
+		{TestID: "networking-networking-icmpv4-connectivity", OldState: "passed", NewState: "skipped", Regression: true},
+	}, diff.ChangedTests)
+	assert.Equal(t, []string{"platform-alteration-platform-alteration-boot-params"}, diff.RemovedTests)
+	assert.Len(t, diff.Regressions(), 2)
+}
+
+func TestCompareSameClaim(t *testing.T) {
+	diff := Compare(loadClaim(t, "old-claim.json"), loadClaim(t, "old-claim.json"), false)
+	assert.Empty(t, diff.ChangedTests)
+	assert.Empty(t, diff.NewTests)
+	assert.Empty(t, diff.RemovedTests)
+	assert.Empty(t, diff.Versions)
+	assert.Equal(t, NodesDiff{}, diff.Nodes)
+
+	var out bytes.Buffer
+	assert.Nil(t, diff.WriteText(&out))
+	assert.Equal(t, "No differences\n", out.String())
+}
+
+func TestWriteText(t *testing.T) {
+	diff := Compare(loadClaim(t, "old-claim.json"), loadClaim(t, "new-claim.json"), false)
+	var out bytes.Buffer
+	assert.Nil(t, diff.WriteText(&out))
+	expected, err := os.ReadFile(path.Join(testDataPath, "diff.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), out.String())
+}
+
+func TestTestStates(t *testing.T) {
+	results := map[string]interface{}{
+		"no-state": []interface{}{map[string]interface{}{}},
+		"failed-wins": []interface{}{
+			map[string]interface{}{"state": "passed"},
+			map[string]interface{}{"state": "failed"},
+			map[string]interface{}{"state": "skipped"},
+		},
+		"not-a-list": "junk",
+	}
+	assert.Equal(t, map[string]string{
+		"no-state":    stateMissing,
+		"failed-wins": "failed",
+		"not-a-list":  stateMissing,
+	}, testStates(results))
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"strings"
+)
+
+const (
+	ocAPIVersionsCommand = "oc api-versions"
+)
+
+// GetAPIVersions returns the group versions served by the API server, e.g. "apps/v1" or "v1" for the core group.
+func GetAPIVersions() ([]string, error) {
+	return getBackend().GetAPIVersions()
+}
+
+// parseAPIVersions returns the group versions listed one per line by `oc api-versions`.
+func parseAPIVersions(out string) []string {
+	var versions []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			versions = append(versions, line)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+)
+
+func TestOcGetAPIVersions(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	testCases := []struct {
+		output           string
+		expectedVersions []string
+		expectedError    bool
+	}{
+		{
+			output:           "apps/v1\nmachineconfiguration.openshift.io/v1\r\nv1\n",
+			expectedVersions: []string{"apps/v1", "machineconfiguration.openshift.io/v1", "v1"},
+		},
+		{output: "", expectedError: true},
+	}
+	for _, tc := range testCases {
+		var command string
+		execCommandOutput = func(c string) string {
+			command = c
+			return tc.output
+		}
+		versions, err := (&ocBackend{}).GetAPIVersions()
+		assert.Equal(t, "oc api-versions", command)
+		assert.Equal(t, tc.expectedError, err != nil)
+		assert.Equal(t, tc.expectedVersions, versions)
+	}
+}
+
+func TestClientGoGetAPIVersions(t *testing.T) {
+	b := newTestClientGoBackend(nil)
+	b.clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1"},
+		{GroupVersion: "apps/v1"},
+		{GroupVersion: "operators.coreos.com/v1alpha1"},
+	}
+	SetBackend(b)
+	defer SetBackend(nil)
+
+	versions, err := GetAPIVersions()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"v1", "apps/v1", "operators.coreos.com/v1alpha1"}, versions)
+}
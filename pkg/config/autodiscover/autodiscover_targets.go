@@ -22,12 +22,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
-	"github.com/test-network-function/test-network-function/pkg/tnf"
-	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodenames"
-	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
-	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
-	"github.com/test-network-function/test-network-function/pkg/utils"
 )
 
 const (
@@ -38,9 +33,11 @@ const (
 )
 
 var (
-	operatorTestsAnnotationName    = buildAnnotationName("operator_tests")
-	subscriptionNameAnnotationName = buildAnnotationName("subscription_name")
-	podTestsAnnotationName         = buildAnnotationName("host_resource_tests")
+	operatorTestsAnnotationName       = buildAnnotationName("operator_tests")
+	subscriptionNameAnnotationName    = buildAnnotationName("subscription_name")
+	channelAnnotationName             = buildAnnotationName("channel")
+	installPlanApprovalAnnotationName = buildAnnotationName("install_plan_approval")
+	podTestsAnnotationName            = buildAnnotationName("host_resource_tests")
 )
 
 // FindTestTarget finds test targets from the current state of the cluster,
@@ -106,44 +103,24 @@ func appendPodsets(podsets []configsections.PodSet, ns map[string]bool) (podSet
 // GetNodesList Function that return a list of node and what is the type of them.
 func GetNodesList() (nodes map[string]configsections.Node) {
 	nodes = make(map[string]configsections.Node)
-	var nodeNames []string
-	context := interactive.GetContext(expectersVerboseModeEnabled)
-	tester := nodenames.NewNodeNames(DefaultTimeout, map[string]*string{configsections.MasterLabel: nil})
-	test, _ := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-	_, err := test.Run()
-	if err != nil {
-		log.Error("Unable to get node list ", ". Error: ", err)
-		return
-	}
-	nodeNames = tester.GetNodeNames()
-	for i := range nodeNames {
-		nodes[nodeNames[i]] = configsections.Node{
-			Name:   nodeNames[i],
-			Labels: []string{configsections.MasterLabel},
+	for _, label := range []string{configsections.MasterLabel, configsections.WorkerLabel} {
+		nodeNames, err := getBackend().GetNodeNames(label)
+		if err != nil {
+			log.Error("Unable to get node list ", ". Error: ", err)
+			if label == configsections.MasterLabel {
+				return nodes
+			}
+			continue
 		}
-	}
-
-	tester = nodenames.NewNodeNames(DefaultTimeout, map[string]*string{configsections.WorkerLabel: nil})
-	test, _ = tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-	_, err = test.Run()
-	if err != nil {
-		log.Error("Unable to get node list ", ". Error: ", err)
-	} else {
-		nodeNames = tester.GetNodeNames()
-		for i := range nodeNames {
-			if _, ok := nodes[nodeNames[i]]; ok {
-				var node = nodes[nodeNames[i]]
-				node.Labels = append(node.Labels, configsections.WorkerLabel)
-				nodes[nodeNames[i]] = node
-			} else {
-				nodes[nodeNames[i]] = configsections.Node{
-					Name:   nodeNames[i],
-					Labels: []string{configsections.WorkerLabel},
-				}
+		for _, name := range nodeNames {
+			node, ok := nodes[name]
+			if !ok {
+				node = configsections.Node{Name: name}
 			}
+			node.Labels = append(node.Labels, label)
+			nodes[name] = node
 		}
 	}
-
 	return nodes
 }
 
@@ -164,6 +141,7 @@ func FindTestPodSetsByLabel(targetLabels []configsections.Label, resourceTypeDep
 					Name:      podsetResource.GetName(),
 					Namespace: podsetResource.GetNamespace(),
 					Replicas:  podsetResource.GetReplicas(),
+					PodLabels: podsetResource.GetPodLabels(),
 					Hpa:       podsetResource.GetHpa(),
 					Type:      configType,
 				}
@@ -203,6 +181,8 @@ func buildPodUnderTest(pr *PodResource) (podUnderTest *configsections.Pod) {
 	if pr.Metadata.OwnerReferences != nil {
 		podUnderTest.IsManaged = true
 	}
+	podUnderTest.Labels = pr.Metadata.Labels
+	podUnderTest.DeclaredPorts = pr.getDeclaredPorts()
 
 	// Get a list of all the containers present in the pod
 	allContainersInPod := buildContainers(pr)
@@ -237,6 +217,15 @@ func buildOperatorFromCSVResource(csv *CSVResource) (op configsections.Operator)
 	} else {
 		op.SubscriptionName = subscriptionName[0]
 	}
+
+	for annotation, value := range map[string]*string{channelAnnotationName: &op.Channel, installPlanApprovalAnnotationName: &op.InstallPlanApproval} {
+		if !csv.hasAnnotation(annotation) {
+			continue
+		}
+		if err = csv.GetAnnotationValue(annotation, value); err != nil {
+			log.Warnf("unable to get the expected %s of CSV %s (error: %s).", annotation, csv.Metadata.Name, err)
+		}
+	}
 	return op
 }
 
@@ -258,17 +247,7 @@ func getConfiguredOperatorTests() []string {
 
 // getClusterCrdNames returns a list of crd names found in the cluster.
 func getClusterCrdNames() ([]string, error) {
-	out := utils.ExecuteCommandAndValidate(ocGetClusterCrdNamesCommand, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
-		log.Error("can't run command: ", ocGetClusterCrdNamesCommand)
-	})
-
-	var crdNamesList []string
-	err := jsonUnmarshal([]byte(out), &crdNamesList)
-	if err != nil {
-		return nil, err
-	}
-
-	return crdNamesList, nil
+	return getBackend().GetCrdNames()
 }
 
 // FindTestCrdNames gets a list of CRD names based on configured groups.
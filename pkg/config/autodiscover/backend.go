@@ -0,0 +1,316 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodenames"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	discoveryBackendEnvVar = "TNF_DISCOVERY_BACKEND"
+	// OcBackendName selects the backend that shells out to `oc`/`kubectl` on a local interactive shell.
+	OcBackendName = "oc"
+	// ClientGoBackendName selects the backend that queries the API server with client-go.
+	ClientGoBackendName = "client-go"
+	// allNamespaces is passed as the namespace to query resources across the whole cluster.
+	allNamespaces = ""
+)
+
+// Backend is the source of cluster state used by autodiscovery. Each method returns the same
+// PodList/PodSetList/CSVList representation regardless of how the data was fetched, so the
+// builders in this package do not depend on the backend in use.
+// An empty namespace means all namespaces.
+type Backend interface {
+	// GetPods returns the pods matching labelQuery.
+	GetPods(namespace, labelQuery string) (*PodList, error)
+	// GetPodSets returns the deployments or statefulsets (resourceType) whose pod template carries targetLabel.
+	GetPodSets(namespace, resourceType string, targetLabel configsections.Label) (*PodSetList, error)
+	// GetCSVs returns the ClusterServiceVersions matching labelQuery.
+	GetCSVs(namespace, labelQuery string) (*CSVList, error)
+	// GetHpa returns the HorizontalPodAutoscaler scaling the podset named podsetName, if any.
+	GetHpa(namespace, podsetName string) (configsections.Hpa, error)
+	// GetNodeNames returns the names of the nodes having the label labelName, whatever its value.
+	GetNodeNames(labelName string) ([]string, error)
+	// GetCrdNames returns the names of all the CRDs in the cluster.
+	GetCrdNames() ([]string, error)
+	// GetNetworkPolicies returns the NetworkPolicies of namespace.
+	GetNetworkPolicies(namespace string) (*NetworkPolicyList, error)
+	// GetPodDisruptionBudgets returns the PodDisruptionBudgets of namespace.
+	GetPodDisruptionBudgets(namespace string) (*PodDisruptionBudgetList, error)
+	// GetAPIVersions returns the group versions served by the API server.
+	GetAPIVersions() ([]string, error)
+	// GetPod returns the pod named name, as returned by the API server.
+	GetPod(namespace, name string) (*corev1.Pod, error)
+	// GetSCCs returns the SecurityContextConstraints of the cluster.
+	GetSCCs() (*SCCList, error)
+	// CanUseSCC returns whether user, member of groups, is allowed to use the SCC named scc by RBAC.
+	CanUseSCC(user string, groups []string, scc string) (bool, error)
+	// GetRBAC returns the Roles and RoleBindings of all the namespaces, and the ClusterRoles and ClusterRoleBindings.
+	GetRBAC() (*RBACList, error)
+}
+
+var (
+	backend Backend
+	// newClientGoBackend is a var so the kubeconfig loading can be spoofed.
+	newClientGoBackend = func() (Backend, error) {
+		return NewClientGoBackendFromKubeconfig()
+	}
+)
+
+// SetBackend forces the backend used by autodiscovery.
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// getBackend returns the backend selected with the TNF_DISCOVERY_BACKEND environment variable.
+// The oc backend is used by default, and as a fallback if the client-go one can't be created. The backend is
+// selected once, the fallback included.
+func getBackend() Backend {
+	if backend != nil {
+		return backend
+	}
+	switch name := os.Getenv(discoveryBackendEnvVar); name {
+	case ClientGoBackendName:
+		b, err := newClientGoBackend()
+		if err != nil {
+			log.Errorf("unable to create the %s discovery backend, falling back to %s: %v", ClientGoBackendName, OcBackendName, err)
+			b = &ocBackend{}
+		}
+		backend = b
+	case "", OcBackendName:
+		backend = &ocBackend{}
+	default:
+		log.Warnf("unknown discovery backend %q, using %s", name, OcBackendName)
+		backend = &ocBackend{}
+	}
+	return backend
+}
+
+// ocBackend gets the cluster state by running `oc get ... -o json` on a local shell.
+type ocBackend struct{}
+
+func (b *ocBackend) GetPods(namespace, labelQuery string) (*PodList, error) {
+	out := b.ocGet(resourceTypePods, namespace, labelQuery)
+
+	log.Debug("JSON output for all pods labeled with: ", labelQuery)
+	log.Debug("Command: ", out)
+
+	var podList PodList
+	err := jsonUnmarshal([]byte(out), &podList)
+	if err != nil {
+		return nil, err
+	}
+	return &podList, nil
+}
+
+func (b *ocBackend) GetPodSets(namespace, resourceType string, targetLabel configsections.Label) (*PodSetList, error) {
+	labelQuery := fmt.Sprintf("%q==%q", buildLabelName(targetLabel.Prefix, targetLabel.Name), targetLabel.Value)
+	jqArgs := fmt.Sprintf("'[.items[] | select(.spec.template.metadata.labels.%s)]'", labelQuery)
+	nsArg := "-A"
+	if namespace != allNamespaces {
+		nsArg = "-n " + namespace
+	}
+	ocCmd := fmt.Sprintf("oc get %s %s -o json | jq %s", resourceType, nsArg, jqArgs)
+
+	out := execCommandOutput(ocCmd)
+
+	var podsetList PodSetList
+	err := jsonUnmarshal([]byte(out), &podsetList.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &podsetList, nil
+}
+
+func (b *ocBackend) GetCSVs(namespace, labelQuery string) (*CSVList, error) {
+	out := b.ocGet(resourceTypeCSV, namespace, labelQuery)
+
+	log.Debug("JSON output for all CSVs labeled with: ", labelQuery)
+	log.Debug("Command: ", out)
+
+	var csvList CSVList
+	err := jsonUnmarshal([]byte(out), &csvList)
+	if err != nil {
+		return nil, err
+	}
+	return &csvList, nil
+}
+
+func (b *ocBackend) GetHpa(namespace, podsetName string) (configsections.Hpa, error) {
+	template := fmt.Sprintf("go-template='{{ range .items }}{{ if eq .spec.scaleTargetRef.name %q }}{{.spec.minReplicas}},{{.spec.maxReplicas}},{{.metadata.name}}{{ end }}{{ end }}'", podsetName)
+	ocCmd := fmt.Sprintf("oc get hpa -n %s -o %s", namespace, template)
+	out := execCommandOutput(ocCmd)
+	if out == "" {
+		return configsections.Hpa{}, nil
+	}
+	fields := strings.Split(out, ",")
+	const hpaFields = 3
+	if len(fields) < hpaFields {
+		return configsections.Hpa{}, fmt.Errorf("unexpected hpa output for %s/%s: %q", namespace, podsetName, out)
+	}
+	min, _ := strconv.Atoi(fields[0])
+	max, _ := strconv.Atoi(fields[1])
+	return configsections.Hpa{
+		MinReplicas: min,
+		MaxReplicas: max,
+		HpaName:     fields[2],
+	}, nil
+}
+
+func (b *ocBackend) GetNodeNames(labelName string) ([]string, error) {
+	context := interactive.GetContext(expectersVerboseModeEnabled)
+	tester := nodenames.NewNodeNames(DefaultTimeout, map[string]*string{labelName: nil})
+	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	if err != nil {
+		return nil, err
+	}
+	_, err = test.Run()
+	if err != nil {
+		return nil, err
+	}
+	return tester.GetNodeNames(), nil
+}
+
+func (b *ocBackend) GetCrdNames() ([]string, error) {
+	out := utils.ExecuteCommandAndValidate(ocGetClusterCrdNamesCommand, ocCommandTimeOut, interactive.GetContext(expectersVerboseModeEnabled), func() {
+		log.Error("can't run command: ", ocGetClusterCrdNamesCommand)
+	})
+
+	var crdNamesList []string
+	err := jsonUnmarshal([]byte(out), &crdNamesList)
+	if err != nil {
+		return nil, err
+	}
+	return crdNamesList, nil
+}
+
+func (b *ocBackend) GetNetworkPolicies(namespace string) (*NetworkPolicyList, error) {
+	out := execCommandOutput(fmt.Sprintf(ocGetNetworkPoliciesCommand, namespace))
+
+	var policyList NetworkPolicyList
+	err := jsonUnmarshal([]byte(out), &policyList)
+	if err != nil {
+		return nil, err
+	}
+	return &policyList, nil
+}
+
+func (b *ocBackend) GetPodDisruptionBudgets(namespace string) (*PodDisruptionBudgetList, error) {
+	out := execCommandOutput(fmt.Sprintf(ocGetPodDisruptionBudgetsCommand, namespace))
+
+	var pdbList PodDisruptionBudgetList
+	err := jsonUnmarshal([]byte(out), &pdbList)
+	if err != nil {
+		return nil, err
+	}
+	return &pdbList, nil
+}
+
+func (b *ocBackend) GetAPIVersions() ([]string, error) {
+	out := execCommandOutput(ocAPIVersionsCommand)
+	versions := parseAPIVersions(out)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no API versions in the output of %q", ocAPIVersionsCommand)
+	}
+	return versions, nil
+}
+
+func (b *ocBackend) GetPod(namespace, name string) (*corev1.Pod, error) {
+	out := execCommandOutput(fmt.Sprintf(ocGetPodCommand, name, namespace))
+
+	var pod corev1.Pod
+	err := jsonUnmarshal([]byte(out), &pod)
+	if err != nil {
+		return nil, err
+	}
+	return &pod, nil
+}
+
+func (b *ocBackend) GetSCCs() (*SCCList, error) {
+	out := execCommandOutput(ocGetSCCsCommand)
+
+	var sccList SCCList
+	err := jsonUnmarshal([]byte(out), &sccList)
+	if err != nil {
+		return nil, err
+	}
+	return &sccList, nil
+}
+
+func (b *ocBackend) CanUseSCC(user string, groups []string, scc string) (bool, error) {
+	command := fmt.Sprintf(ocCanUseSCCCommand, scc, user)
+	for _, g := range groups {
+		command += fmt.Sprintf(ocAsGroupOption, g)
+	}
+	command += ocCanIAnswerSuffix
+	return parseCanI(command, execCommandOutput(command))
+}
+
+func (b *ocBackend) GetRBAC() (*RBACList, error) {
+	var roles struct {
+		Items []rbacv1.Role `json:"items"`
+	}
+	var roleBindings struct {
+		Items []rbacv1.RoleBinding `json:"items"`
+	}
+	var clusterRoles struct {
+		Items []rbacv1.ClusterRole `json:"items"`
+	}
+	var clusterRoleBindings struct {
+		Items []rbacv1.ClusterRoleBinding `json:"items"`
+	}
+	lists := []struct {
+		command string
+		list    interface{}
+	}{
+		{ocGetRolesCommand, &roles},
+		{ocGetRoleBindingsCommand, &roleBindings},
+		{ocGetClusterRolesCommand, &clusterRoles},
+		{ocGetClusterRoleBindingsCommand, &clusterRoleBindings},
+	}
+	for _, l := range lists {
+		if err := jsonUnmarshal([]byte(execCommandOutput(l.command)), l.list); err != nil {
+			return nil, err
+		}
+	}
+	return &RBACList{
+		Roles:               roles.Items,
+		RoleBindings:        roleBindings.Items,
+		ClusterRoles:        clusterRoles.Items,
+		ClusterRoleBindings: clusterRoleBindings.Items,
+	}, nil
+}
+
+func (b *ocBackend) ocGet(resourceType, namespace, labelQuery string) string {
+	if namespace == allNamespaces {
+		return executeOcGetAllCommand(resourceType, labelQuery)
+	}
+	return executeOcGetCommand(resourceType, labelQuery, namespace)
+}
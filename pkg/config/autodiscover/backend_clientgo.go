@@ -0,0 +1,304 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	// CSVGroupVersionResource identifies the OLM ClusterServiceVersion resource.
+	CSVGroupVersionResource = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "clusterserviceversions"}
+	// CRDGroupVersionResource identifies the CustomResourceDefinition resource.
+	CRDGroupVersionResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	// SCCGroupVersionResource identifies the OpenShift SecurityContextConstraints resource.
+	SCCGroupVersionResource = schema.GroupVersionResource{Group: "security.openshift.io", Version: "v1", Resource: resourceTypeSCCs}
+)
+
+// ClientGoBackend gets the cluster state from the API server through client-go. Core resources are
+// read with the typed clientset, OLM and CRD resources with the dynamic client, so no extra API
+// packages are needed.
+type ClientGoBackend struct {
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+}
+
+// NewClientGoBackend creates a ClientGoBackend from already built clients.
+func NewClientGoBackend(clientset kubernetes.Interface, dynamicClient dynamic.Interface) *ClientGoBackend {
+	return &ClientGoBackend{clientset: clientset, dynamic: dynamicClient}
+}
+
+// NewClientGoBackendFromKubeconfig creates a ClientGoBackend using the same kubeconfig lookup as `oc`:
+// the KUBECONFIG environment variable, then ~/.kube/config, then the in-cluster configuration.
+func NewClientGoBackendFromKubeconfig() (*ClientGoBackend, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientGoBackend(clientset, dynamicClient), nil
+}
+
+// GetPods returns the pods matching labelQuery.
+func (b *ClientGoBackend) GetPods(namespace, labelQuery string) (*PodList, error) {
+	list, err := b.clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: labelQuery})
+	if err != nil {
+		return nil, err
+	}
+	var podList PodList
+	err = convertResource(list, &podList)
+	if err != nil {
+		return nil, err
+	}
+	return &podList, nil
+}
+
+// GetPodSets returns the deployments or statefulsets whose pod template carries targetLabel.
+func (b *ClientGoBackend) GetPodSets(namespace, resourceType string, targetLabel configsections.Label) (*PodSetList, error) {
+	labelName := buildLabelName(targetLabel.Prefix, targetLabel.Name)
+	selected := func(templateLabels map[string]string) bool {
+		value, ok := templateLabels[labelName]
+		return ok && value == targetLabel.Value
+	}
+
+	var items []interface{}
+	switch resourceType {
+	case string(configsections.StateFulSet):
+		list, err := b.clientset.AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			if selected(list.Items[i].Spec.Template.Labels) {
+				items = append(items, &list.Items[i])
+			}
+		}
+	default:
+		list, err := b.clientset.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			if selected(list.Items[i].Spec.Template.Labels) {
+				items = append(items, &list.Items[i])
+			}
+		}
+	}
+
+	podsetList := PodSetList{Items: []PodSetResource{}}
+	if len(items) == 0 {
+		return &podsetList, nil
+	}
+	err := convertResource(items, &podsetList.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &podsetList, nil
+}
+
+// GetCSVs returns the ClusterServiceVersions matching labelQuery.
+func (b *ClientGoBackend) GetCSVs(namespace, labelQuery string) (*CSVList, error) {
+	list, err := b.dynamic.Resource(CSVGroupVersionResource).Namespace(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: labelQuery})
+	if err != nil {
+		return nil, err
+	}
+	var csvList CSVList
+	err = convertResource(list, &csvList)
+	if err != nil {
+		return nil, err
+	}
+	return &csvList, nil
+}
+
+// GetHpa returns the HorizontalPodAutoscaler scaling the podset named podsetName, if any.
+func (b *ClientGoBackend) GetHpa(namespace, podsetName string) (configsections.Hpa, error) {
+	list, err := b.clientset.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return configsections.Hpa{}, err
+	}
+	for i := range list.Items {
+		hpa := &list.Items[i]
+		if hpa.Spec.ScaleTargetRef.Name != podsetName {
+			continue
+		}
+		minReplicas := 1
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = int(*hpa.Spec.MinReplicas)
+		}
+		return configsections.Hpa{
+			MinReplicas: minReplicas,
+			MaxReplicas: int(hpa.Spec.MaxReplicas),
+			HpaName:     hpa.Name,
+		}, nil
+	}
+	return configsections.Hpa{}, nil
+}
+
+// GetNodeNames returns the names of the nodes having the label labelName, whatever its value.
+func (b *ClientGoBackend) GetNodeNames(labelName string) ([]string, error) {
+	list, err := b.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: labelName})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for i := range list.Items {
+		names = append(names, list.Items[i].Name)
+	}
+	return names, nil
+}
+
+// GetCrdNames returns the names of all the CRDs in the cluster.
+func (b *ClientGoBackend) GetCrdNames() ([]string, error) {
+	list, err := b.dynamic.Resource(CRDGroupVersionResource).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for i := range list.Items {
+		names = append(names, list.Items[i].GetName())
+	}
+	return names, nil
+}
+
+// GetNetworkPolicies returns the NetworkPolicies of namespace.
+func (b *ClientGoBackend) GetNetworkPolicies(namespace string) (*NetworkPolicyList, error) {
+	list, err := b.clientset.NetworkingV1().NetworkPolicies(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkPolicyList{Items: list.Items}, nil
+}
+
+// GetPodDisruptionBudgets returns the PodDisruptionBudgets of namespace.
+func (b *ClientGoBackend) GetPodDisruptionBudgets(namespace string) (*PodDisruptionBudgetList, error) {
+	list, err := b.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &PodDisruptionBudgetList{Items: list.Items}, nil
+}
+
+// GetAPIVersions returns the group versions served by the API server.
+func (b *ClientGoBackend) GetAPIVersions() ([]string, error) {
+	groups, err := b.clientset.Discovery().ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for i := range groups.Groups {
+		for _, v := range groups.Groups[i].Versions {
+			versions = append(versions, v.GroupVersion)
+		}
+	}
+	return versions, nil
+}
+
+// GetPod returns the pod named name.
+func (b *ClientGoBackend) GetPod(namespace, name string) (*corev1.Pod, error) {
+	return b.clientset.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// GetSCCs returns the SecurityContextConstraints of the cluster.
+func (b *ClientGoBackend) GetSCCs() (*SCCList, error) {
+	list, err := b.dynamic.Resource(SCCGroupVersionResource).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var sccList SCCList
+	err = convertResource(list, &sccList)
+	if err != nil {
+		return nil, err
+	}
+	return &sccList, nil
+}
+
+// CanUseSCC returns whether user, member of groups, is allowed to use the SCC named scc by RBAC, as told by a
+// SubjectAccessReview.
+func (b *ClientGoBackend) CanUseSCC(user string, groups []string, scc string) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user,
+			Groups: groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "use",
+				Group:    SCCGroupVersionResource.Group,
+				Resource: SCCGroupVersionResource.Resource,
+				Name:     scc,
+			},
+		},
+	}
+	review, err := b.clientset.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// GetRBAC returns the Roles and RoleBindings of all the namespaces, and the ClusterRoles and ClusterRoleBindings.
+func (b *ClientGoBackend) GetRBAC() (*RBACList, error) {
+	rbacClient := b.clientset.RbacV1()
+	roles, err := rbacClient.Roles(allNamespaces).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	roleBindings, err := rbacClient.RoleBindings(allNamespaces).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterRoles, err := rbacClient.ClusterRoles().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleBindings, err := rbacClient.ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &RBACList{
+		Roles:               roles.Items,
+		RoleBindings:        roleBindings.Items,
+		ClusterRoles:        clusterRoles.Items,
+		ClusterRoleBindings: clusterRoleBindings.Items,
+	}, nil
+}
+
+// convertResource turns an API object into one of the resource types of this package by going through
+// its JSON representation, which is the same one `oc get -o json` outputs.
+func convertResource(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return jsonUnmarshal(data, out)
+}
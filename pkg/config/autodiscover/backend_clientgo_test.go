@@ -0,0 +1,260 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestClientGoBackend(objects []runtime.Object, unstructuredObjects ...runtime.Object) *ClientGoBackend {
+	listKinds := map[schema.GroupVersionResource]string{
+		CSVGroupVersionResource: "ClusterServiceVersionList",
+		CRDGroupVersionResource: "CustomResourceDefinitionList",
+		SCCGroupVersionResource: "SecurityContextConstraintsList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, unstructuredObjects...)
+	return NewClientGoBackend(fake.NewSimpleClientset(objects...), dynamicClient)
+}
+
+func newUnstructured(apiVersion, kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetLabels(labels)
+	return u
+}
+
+func TestClientGoGetPodsByLabel(t *testing.T) {
+	pods := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-0",
+				Namespace: "tnf",
+				Labels:    map[string]string{"test-network-function.com/generic": "target"},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "test-sa",
+				NodeName:           "worker-0",
+				Containers:         []corev1.Container{{Name: "test", Image: "quay.io/testnetworkfunction/cnf-test-partner:latest"}},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "test", ContainerID: "cri-o://0123456789"}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-0", Namespace: "tnf"},
+		},
+	}
+
+	SetBackend(newTestClientGoBackend(pods))
+	defer SetBackend(nil)
+
+	podList, err := GetPodsByLabel(configsections.Label{Prefix: "test-network-function.com", Name: "generic", Value: "target"})
+	assert.Nil(t, err)
+	assert.Len(t, podList.Items, 1)
+
+	pod := podList.Items[0]
+	assert.Equal(t, "test-0", pod.Metadata.Name)
+	assert.Equal(t, "tnf", pod.Metadata.Namespace)
+	assert.Equal(t, "test-sa", pod.Spec.ServiceAccount)
+	assert.Equal(t, "", pod.Metadata.DeletionTimestamp)
+
+	containers := buildContainers(pod)
+	assert.Len(t, containers, 1)
+	assert.Equal(t, "worker-0", containers[0].NodeName)
+	assert.Equal(t, "0123456789", containers[0].ContainerUID)
+	assert.Equal(t, "cri-o", containers[0].ContainerRuntime)
+	assert.Equal(t, "cnf-test-partner", containers[0].ImageSource.Name)
+}
+
+func TestClientGoGetTargetPodSetsByLabel(t *testing.T) {
+	replicas := int32(2)
+	minReplicas := int32(1)
+	template := func(labels map[string]string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+	}
+	targetLabels := map[string]string{"test-network-function.com/generic": "target"}
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tnf"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Template: template(targetLabels)},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "tnf"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Template: template(map[string]string{"app": "unrelated"})},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sts", Namespace: "tnf"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas, Template: template(targetLabels)},
+		},
+		&autoscalingv1.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-hpa", Namespace: "tnf"},
+			Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{Kind: "Deployment", Name: "test"},
+				MinReplicas:    &minReplicas,
+				MaxReplicas:    3,
+			},
+		},
+	}
+
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	label := configsections.Label{Prefix: "test-network-function.com", Name: "generic", Value: "target"}
+	deployments := FindTestPodSetsByLabel([]configsections.Label{label}, string(configsections.Deployment))
+	assert.Equal(t, []configsections.PodSet{
+		{
+			Name:      "test",
+			Namespace: "tnf",
+			Replicas:  2,
+			PodLabels: targetLabels,
+			Hpa:       configsections.Hpa{MinReplicas: 1, MaxReplicas: 3, HpaName: "test-hpa"},
+			Type:      configsections.Deployment,
+		},
+	}, deployments)
+
+	statefulSets, err := GetTargetPodSetsByNamespace("tnf", label, string(configsections.StateFulSet))
+	assert.Nil(t, err)
+	assert.Len(t, statefulSets.Items, 1)
+	assert.Equal(t, "test-sts", statefulSets.Items[0].GetName())
+	assert.Equal(t, configsections.Hpa{}, statefulSets.Items[0].GetHpa())
+
+	empty, err := GetTargetPodSetsByNamespace("other", label, string(configsections.Deployment))
+	assert.Nil(t, err)
+	assert.Empty(t, empty.Items)
+}
+
+func TestClientGoGetCSVsByLabel(t *testing.T) {
+	csv := newUnstructured("operators.coreos.com/v1alpha1", "ClusterServiceVersion", "tnf", "etcdoperator.v0.9.4",
+		map[string]string{"test-network-function.com/operator": "target"})
+	csv.SetAnnotations(map[string]string{"test-network-function.com/subscription_name": `["etcd"]`})
+	unlabeled := newUnstructured("operators.coreos.com/v1alpha1", "ClusterServiceVersion", "tnf", "other.v1.0.0", nil)
+
+	SetBackend(newTestClientGoBackend(nil, csv, unlabeled))
+	defer SetBackend(nil)
+
+	csvList, err := GetCSVsByLabel(operatorLabelName, anyLabelValue)
+	assert.Nil(t, err)
+	assert.Len(t, csvList.Items, 1)
+	op := buildOperatorFromCSVResource(&csvList.Items[0])
+	assert.Equal(t, "etcdoperator.v0.9.4", op.Name)
+	assert.Equal(t, "tnf", op.Namespace)
+	assert.Equal(t, "etcd", op.SubscriptionName)
+
+	csvList, err = GetCSVsByLabelByNamespace(operatorLabelName, anyLabelValue, "default")
+	assert.Nil(t, err)
+	assert.Empty(t, csvList.Items)
+}
+
+func TestClientGoGetNodesList(t *testing.T) {
+	node := func(name string, labels ...string) *corev1.Node {
+		n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+		for _, l := range labels {
+			n.Labels[l] = ""
+		}
+		return n
+	}
+	objects := []runtime.Object{
+		node("master-0", configsections.MasterLabel),
+		node("worker-0", configsections.WorkerLabel),
+		node("compact-0", configsections.MasterLabel, configsections.WorkerLabel),
+		node("infra-0"),
+	}
+
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	assert.Equal(t, map[string]configsections.Node{
+		"master-0":  {Name: "master-0", Labels: []string{configsections.MasterLabel}},
+		"worker-0":  {Name: "worker-0", Labels: []string{configsections.WorkerLabel}},
+		"compact-0": {Name: "compact-0", Labels: []string{configsections.MasterLabel, configsections.WorkerLabel}},
+	}, GetNodesList())
+}
+
+func TestClientGoFindTestCrdNames(t *testing.T) {
+	crds := []runtime.Object{
+		newUnstructured("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "crontabs.tnf.example.com", nil),
+		newUnstructured("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "etcdclusters.etcd.database.coreos.com", nil),
+	}
+
+	SetBackend(newTestClientGoBackend(nil, crds...))
+	defer SetBackend(nil)
+
+	assert.Equal(t, []string{"crontabs.tnf.example.com"}, FindTestCrdNames([]configsections.CrdFilter{{NameSuffix: "tnf.example.com"}}))
+}
+
+func TestGetBackend(t *testing.T) {
+	origNewClientGoBackend := newClientGoBackend
+	defer func() {
+		newClientGoBackend = origNewClientGoBackend
+		SetBackend(nil)
+	}()
+	clientGoBackend := newTestClientGoBackend(nil)
+	newClientGoBackend = func() (Backend, error) {
+		return clientGoBackend, nil
+	}
+
+	testCases := []struct {
+		envValue        string
+		expectedBackend Backend
+	}{
+		{envValue: "", expectedBackend: &ocBackend{}},
+		{envValue: OcBackendName, expectedBackend: &ocBackend{}},
+		{envValue: "unknown", expectedBackend: &ocBackend{}},
+		{envValue: ClientGoBackendName, expectedBackend: clientGoBackend},
+	}
+	for _, tc := range testCases {
+		SetBackend(nil)
+		t.Setenv(discoveryBackendEnvVar, tc.envValue)
+		assert.Equal(t, tc.expectedBackend, getBackend())
+	}
+}
+
+func TestGetBackendFallback(t *testing.T) {
+	origNewClientGoBackend := newClientGoBackend
+	defer func() {
+		newClientGoBackend = origNewClientGoBackend
+		SetBackend(nil)
+	}()
+	calls := 0
+	newClientGoBackend = func() (Backend, error) {
+		calls++
+		return nil, errors.New("no kubeconfig")
+	}
+
+	SetBackend(nil)
+	t.Setenv(discoveryBackendEnvVar, ClientGoBackendName)
+	assert.Equal(t, &ocBackend{}, getBackend())
+	assert.Equal(t, &ocBackend{}, getBackend())
+	assert.Equal(t, 1, calls)
+}
@@ -19,8 +19,6 @@ package autodiscover
 import (
 	"fmt"
 
-	log "github.com/sirupsen/logrus"
-
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
@@ -70,33 +68,11 @@ func (csv *CSVResource) annotationUnmarshalError(annotationKey string, err error
 // GetCSVsByLabelByNamespace will return all CSVs with a given label value. If `labelValue` is an empty string, all CSVs with that
 // label will be returned, regardless of the labels value.
 func GetCSVsByLabelByNamespace(labelName, labelValue, namespace string) (*CSVList, error) {
-	out := executeOcGetCommand(resourceTypeCSV, buildLabelQuery(configsections.Label{Prefix: tnfLabelPrefix, Name: labelName, Value: labelValue}), namespace)
-
-	log.Debug("JSON output for all pods labeled with: ", labelName)
-	log.Debug("Command: ", out)
-
-	var csvList CSVList
-	err := jsonUnmarshal([]byte(out), &csvList)
-	if err != nil {
-		return nil, err
-	}
-
-	return &csvList, nil
+	return getBackend().GetCSVs(namespace, buildLabelQuery(configsections.Label{Prefix: tnfLabelPrefix, Name: labelName, Value: labelValue}))
 }
 
 // GetCSVsByLabel will return all CSVs with a given label value. If `labelValue` is an empty string, all CSVs with that
 // label will be returned, regardless of the labels value.
 func GetCSVsByLabel(labelName, labelValue string) (*CSVList, error) {
-	out := executeOcGetAllCommand(resourceTypeCSV, buildLabelQuery(configsections.Label{Prefix: tnfLabelPrefix, Name: labelName, Value: labelValue}))
-
-	log.Debug("JSON output for all pods labeled with: ", labelName)
-	log.Debug("Command: ", out)
-
-	var csvList CSVList
-	err := jsonUnmarshal([]byte(out), &csvList)
-	if err != nil {
-		return nil, err
-	}
-
-	return &csvList, nil
+	return getBackend().GetCSVs(allNamespaces, buildLabelQuery(configsections.Label{Prefix: tnfLabelPrefix, Name: labelName, Value: labelValue}))
 }
@@ -0,0 +1,36 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	resourceTypeNetworkPolicies = "networkpolicies"
+	ocGetNetworkPoliciesCommand = "oc get " + resourceTypeNetworkPolicies + " -n %s -o json"
+)
+
+// NetworkPolicyList holds the data from an `oc get networkpolicies -o json` command
+type NetworkPolicyList struct {
+	Items []networkingv1.NetworkPolicy `json:"items"`
+}
+
+// GetNetworkPolicies returns the NetworkPolicies of namespace.
+func GetNetworkPolicies(namespace string) (*NetworkPolicyList, error) {
+	return getBackend().GetNetworkPolicies(namespace)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestOcGetNetworkPolicies(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	var command string
+	execCommandOutput = func(c string) string {
+		command = c
+		contents, err := os.ReadFile(path.Join(filePath, "networkpolicies.json"))
+		assert.Nil(t, err)
+		return string(contents)
+	}
+
+	policies, err := (&ocBackend{}).GetNetworkPolicies("tnf")
+	assert.Nil(t, err)
+	assert.Equal(t, "oc get networkpolicies -n tnf -o json", command)
+	assert.Len(t, policies.Items, 2)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policies.Items[0].Spec.PolicyTypes)
+	assert.Equal(t, intstr.FromString("http"), *policies.Items[1].Spec.Ingress[0].Ports[0].Port)
+}
+
+func TestClientGoGetNetworkPolicies(t *testing.T) {
+	objects := []runtime.Object{
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "default-deny-all", Namespace: "tnf"}},
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+	}
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	policies, err := GetNetworkPolicies("tnf")
+	assert.Nil(t, err)
+	assert.Len(t, policies.Items, 1)
+	assert.Equal(t, "default-deny-all", policies.Items[0].Name)
+}
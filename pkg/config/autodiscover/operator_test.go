@@ -29,4 +29,6 @@ func TestBuildOperatorFromCSVResource(t *testing.T) {
 	assert.Equal(t, "CSVNamespace", operator.Namespace)
 	assert.Equal(t, "CSVName", operator.Name)
 	assert.Equal(t, []string{"OPERATOR_STATUS", "ANOTHER_TEST"}, operator.Tests)
+	assert.Equal(t, "alpha", operator.Channel)
+	assert.Equal(t, "", operator.InstallPlanApproval)
 }
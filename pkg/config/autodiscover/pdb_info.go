@@ -0,0 +1,36 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+)
+
+const (
+	resourceTypePodDisruptionBudgets = "poddisruptionbudgets"
+	ocGetPodDisruptionBudgetsCommand = "oc get " + resourceTypePodDisruptionBudgets + " -n %s -o json"
+)
+
+// PodDisruptionBudgetList holds the data from an `oc get poddisruptionbudgets -o json` command
+type PodDisruptionBudgetList struct {
+	Items []policyv1.PodDisruptionBudget `json:"items"`
+}
+
+// GetPodDisruptionBudgets returns the PodDisruptionBudgets of namespace.
+func GetPodDisruptionBudgets(namespace string) (*PodDisruptionBudgetList, error) {
+	return getBackend().GetPodDisruptionBudgets(namespace)
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestOcGetPodDisruptionBudgets(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	var command string
+	execCommandOutput = func(c string) string {
+		command = c
+		contents, err := os.ReadFile(path.Join(filePath, "poddisruptionbudgets.json"))
+		assert.Nil(t, err)
+		return string(contents)
+	}
+
+	pdbs, err := (&ocBackend{}).GetPodDisruptionBudgets("tnf")
+	assert.Nil(t, err)
+	assert.Equal(t, "oc get poddisruptionbudgets -n tnf -o json", command)
+	assert.Len(t, pdbs.Items, 2)
+	assert.Equal(t, intstr.FromInt(1), *pdbs.Items[0].Spec.MinAvailable)
+	assert.Nil(t, pdbs.Items[0].Spec.MaxUnavailable)
+	assert.Equal(t, intstr.FromString("50%"), *pdbs.Items[1].Spec.MaxUnavailable)
+	assert.Equal(t, map[string]string{"app": "test-sts"}, pdbs.Items[1].Spec.Selector.MatchLabels)
+}
+
+func TestClientGoGetPodDisruptionBudgets(t *testing.T) {
+	objects := []runtime.Object{
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "test-pdb", Namespace: "tnf"}},
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+	}
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	pdbs, err := GetPodDisruptionBudgets("tnf")
+	assert.Nil(t, err)
+	assert.Len(t, pdbs.Items, 1)
+	assert.Equal(t, "test-pdb", pdbs.Items[0].Name)
+}
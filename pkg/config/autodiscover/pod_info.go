@@ -21,6 +21,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -28,6 +29,8 @@ const (
 	cniNetworksStatusKey          = "k8s.v1.cni.cncf.io/networks-status"
 	resourceTypePods              = "pods"
 	podPhaseRunning               = "Running"
+	defaultPortProtocol           = "TCP"
+	ocGetPodCommand               = "oc get pods %s -n %s -o json"
 )
 
 var (
@@ -44,6 +47,7 @@ type PodResource struct {
 	Metadata struct {
 		Name              string                   `json:"name"`
 		Namespace         string                   `json:"namespace"`
+		UID               string                   `json:"uid"`
 		DeletionTimestamp string                   `json:"deletionTimestamp"`
 		Labels            map[string]string        `json:"labels"`
 		Annotations       map[string]string        `json:"annotations"`
@@ -54,19 +58,26 @@ type PodResource struct {
 		Containers     []struct {
 			Name  string `json:"name"`
 			Image string `json:"image"`
+			Ports []struct {
+				Name          string `json:"name"`
+				ContainerPort int    `json:"containerPort"`
+				Protocol      string `json:"protocol"`
+			} `json:"ports"`
 		} `json:"containers"`
 		NodeName string `json:"nodeName"`
 	} `json:"spec"`
 	Status struct {
-		// PodIPs this is currently unused, but part of the oc get output
-		// The listof IPs is contained in the Metadata->Annotations section
+		// PodIPs is the list of the IPs of the pod on the default network, read by GetIPs.
+		// The IPs per network are contained in the Metadata->Annotations section
 		// of this structure. This is a list of ips with the following format:
 		// [0]:map[string]string ["ip": "10.130.0.65", ]
 		PodIPs            []map[string]string `json:"podIPs"`
 		Phase             string              `json:"phase"`
 		ContainerStatuses []struct {
-			Name        string `json:"name"`
-			ContainerID string `json:"containerID"`
+			Name         string `json:"name"`
+			ContainerID  string `json:"containerID"`
+			Ready        bool   `json:"ready"`
+			RestartCount int    `json:"restartCount"`
 		} `json:"containerStatuses"`
 	} `json:"status"`
 }
@@ -79,6 +90,42 @@ type cniNetworkInterface struct {
 	DNS       map[string]interface{} `json:"dns"`
 }
 
+// GetIPs returns the IP addresses of the pod on the default network.
+func (pr *PodResource) GetIPs() []string {
+	var ips []string
+	for _, podIP := range pr.Status.PodIPs {
+		if ip := podIP["ip"]; ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// IsReady returns whether the pod is running, not being deleted, and all its containers are ready.
+func (pr *PodResource) IsReady() bool {
+	if pr.Metadata.DeletionTimestamp != "" || pr.Status.Phase != podPhaseRunning ||
+		len(pr.Status.ContainerStatuses) < len(pr.Spec.Containers) {
+		return false
+	}
+	for _, cs := range pr.Status.ContainerStatuses {
+		if !cs.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRestartCount returns the number of times the container called name restarted, 0 if the pod has no such
+// container.
+func (pr *PodResource) GetRestartCount(name string) int {
+	for _, cs := range pr.Status.ContainerStatuses {
+		if cs.Name == name {
+			return cs.RestartCount
+		}
+	}
+	return 0
+}
+
 func (pr *PodResource) hasAnnotation(annotationKey string) (present bool) {
 	_, present = pr.Metadata.Annotations[annotationKey]
 	return
@@ -155,22 +202,38 @@ func (pr *PodResource) getPodIPsPerNet() (map[string][]string, error) {
 	return ips, nil
 }
 
+// getDeclaredPorts returns the ports declared by the containers of the pod. The protocol defaults to TCP, as it
+// does in the API.
+func (pr *PodResource) getDeclaredPorts() []configsections.ContainerPort {
+	var ports []configsections.ContainerPort
+	for _, c := range pr.Spec.Containers {
+		for _, p := range c.Ports {
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = defaultPortProtocol
+			}
+			ports = append(ports, configsections.ContainerPort{Container: c.Name, Name: p.Name, Port: p.ContainerPort, Protocol: protocol})
+		}
+	}
+	return ports
+}
+
 func (pr *PodResource) annotationUnmarshalError(annotationKey string, err error) error {
 	return fmt.Errorf("error (%s) attempting to unmarshal value of annotation '%s' on pod '%s/%s'",
 		err, annotationKey, pr.Metadata.Namespace, pr.Metadata.Name)
 }
 
+// GetPod returns the full spec and status of the pod named name in namespace, e.g. for the checks needing more than
+// the fields of PodResource.
+func GetPod(namespace, name string) (*corev1.Pod, error) {
+	return getBackend().GetPod(namespace, name)
+}
+
 // GetPodsByLabelByNamespace will return all pods with a given label value in provided namespace.
 // If `labelValue` is an empty string, all pods with that
 // label will be returned, regardless of the labels value.
 func GetPodsByLabelByNamespace(label configsections.Label, namespace string) (*PodList, error) {
-	out := executeOcGetCommand(resourceTypePods, buildLabelQuery(label), namespace)
-
-	log.Debug("JSON output for all pods labeled with: ", label)
-	log.Debug("Command: ", out)
-
-	var podList PodList
-	err := jsonUnmarshal([]byte(out), &podList)
+	podList, err := getBackend().GetPods(namespace, buildLabelQuery(label))
 	if err != nil {
 		return nil, err
 	}
@@ -183,20 +246,14 @@ func GetPodsByLabelByNamespace(label configsections.Label, namespace string) (*P
 		}
 	}
 	podList.Items = pods
-	return &podList, nil
+	return podList, nil
 }
 
 // GetPodsByLabelByNamespace will return all pods with a given label value.
 // If `labelValue` is an empty string, all pods with that
 // label will be returned, regardless of the labels value.
 func GetPodsByLabel(label configsections.Label) (*PodList, error) {
-	out := executeOcGetAllCommand(resourceTypePods, buildLabelQuery(label))
-
-	log.Debug("JSON output for all pods labeled with: ", label)
-	log.Debug("Command: ", out)
-
-	var podList PodList
-	err := jsonUnmarshal([]byte(out), &podList)
+	podList, err := getBackend().GetPods(allNamespaces, buildLabelQuery(label))
 	if err != nil {
 		return nil, err
 	}
@@ -209,5 +266,5 @@ func GetPodsByLabel(label configsections.Label) (*PodList, error) {
 		}
 	}
 	podList.Items = pods
-	return &podList, nil
+	return podList, nil
 }
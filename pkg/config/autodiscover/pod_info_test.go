@@ -23,6 +23,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -59,3 +62,43 @@ func TestPodGetAnnotationValue(t *testing.T) {
 	assert.Equal(t, "eth0", val)
 	assert.Nil(t, err)
 }
+
+func TestOcGetPod(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	var command string
+	execCommandOutput = func(c string) string {
+		command = c
+		contents, err := os.ReadFile(testSubjectFilePath)
+		assert.Nil(t, err)
+		return string(contents)
+	}
+
+	pod, err := (&ocBackend{}).GetPod("tnf", "I'mAPodName")
+	assert.Nil(t, err)
+	assert.Equal(t, "oc get pods I'mAPodName -n tnf -o json", command)
+	assert.Equal(t, "I'mAPodName", pod.Name)
+	assert.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, corev1.ProtocolSCTP, pod.Spec.Containers[0].Ports[1].Protocol)
+}
+
+func TestClientGoGetPod(t *testing.T) {
+	privileged := true
+	objects := []runtime.Object{&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tnf"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "test", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+		}},
+	}}
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	pod, err := GetPod("tnf", "test")
+	assert.Nil(t, err)
+	assert.True(t, *pod.Spec.Containers[0].SecurityContext.Privileged)
+
+	_, err = GetPod("tnf", "missing")
+	assert.NotNil(t, err)
+}
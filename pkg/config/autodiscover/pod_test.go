@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 )
 
 func TestBuildPodUnderTest(t *testing.T) {
@@ -38,4 +39,11 @@ func TestBuildPodUnderTest(t *testing.T) {
 	assert.Equal(t, "tnf", subjectPod.Namespace)
 	assert.Equal(t, "I'mAPodName", subjectPod.Name)
 	assert.Equal(t, []string{"OneTestName", "AnotherTestName"}, subjectPod.Tests)
+	assert.Equal(t, "test", subjectPod.Labels["app"])
+	assert.Equal(t, []configsections.ContainerPort{
+		{Container: "I'mAContainer", Name: "http", Port: 8080, Protocol: "TCP"},
+		{Container: "I'mAContainer", Port: 38412, Protocol: "SCTP"},
+		{Container: "I'mAContainer", Port: 9090, Protocol: "TCP"},
+	}, subjectPod.DeclaredPorts)
+	assert.Empty(t, orchestratorPod.DeclaredPorts)
 }
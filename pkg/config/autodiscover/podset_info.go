@@ -18,14 +18,12 @@ package autodiscover
 
 import (
 	"encoding/json"
-	"fmt"
-	"strconv"
-	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 	"github.com/test-network-function/test-network-function/pkg/utils"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var (
@@ -53,6 +51,11 @@ type PodSetResource struct {
 
 	Spec struct {
 		Replicas int `json:"replicas"`
+		Template struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		} `json:"template"`
 	}
 }
 
@@ -75,54 +78,33 @@ func (podset *PodSetResource) GetReplicas() int {
 func (podset *PodSetResource) GetLabels() map[string]string {
 	return podset.Metadata.Labels
 }
+
+// GetPodLabels returns a map with the labels of the podset's pod template.
+func (podset *PodSetResource) GetPodLabels() map[string]string {
+	return podset.Spec.Template.Metadata.Labels
+}
+
+// GetHpa returns the HorizontalPodAutoscaler targeting the podset, or an empty Hpa if there is none.
 func (podset *PodSetResource) GetHpa() configsections.Hpa {
-	template := fmt.Sprintf("go-template='{{ range .items }}{{ if eq .spec.scaleTargetRef.name %q }}{{.spec.minReplicas}},{{.spec.maxReplicas}},{{.metadata.name}}{{ end }}{{ end }}'", podset.GetName())
-	ocCmd := fmt.Sprintf("oc get hpa -n %s -o %s", podset.GetNamespace(), template)
-	out := execCommandOutput(ocCmd)
-	if out != "" {
-		out := strings.Split(out, ",")
-		min, _ := strconv.Atoi(out[0])
-		max, _ := strconv.Atoi(out[1])
-		hpaNmae := out[2]
-		return configsections.Hpa{
-			MinReplicas: min,
-			MaxReplicas: max,
-			HpaName:     hpaNmae,
-		}
+	hpa, err := getBackend().GetHpa(podset.GetNamespace(), podset.GetName())
+	if err != nil {
+		log.Errorf("Unable to get the hpa of %s/%s: %v", podset.GetNamespace(), podset.GetName(), err)
 	}
-	return configsections.Hpa{}
+	return hpa
 }
 
 // GetTargetPodSetsByNamespace will return all podsets(deployments/statefulset )that have pods with a given label.
 func GetTargetPodSetsByNamespace(namespace string, targetLabel configsections.Label, resourceTypePodSet string) (*PodSetList, error) {
-	labelQuery := fmt.Sprintf("%q==%q", buildLabelName(targetLabel.Prefix, targetLabel.Name), targetLabel.Value)
-	jqArgs := fmt.Sprintf("'[.items[] | select(.spec.template.metadata.labels.%s)]'", labelQuery)
-	ocCmd := fmt.Sprintf("oc get %s -n %s -o json | jq %s", resourceTypePodSet, namespace, jqArgs)
-
-	out := execCommandOutput(ocCmd)
-
-	var podsetList PodSetList
-	err := jsonUnmarshal([]byte(out), &podsetList.Items)
-	if err != nil {
-		return nil, err
-	}
-
-	return &podsetList, nil
+	return getBackend().GetPodSets(namespace, resourceTypePodSet, targetLabel)
 }
 
 // GetTargetDeploymentsByLabel will return all deployments/statefulsets that have pods with a given label.
 func GetTargetPodSetsByLabel(targetLabel configsections.Label, resourceTypePodSet string) (*PodSetList, error) {
-	labelQuery := fmt.Sprintf("%q==%q", buildLabelName(targetLabel.Prefix, targetLabel.Name), targetLabel.Value)
-	jqArgs := fmt.Sprintf("'[.items[] | select(.spec.template.metadata.labels.%s)]'", labelQuery)
-	ocCmd := fmt.Sprintf("oc get %s -A -o json | jq %s", resourceTypePodSet, jqArgs)
-
-	out := execCommandOutput(ocCmd)
-
-	var podsetList PodSetList
-	err := jsonUnmarshal([]byte(out), &podsetList.Items)
-	if err != nil {
-		return nil, err
-	}
+	return getBackend().GetPodSets(allNamespaces, resourceTypePodSet, targetLabel)
+}
 
-	return &podsetList, nil
+// GetPodSetPods returns the pods of namespace carrying podLabels, the labels of the pod template of a podset.  The
+// pods being deleted are returned too.
+func GetPodSetPods(namespace string, podLabels map[string]string) (*PodList, error) {
+	return getBackend().GetPods(namespace, labels.SelectorFromSet(podLabels).String())
 }
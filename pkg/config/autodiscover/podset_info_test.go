@@ -26,6 +26,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -60,6 +63,10 @@ func TestPodGetAnnotationValue1(t *testing.T) {
 	labels := deployment.GetLabels()
 	assert.Equal(t, 1, len(labels))
 	assert.Equal(t, "test", labels["app"])
+
+	podLabels := deployment.GetPodLabels()
+	assert.Equal(t, 2, len(podLabels))
+	assert.Equal(t, "target", podLabels["test-network-function.com/generic"])
 }
 
 //nolint:funlen
@@ -135,3 +142,80 @@ func TestGetTargetDeploymentByNamespace(t *testing.T) {
 		}
 	}
 }
+
+func TestGetPodSetPods(t *testing.T) {
+	podLabels := map[string]string{"app": "test", "test-network-function.com/generic": "target"}
+	objects := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-0", Namespace: "tnf", Labels: podLabels},
+			Status:     corev1.PodStatus{PodIPs: []corev1.PodIP{{IP: "10.217.0.12"}, {IP: "fd00::12"}}},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-1", Namespace: "tnf", Labels: podLabels}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "tnf", Labels: map[string]string{"app": "test"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-0", Namespace: "other", Labels: podLabels}},
+	}
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	pods, err := GetPodSetPods("tnf", podLabels)
+	assert.Nil(t, err)
+	assert.Len(t, pods.Items, 2)
+	assert.Equal(t, "test-0", pods.Items[0].Metadata.Name)
+	assert.Equal(t, []string{"10.217.0.12", "fd00::12"}, pods.Items[0].GetIPs())
+	assert.Empty(t, pods.Items[1].GetIPs())
+}
+
+func TestPodReadiness(t *testing.T) {
+	podLabels := map[string]string{"app": "test"}
+	deleted := metav1.Now()
+	containers := []corev1.Container{{Name: "test"}, {Name: "sidecar"}}
+	ready := corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "test", Ready: true, RestartCount: 2},
+			{Name: "sidecar", Ready: true},
+		},
+	}
+	objects := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "tnf", Labels: podLabels, UID: "1"},
+			Spec:       corev1.PodSpec{Containers: containers},
+			Status:     ready,
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "restarting", Namespace: "tnf", Labels: podLabels},
+			Spec:       corev1.PodSpec{Containers: containers},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "test", Ready: false, RestartCount: 3},
+					{Name: "sidecar", Ready: true},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "starting", Namespace: "tnf", Labels: podLabels},
+			Spec:       corev1.PodSpec{Containers: containers},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "terminating", Namespace: "tnf", Labels: podLabels, DeletionTimestamp: &deleted},
+			Spec:       corev1.PodSpec{Containers: containers},
+			Status:     ready,
+		},
+	}
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	pods, err := GetPodSetPods("tnf", podLabels)
+	assert.Nil(t, err)
+	readiness := map[string]bool{}
+	restarts := map[string]int{}
+	for _, pod := range pods.Items {
+		readiness[pod.Metadata.Name] = pod.IsReady()
+		restarts[pod.Metadata.Name] = pod.GetRestartCount("test")
+	}
+	assert.Equal(t, map[string]bool{"ready": true, "restarting": false, "starting": false, "terminating": false}, readiness)
+	assert.Equal(t, map[string]int{"ready": 2, "restarting": 3, "starting": 0, "terminating": 2}, restarts)
+	assert.Equal(t, "1", pods.Items[0].Metadata.UID)
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	ocGetRolesCommand               = "oc get roles -A -o json"
+	ocGetRoleBindingsCommand        = "oc get rolebindings -A -o json"
+	ocGetClusterRolesCommand        = "oc get clusterroles -o json"
+	ocGetClusterRoleBindingsCommand = "oc get clusterrolebindings -o json"
+)
+
+// RBACList holds the RBAC objects of the whole cluster.
+type RBACList struct {
+	Roles               []rbacv1.Role
+	RoleBindings        []rbacv1.RoleBinding
+	ClusterRoles        []rbacv1.ClusterRole
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding
+}
+
+// GetRBAC returns the Roles and RoleBindings of all the namespaces, and the ClusterRoles and ClusterRoleBindings.
+func GetRBAC() (*RBACList, error) {
+	return getBackend().GetRBAC()
+}
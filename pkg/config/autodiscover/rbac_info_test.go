@@ -0,0 +1,80 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestOcGetRBAC(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	outputs := map[string]string{
+		"oc get roles -A -o json": `{"items": [{"metadata": {"name": "reader", "namespace": "tnf"},
+			"rules": [{"apiGroups": [""], "resources": ["pods"], "verbs": ["get"]}]}]}`,
+		"oc get rolebindings -A -o json": `{"items": [{"metadata": {"name": "reader", "namespace": "tnf"},
+			"subjects": [{"kind": "ServiceAccount", "name": "cnf", "namespace": "tnf"}],
+			"roleRef": {"apiGroup": "rbac.authorization.k8s.io", "kind": "Role", "name": "reader"}}]}`,
+		"oc get clusterroles -o json": `{"items": [{"metadata": {"name": "admin"},
+			"aggregationRule": {"clusterRoleSelectors": [{"matchLabels": {"aggregate-to-admin": "true"}}]}}]}`,
+		"oc get clusterrolebindings -o json": `{"items": []}`,
+	}
+	execCommandOutput = func(c string) string {
+		out, ok := outputs[c]
+		assert.True(t, ok, c)
+		return out
+	}
+
+	rbac, err := (&ocBackend{}).GetRBAC()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pods"}, rbac.Roles[0].Rules[0].Resources)
+	assert.Equal(t, "cnf", rbac.RoleBindings[0].Subjects[0].Name)
+	assert.Equal(t, "Role", rbac.RoleBindings[0].RoleRef.Kind)
+	assert.Equal(t, map[string]string{"aggregate-to-admin": "true"},
+		rbac.ClusterRoles[0].AggregationRule.ClusterRoleSelectors[0].MatchLabels)
+	assert.Empty(t, rbac.ClusterRoleBindings)
+
+	outputs["oc get clusterrolebindings -o json"] = "error: You must be logged in to the server"
+	_, err = (&ocBackend{}).GetRBAC()
+	assert.NotNil(t, err)
+}
+
+func TestClientGoGetRBAC(t *testing.T) {
+	objects := []runtime.Object{
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "tnf"}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "other"}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "tnf"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin"}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "cnf-admin"}},
+	}
+	SetBackend(newTestClientGoBackend(objects))
+	defer SetBackend(nil)
+
+	rbac, err := GetRBAC()
+	assert.Nil(t, err)
+	assert.Len(t, rbac.Roles, 2)
+	assert.Len(t, rbac.RoleBindings, 1)
+	assert.Equal(t, "admin", rbac.ClusterRoles[0].Name)
+	assert.Equal(t, "cnf-admin", rbac.ClusterRoleBindings[0].Name)
+}
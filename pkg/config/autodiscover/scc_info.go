@@ -0,0 +1,68 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	resourceTypeSCCs   = "securitycontextconstraints"
+	ocGetSCCsCommand   = "oc get " + resourceTypeSCCs + " -o json"
+	ocCanUseSCCCommand = "oc auth can-i use " + resourceTypeSCCs + "/%s --as=%s"
+	ocAsGroupOption    = " --as-group=%s"
+	// ocCanIAnswerSuffix keeps a "no" answer, for which `oc auth can-i` exits with 1, from failing the command.
+	ocCanIAnswerSuffix = " || true"
+)
+
+// SCCList holds the data from an `oc get securitycontextconstraints -o json` command
+type SCCList struct {
+	Items []SCCResource `json:"items"`
+}
+
+// SCCResource is a single SecurityContextConstraints from an `oc get securitycontextconstraints -o json` command
+type SCCResource struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	// Users and Groups can use the SCC whatever their RBAC permissions.
+	Users  []string `json:"users"`
+	Groups []string `json:"groups"`
+}
+
+// GetSCCs returns the SecurityContextConstraints of the cluster.
+func GetSCCs() (*SCCList, error) {
+	return getBackend().GetSCCs()
+}
+
+// CanUseSCC returns whether user, member of groups, is allowed to use the SCC named scc by RBAC.
+func CanUseSCC(user string, groups []string, scc string) (bool, error) {
+	return getBackend().CanUseSCC(user, groups, scc)
+}
+
+// parseCanI reads the answer of `oc auth can-i`.
+func parseCanI(command, out string) (bool, error) {
+	answer := strings.TrimSpace(out)
+	switch {
+	case strings.HasPrefix(answer, "yes"):
+		return true, nil
+	case strings.HasPrefix(answer, "no"):
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected output of %q: %q", command, answer)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package autodiscover
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestOcGetSCCs(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	var command string
+	execCommandOutput = func(c string) string {
+		command = c
+		contents, err := os.ReadFile(path.Join(filePath, "sccs.json"))
+		assert.Nil(t, err)
+		return string(contents)
+	}
+
+	sccs, err := (&ocBackend{}).GetSCCs()
+	assert.Nil(t, err)
+	assert.Equal(t, "oc get securitycontextconstraints -o json", command)
+	assert.Len(t, sccs.Items, 2)
+	assert.Equal(t, "privileged", sccs.Items[0].Metadata.Name)
+	assert.Contains(t, sccs.Items[0].Users, "system:admin")
+	assert.Equal(t, []string{"system:authenticated"}, sccs.Items[1].Groups)
+}
+
+func TestOcCanUseSCC(t *testing.T) {
+	origExecFunc := execCommandOutput
+	defer func() {
+		execCommandOutput = origExecFunc
+	}()
+	testCases := []struct {
+		output        string
+		expected      bool
+		expectedError bool
+	}{
+		{output: "yes\n", expected: true},
+		{output: "no\n"},
+		{output: "no - RBAC: clusterrole.rbac.authorization.k8s.io \"x\" not found\n"},
+		{output: "error: the server doesn't have a resource type", expectedError: true},
+		{output: "", expectedError: true},
+	}
+	for _, tc := range testCases {
+		var command string
+		execCommandOutput = func(c string) string {
+			command = c
+			return tc.output
+		}
+		allowed, err := (&ocBackend{}).CanUseSCC("system:serviceaccount:tnf:default", []string{"system:serviceaccounts", "system:serviceaccounts:tnf"}, "anyuid")
+		assert.Equal(t, "oc auth can-i use securitycontextconstraints/anyuid --as=system:serviceaccount:tnf:default "+
+			"--as-group=system:serviceaccounts --as-group=system:serviceaccounts:tnf || true", command)
+		assert.Equal(t, tc.expected, allowed)
+		assert.Equal(t, tc.expectedError, err != nil)
+	}
+}
+
+func TestClientGoGetSCCs(t *testing.T) {
+	scc := newUnstructured("security.openshift.io/v1", "SecurityContextConstraints", "", "anyuid", nil)
+	scc.Object["groups"] = []interface{}{"system:cluster-admins"}
+	// The fake client guesses the wrong plural for the kind of initial objects, so the SCC is created explicitly.
+	b := newTestClientGoBackend(nil)
+	_, err := b.dynamic.Resource(SCCGroupVersionResource).Create(context.TODO(), scc, metav1.CreateOptions{})
+	assert.Nil(t, err)
+	SetBackend(b)
+	defer SetBackend(nil)
+
+	sccs, err := GetSCCs()
+	assert.Nil(t, err)
+	assert.Len(t, sccs.Items, 1)
+	assert.Equal(t, "anyuid", sccs.Items[0].Metadata.Name)
+	assert.Equal(t, []string{"system:cluster-admins"}, sccs.Items[0].Groups)
+}
+
+func TestClientGoCanUseSCC(t *testing.T) {
+	b := newTestClientGoBackend(nil)
+	var spec authorizationv1.SubjectAccessReviewSpec
+	b.clientset.(*fake.Clientset).PrependReactor("create", "subjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			spec = review.Spec
+			if review.Spec.ResourceAttributes.Name == "broken" {
+				return true, nil, errors.New("forbidden")
+			}
+			review.Status.Allowed = review.Spec.ResourceAttributes.Name == "anyuid"
+			return true, review, nil
+		})
+	SetBackend(b)
+	defer SetBackend(nil)
+
+	allowed, err := CanUseSCC("system:serviceaccount:tnf:default", []string{"system:serviceaccounts"}, "anyuid")
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, "system:serviceaccount:tnf:default", spec.User)
+	assert.Equal(t, []string{"system:serviceaccounts"}, spec.Groups)
+	assert.Equal(t, authorizationv1.ResourceAttributes{Verb: "use", Group: "security.openshift.io",
+		Resource: "securitycontextconstraints", Name: "anyuid"}, *spec.ResourceAttributes)
+
+	allowed, err = CanUseSCC("system:serviceaccount:tnf:default", nil, "privileged")
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+
+	_, err = CanUseSCC("system:serviceaccount:tnf:default", nil, "broken")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+)
+
+// Capability is an API or a facility of the cluster that some tests need.
+type Capability string
+
+const (
+	// MachineConfigCapability is the OpenShift MachineConfig API, giving the kernel arguments of the nodes.
+	MachineConfigCapability Capability = "machineconfig"
+	// ClusterVersionCapability is the OpenShift ClusterVersion API, giving the OpenShift version.
+	ClusterVersionCapability Capability = "clusterversion"
+	// OLMCapability is the Operator Lifecycle Manager API: ClusterServiceVersions, Subscriptions...
+	OLMCapability Capability = "olm"
+	// SCCCapability is the OpenShift SecurityContextConstraints API.
+	SCCCapability Capability = "scc"
+	// DebugPodsCapability is the debug daemonset of the test suite, running privileged pods on the nodes.
+	DebugPodsCapability Capability = "debug-pods"
+)
+
+var (
+	// apiCapabilities are the capabilities given by serving an API group version.
+	apiCapabilities = map[string]Capability{
+		"machineconfiguration.openshift.io/v1": MachineConfigCapability,
+		"config.openshift.io/v1":               ClusterVersionCapability,
+		"operators.coreos.com/v1alpha1":        OLMCapability,
+		"security.openshift.io/v1":             SCCCapability,
+	}
+
+	// OpenShiftCapabilities are the capabilities only OpenShift clusters have, OLM aside.
+	OpenShiftCapabilities = []Capability{MachineConfigCapability, ClusterVersionCapability, SCCCapability}
+
+	// getAPIVersions is a var so the API server can be spoofed in tests.
+	getAPIVersions = autodiscover.GetAPIVersions
+)
+
+// Capabilities is a set of capabilities.
+type Capabilities map[Capability]bool
+
+// Missing returns the capabilities of required that are not in c.
+func (c Capabilities) Missing(required ...Capability) []Capability {
+	var missing []Capability
+	for _, r := range required {
+		if !c[r] {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// Without returns a copy of c without the capabilities of removed.
+func (c Capabilities) Without(removed ...Capability) Capabilities {
+	out := Capabilities{}
+	for k, v := range c {
+		out[k] = v
+	}
+	for _, r := range removed {
+		delete(out, r)
+	}
+	return out
+}
+
+// String returns the sorted capabilities of c.
+func (c Capabilities) String() string {
+	names := make([]string, 0, len(c))
+	for k, v := range c {
+		if v {
+			names = append(names, string(k))
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// apiVersionCapabilities returns the capabilities given by the served API group versions.
+func apiVersionCapabilities(apiVersions []string) Capabilities {
+	capabilities := Capabilities{}
+	for _, v := range apiVersions {
+		if c, ok := apiCapabilities[v]; ok {
+			capabilities[c] = true
+		}
+	}
+	return capabilities
+}
+
+// Capabilities returns the capabilities of the cluster. They are probed once autodiscovery has found the nodes, and
+// again after a refresh since the debug pods may have changed.
+func (env *TestEnvironment) Capabilities() Capabilities {
+	if env.capabilities == nil {
+		env.capabilities = env.probeCapabilities()
+	}
+	return env.capabilities
+}
+
+// probeCapabilities returns the capabilities given by the APIs the cluster serves and the debug pods. When the APIs
+// can't be listed, the cluster is assumed to have them all so that no test is skipped because of a transient error.
+func (env *TestEnvironment) probeCapabilities() Capabilities {
+	var capabilities Capabilities
+	apiVersions, err := getAPIVersions()
+	if err != nil {
+		log.Errorf("Unable to list the API versions of the cluster, assuming it serves all of them: %v", err)
+		capabilities = Capabilities{}
+		for _, c := range apiCapabilities {
+			capabilities[c] = true
+		}
+	} else {
+		capabilities = apiVersionCapabilities(apiVersions)
+	}
+	for _, node := range env.NodesUnderTest {
+		if node.HasDebugPod() {
+			capabilities[DebugPodsCapability] = true
+			break
+		}
+	}
+	log.Infof("Cluster capabilities: %s", capabilities)
+	return capabilities
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	testCases := []struct {
+		apiVersions          []string
+		apiErr               error
+		nodes                map[string]*NodeConfig
+		expectedCapabilities Capabilities
+	}{
+		{
+			// kind: nothing but the Kubernetes APIs.
+			apiVersions:          []string{"v1", "apps/v1", "networking.k8s.io/v1"},
+			nodes:                map[string]*NodeConfig{"n1": {Name: "n1"}},
+			expectedCapabilities: Capabilities{},
+		},
+		{
+			// Kubernetes with OLM installed and debug pods.
+			apiVersions: []string{"v1", "operators.coreos.com/v1alpha1"},
+			nodes: map[string]*NodeConfig{
+				"n1": {Name: "n1"},
+				"n2": {Name: "n2", DebugContainer: &configsections.Container{}},
+			},
+			expectedCapabilities: Capabilities{OLMCapability: true, DebugPodsCapability: true},
+		},
+		{
+			apiVersions: []string{"v1", "config.openshift.io/v1", "machineconfiguration.openshift.io/v1",
+				"operators.coreos.com/v1alpha1", "security.openshift.io/v1"},
+			expectedCapabilities: Capabilities{MachineConfigCapability: true, ClusterVersionCapability: true,
+				OLMCapability: true, SCCCapability: true},
+		},
+		{
+			// The APIs are assumed to be served when they can't be listed.
+			apiErr: errors.New("timeout"),
+			expectedCapabilities: Capabilities{MachineConfigCapability: true, ClusterVersionCapability: true,
+				OLMCapability: true, SCCCapability: true},
+		},
+	}
+
+	origFunc := getAPIVersions
+	defer func() {
+		getAPIVersions = origFunc
+	}()
+	for _, tc := range testCases {
+		getAPIVersions = func() ([]string, error) {
+			return tc.apiVersions, tc.apiErr
+		}
+		env := &TestEnvironment{NodesUnderTest: tc.nodes}
+		assert.Equal(t, tc.expectedCapabilities, env.Capabilities())
+	}
+}
+
+func TestCapabilitiesProbedOnce(t *testing.T) {
+	origFunc := getAPIVersions
+	defer func() {
+		getAPIVersions = origFunc
+	}()
+	calls := 0
+	getAPIVersions = func() ([]string, error) {
+		calls++
+		return []string{"security.openshift.io/v1"}, nil
+	}
+	env := &TestEnvironment{}
+	assert.Equal(t, Capabilities{SCCCapability: true}, env.Capabilities())
+	assert.Equal(t, Capabilities{SCCCapability: true}, env.Capabilities())
+	assert.Equal(t, 1, calls)
+}
+
+func TestCapabilitiesMissing(t *testing.T) {
+	c := Capabilities{OLMCapability: true, DebugPodsCapability: true}
+	assert.Nil(t, c.Missing())
+	assert.Nil(t, c.Missing(OLMCapability, DebugPodsCapability))
+	assert.Equal(t, []Capability{MachineConfigCapability}, c.Missing(DebugPodsCapability, MachineConfigCapability))
+	assert.Equal(t, []Capability{OLMCapability}, c.Without(OLMCapability).Missing(OLMCapability))
+	assert.True(t, c[OLMCapability], "Without must not modify its receiver")
+	assert.Equal(t, "debug-pods, olm", c.String())
+}
@@ -19,6 +19,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/onsi/gomega"
@@ -28,6 +30,7 @@ import (
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ipaddr"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/utils"
 	"gopkg.in/yaml.v2"
@@ -37,12 +40,16 @@ const (
 	configurationFilePathEnvironmentVariableKey = "TNF_CONFIGURATION_PATH"
 	defaultConfigurationFilePath                = "tnf_config.yml"
 	defaultTimeoutSeconds                       = 10
+	maxSessionsPerNodeEnvironmentVariableKey    = "TNF_MAX_SESSIONS_PER_NODE"
+	defaultMaxSessionsPerNode                   = 4
 )
 
 var (
 	// testEnvironment is the singleton instance of `TestEnvironment`, accessed through `GetTestEnvironment`
 	testEnvironment             TestEnvironment
 	expectersVerboseModeEnabled = false
+	// debugSessionsMutex guards the lazy creation of the NodeConfig debug session pools.
+	debugSessionsMutex sync.Mutex
 )
 
 // getConfigurationFilePathFromEnvironment returns the test configuration file.
@@ -54,6 +61,15 @@ func getConfigurationFilePathFromEnvironment() string {
 	return defaultConfigurationFilePath
 }
 
+// getMaxSessionsPerNode returns how many sessions may be opened at the same time to a node debug pod.
+func getMaxSessionsPerNode() int {
+	n, err := strconv.Atoi(os.Getenv(maxSessionsPerNodeEnvironmentVariableKey))
+	if err != nil || n < 1 {
+		return defaultMaxSessionsPerNode
+	}
+	return n
+}
+
 type NodeConfig struct {
 	// same Name as the one inside Node structure
 	Name string
@@ -64,6 +80,8 @@ type NodeConfig struct {
 	podset bool
 	// debug indicates if the node should have a debug pod
 	debug bool
+	// debugSessions are the extra sessions to the debug pod used by checks running in parallel
+	debugSessions *parallel.SessionPool
 }
 
 func (n NodeConfig) IsMaster() bool {
@@ -81,12 +99,40 @@ func (n NodeConfig) HasDebugPod() bool {
 	return n.DebugContainer != nil
 }
 
+// DebugSessions returns the pool of sessions to the node debug pod. Unlike DebugContainer.GetOc(), a session
+// taken from the pool is not shared with other checks, so checks targeting the same node can run in parallel.
+// The number of sessions opened to the debug pod is capped by TNF_MAX_SESSIONS_PER_NODE.
+func (n *NodeConfig) DebugSessions() *parallel.SessionPool {
+	debugSessionsMutex.Lock()
+	defer debugSessionsMutex.Unlock()
+	if n.debugSessions == nil {
+		c := n.DebugContainer
+		n.debugSessions = parallel.NewSessionPool(getMaxSessionsPerNode(), func() (*interactive.Oc, error) {
+			if c == nil {
+				return nil, fmt.Errorf("node %s has no debug pod", n.Name)
+			}
+			return configsections.GetOcSession(c.PodName, c.ContainerName, c.Namespace, DefaultTimeout, interactive.Verbose(expectersVerboseModeEnabled), interactive.SendTimeout(DefaultTimeout)), nil
+		})
+	}
+	return n.debugSessions
+}
+
+// closeDebugSessions closes the sessions opened by DebugSessions.
+func (n *NodeConfig) closeDebugSessions() {
+	debugSessionsMutex.Lock()
+	defer debugSessionsMutex.Unlock()
+	if n.debugSessions != nil {
+		n.debugSessions.Close()
+		n.debugSessions = nil
+	}
+}
+
 // DefaultTimeout for creating new interactive sessions (oc, ssh, tty)
 var DefaultTimeout = time.Duration(defaultTimeoutSeconds) * time.Second
 
-// Extract a container IP address for a particular device.  This is needed since container default network IP address
-// is served by dhcp, and thus is ephemeral.
-func getContainerDefaultNetworkIPAddress(initiatingPodNodeOc *interactive.Oc, nodeName, containerID, runtime, dev string) (string, error) {
+// Extract a container IPv4 and IPv6 addresses for a particular device, either may be empty on single stack clusters.
+// This is needed since container default network IP address is served by dhcp, and thus is ephemeral.
+func getContainerDefaultNetworkIPAddress(initiatingPodNodeOc *interactive.Oc, nodeName, containerID, runtime, dev string) (ipv4, ipv6 string, err error) {
 	log.Infof("Getting IP Information for: %s(%s) in ns=%s", initiatingPodNodeOc.GetPodName(), initiatingPodNodeOc.GetPodContainerName(), initiatingPodNodeOc.GetPodNamespace())
 	containerPID := utils.GetContainerPID(nodeName, initiatingPodNodeOc, containerID, runtime)
 	ipTester := ipaddr.NewIPAddrNsenter(DefaultTimeout, containerPID, dev)
@@ -94,9 +140,9 @@ func getContainerDefaultNetworkIPAddress(initiatingPodNodeOc *interactive.Oc, no
 	gomega.Expect(err).To(gomega.BeNil())
 	result, err := test.Run()
 	if result == tnf.SUCCESS && err == nil {
-		return ipTester.GetIPv4Address(), nil
+		return ipTester.GetIPv4Address(), ipTester.GetIPv6Address(), nil
 	}
-	return "", err
+	return "", "", err
 }
 
 // TestEnvironment includes the representation of the current state of the test targets and partners as well as the test configuration
@@ -122,6 +168,8 @@ type TestEnvironment struct {
 	needsRefresh bool
 	// context for executing command in local shell
 	localShell *interactive.Context
+	// capabilities of the cluster, probed on first use
+	capabilities Capabilities
 }
 
 func (env *TestEnvironment) GetLocalShellContext() *interactive.Context {
@@ -161,10 +209,29 @@ func (env *TestEnvironment) loadConfigFromFile(filePath string) error {
 	if err != nil {
 		return err
 	}
+	if err = env.applyWaivers(); err != nil {
+		return err
+	}
 	env.loaded = true
 	return nil
 }
 
+// applyWaivers checks the configured waivers and makes the tests apply the ones that have not expired.
+func (env *TestEnvironment) applyWaivers() error {
+	now := time.Now()
+	for i := range env.Config.Waivers {
+		w := &env.Config.Waivers[i]
+		if err := w.Validate(); err != nil {
+			return err
+		}
+		if w.Expired(now) {
+			log.Warnf("The waiver of %s expired on %s, it is ignored", w.TestID, w.Expiry)
+		}
+	}
+	tnf.SetWaiverFunc(configsections.WaiverFunc(env.Config.Waivers, now))
+	return nil
+}
+
 // LoadAndRefresh loads the config file if not loaded already and performs autodiscovery if needed
 func (env *TestEnvironment) LoadAndRefresh() {
 	if !env.loaded {
@@ -197,6 +264,7 @@ func (env *TestEnvironment) reset() {
 	env.NodesUnderTest = nil
 	env.Config.Nodes = nil
 	env.DebugContainers = nil
+	env.capabilities = nil
 }
 
 // Resets the environment during the intrusive tests since all the connections are affected
@@ -208,6 +276,7 @@ func (env *TestEnvironment) ResetOc() {
 			log.Infof("Closing session to node %s", node.Name)
 			node.DebugContainer.CloseOc()
 		}
+		node.closeDebugSessions()
 	}
 	// Delete all remaining sessions before re-creating them
 	for _, cut := range env.ContainersUnderTest {
@@ -368,10 +437,11 @@ func (env *TestEnvironment) recordPodsDefaultIP(pods []*configsections.Pod) {
 		// the first container is used to get the network namespace
 		c := p.ContainerList[0]
 		var defaultIPAddress = "UNKNOWN"
+		var defaultIPv6Address string
 		var err error
 		if _, ok := env.ContainersToExcludeFromConnectivityTests[c.ContainerIdentifier]; !ok {
 			if env.NodesUnderTest[c.NodeName].HasDebugPod() {
-				defaultIPAddress, err = getContainerDefaultNetworkIPAddress(env.NodesUnderTest[c.NodeName].DebugContainer.Oc,
+				defaultIPAddress, defaultIPv6Address, err = getContainerDefaultNetworkIPAddress(env.NodesUnderTest[c.NodeName].DebugContainer.Oc,
 					c.NodeName,
 					c.ContainerUID,
 					c.ContainerRuntime,
@@ -387,6 +457,7 @@ func (env *TestEnvironment) recordPodsDefaultIP(pods []*configsections.Pod) {
 			}
 		}
 		p.DefaultNetworkIPAddress = defaultIPAddress
+		p.DefaultNetworkIPv6Address = defaultIPv6Address
 	}
 }
 
@@ -38,6 +38,12 @@ type Operator struct {
 
 	// Subscription name is required field, Name of used subscription.
 	SubscriptionName string `yaml:"subscriptionName" json:"subscriptionName"`
+
+	// Channel is optional, the channel the subscription is expected to follow.
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
+
+	// InstallPlanApproval is optional, the approval strategy the subscription is expected to use: Automatic or Manual.
+	InstallPlanApproval string `yaml:"installPlanApproval,omitempty" json:"installPlanApproval,omitempty"`
 }
 
 // Namespace struct defines namespace properties
@@ -60,12 +66,20 @@ type TestConfiguration struct {
 	CertifiedContainerInfo []ContainerImageIdentifier `yaml:"certifiedcontainerinfo,omitempty" json:"certifiedcontainerinfo,omitempty"`
 	// CheckDiscoveredContainerCertificationStatus controls whether the container certification test will validate images used by autodiscovered containers, in addition to the configured image list
 	CheckDiscoveredContainerCertificationStatus bool `yaml:"checkDiscoveredContainerCertificationStatus" json:"checkDiscoveredContainerCertificationStatus"`
+	// CertifiedContainerCatalogSnapshot is the file of an offline snapshot of the container catalog the container
+	// images are looked up in instead of the catalog API, for clusters that cannot reach it.
+	CertifiedContainerCatalogSnapshot string `yaml:"certifiedContainerCatalogSnapshot,omitempty" json:"certifiedContainerCatalogSnapshot,omitempty"`
 	// CertifiedOperatorInfo is list of operator bundle names that are queried for certification status.
 	CertifiedOperatorInfo []CertifiedOperatorRequestInfo `yaml:"certifiedoperatorinfo,omitempty" json:"certifiedoperatorinfo,omitempty"`
 	// CRDs section.
 	CrdFilters []CrdFilter `yaml:"targetCrdFilters" json:"targetCrdFilters"`
 	// AcceptedKernelTaints
 	AcceptedKernelTaints []AcceptedKernelTaintsInfo `yaml:"acceptedKernelTaints,omitempty" json:"acceptedKernelTaints,omitempty"`
+	// GuaranteedQoSContainers selects the containers needing exclusive CPUs: their pods must be in the Guaranteed QoS
+	// class and they must request integer CPUs.
+	GuaranteedQoSContainers []ContainerSelector `yaml:"guaranteedQosContainers,omitempty" json:"guaranteedQosContainers,omitempty"`
+	// Waivers lists the accepted deviations, per test and object.
+	Waivers []Waiver `yaml:"waivers,omitempty" json:"waivers,omitempty"`
 }
 
 // TestPartner contains the helper containers that can be used to facilitate tests
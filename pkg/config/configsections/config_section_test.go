@@ -124,7 +124,7 @@ func loadOperatorConfig() {
 	operator := Operator{}
 	operator.Name = operatorName
 	operator.Namespace = operatorNameSpace
-	operator.Tests = []string{testcases.OperatorStatus}
+	operator.Tests = []string{"OPERATOR_STATUS"}
 	test.Operators = append(test.Operators, operator)
 	loadPodConfig()
 }
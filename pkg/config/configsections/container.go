@@ -74,11 +74,10 @@ func GetOcSession(pod, container, namespace string, timeout time.Duration, optio
 	var containerOc *interactive.Oc
 	ocChan := make(chan *interactive.Oc)
 
-	goExpectSpawner := interactive.NewGoExpectSpawner()
-	var spawner interactive.Spawner = goExpectSpawner
+	spawner := interactive.CreateGoExpectSpawner()
 
 	go func() {
-		oc, outCh, err := interactive.SpawnOc(&spawner, pod, container, namespace, timeout, options...)
+		oc, outCh, err := interactive.SpawnOc(spawner, pod, container, namespace, timeout, options...)
 		gomega.Expect(outCh).ToNot(gomega.BeNil())
 		gomega.Expect(err).To(gomega.BeNil())
 		// Set up a go routine which reads from the error channel
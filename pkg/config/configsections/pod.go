@@ -35,6 +35,10 @@ type Pod struct {
 
 	DefaultNetworkIPAddress string `yaml:"defaultnetworkipaddress" json:"defaultnetworkipaddress"`
 
+	// DefaultNetworkIPv6Address is the global IPv6 address of the default network interface of dual-stack and IPv6
+	// pods.
+	DefaultNetworkIPv6Address string `yaml:"defaultnetworkipv6address,omitempty" json:"defaultnetworkipv6address,omitempty"`
+
 	// OpenShift Default network interface name (i.e., eth0)
 	DefaultNetworkDevice string `yaml:"defaultNetworkDevice" json:"defaultNetworkDevice"`
 
@@ -46,4 +50,22 @@ type Pod struct {
 
 	// IsManaged indicates whether this pod belongs to any other resource (deployment/statefulset).
 	IsManaged bool
+
+	// Labels are the labels of the pod, used to find the NetworkPolicies selecting it.
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// DeclaredPorts are the ports declared by the containers of the pod.
+	DeclaredPorts []ContainerPort `yaml:"declaredPorts,omitempty" json:"declaredPorts,omitempty"`
+}
+
+// ContainerPort is a port declared by a container.
+type ContainerPort struct {
+	// Container is the name of the container declaring the port.
+	Container string `yaml:"container" json:"container"`
+	// Name is the optional name of the port, NetworkPolicies may refer to it.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Port is the port number.
+	Port int `yaml:"port" json:"port"`
+	// Protocol is TCP, UDP or SCTP.
+	Protocol string `yaml:"protocol" json:"protocol"`
 }
@@ -21,6 +21,8 @@ type PodSet struct {
 	Name      string
 	Namespace string
 	Replicas  int
+	// PodLabels are the labels of the pod template of the podset.
+	PodLabels map[string]string
 	Hpa       Hpa
 	Type      PodSetType
 }
@@ -0,0 +1,40 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import "path"
+
+// ContainerSelector selects containers by namespace, pod and container name. Each selector is a pattern as matched
+// by path.Match, e.g. "dpdk-*" for the pods of a deployment, and an empty one selects any name.
+type ContainerSelector struct {
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Pod       string `yaml:"pod,omitempty" json:"pod,omitempty"`
+	Container string `yaml:"container,omitempty" json:"container,omitempty"`
+}
+
+// Selects returns whether the selector selects the container. A malformed pattern selects no container.
+func (s *ContainerSelector) Selects(namespace, pod, container string) bool {
+	return matches(s.Namespace, namespace) && matches(s.Pod, pod) && matches(s.Container, container)
+}
+
+func matches(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerSelectorSelects(t *testing.T) {
+	testCases := []struct {
+		selector ContainerSelector
+		expected bool
+	}{
+		{selector: ContainerSelector{}, expected: true},
+		{selector: ContainerSelector{Namespace: "tnf"}, expected: true},
+		{selector: ContainerSelector{Namespace: "tnf", Pod: "dpdk-*", Container: "worker"}, expected: true},
+		{selector: ContainerSelector{Pod: "dpdk-7f9c6b-*"}, expected: false},
+		{selector: ContainerSelector{Container: "sidecar"}, expected: false},
+		{selector: ContainerSelector{Namespace: "other"}, expected: false},
+		{selector: ContainerSelector{Pod: "dpdk-["}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.selector.Selects("tnf", "dpdk-5d8f7c9b4-x2k8p", "worker"), "%+v", tc.selector)
+	}
+}
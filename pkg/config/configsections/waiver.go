@@ -0,0 +1,109 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+// WaiverExpiryLayout is the layout of Waiver.Expiry, a date.
+const WaiverExpiryLayout = "2006-01-02"
+
+// Waiver is an accepted deviation: the failures of test TestID for the objects selected by Namespace, Pod,
+// Container, Node and Name do not fail the test until the waiver expires. An empty selector selects any object, and a
+// selector that does not apply to an object type, e.g. Node for a pod, selects no object of that type.
+type Waiver struct {
+	// TestID is the ID of the waived test, as used in the test labels, e.g. "access-control-host-resource".
+	TestID    string `yaml:"testId" json:"testId"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Pod       string `yaml:"pod,omitempty" json:"pod,omitempty"`
+	Container string `yaml:"container,omitempty" json:"container,omitempty"`
+	Node      string `yaml:"node,omitempty" json:"node,omitempty"`
+	// Name selects an operator, a service account, a deployment or a statefulset by name.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Justification explains why the deviation is accepted, it is recorded in the claim with the waived objects.
+	Justification string `yaml:"justification" json:"justification"`
+	// Expiry is the last day the waiver applies, as YYYY-MM-DD.
+	Expiry string `yaml:"expiry" json:"expiry"`
+}
+
+// Validate checks that the waiver has a test ID, a justification and a valid expiry date.
+func (w *Waiver) Validate() error {
+	if w.TestID == "" {
+		return errors.New("waiver without testId")
+	}
+	if w.Justification == "" {
+		return fmt.Errorf("waiver of %s without justification", w.TestID)
+	}
+	if _, err := time.Parse(WaiverExpiryLayout, w.Expiry); err != nil {
+		return fmt.Errorf("waiver of %s has an invalid expiry date: %v", w.TestID, err)
+	}
+	return nil
+}
+
+// Expired returns whether the waiver no longer applies at now. A waiver applies until the end of its expiry day.
+func (w *Waiver) Expired(now time.Time) bool {
+	expiry, err := time.ParseInLocation(WaiverExpiryLayout, w.Expiry, now.Location())
+	if err != nil {
+		return true
+	}
+	return !now.Before(expiry.AddDate(0, 0, 1))
+}
+
+// Covers returns whether the waiver covers the failure of object o in test testID.
+func (w *Waiver) Covers(testID string, o *tnf.CheckedObject) bool {
+	if w.TestID != testID {
+		return false
+	}
+	var namespace, pod, container, node, name string
+	switch o.Type {
+	case tnf.PodObject:
+		namespace, pod = o.Namespace, o.Name
+	case tnf.ContainerObject:
+		namespace, pod, container = o.Namespace, o.Pod, o.Name
+	case tnf.PortObject:
+		namespace, pod = o.Namespace, o.Pod
+	case tnf.NodeObject:
+		node = o.Name
+	case tnf.NamespaceObject:
+		namespace = o.Name
+	case tnf.OperatorObject, tnf.ServiceAccountObject, tnf.DeploymentObject, tnf.StatefulSetObject:
+		namespace, name = o.Namespace, o.Name
+	}
+	return selects(w.Namespace, namespace) && selects(w.Pod, pod) && selects(w.Container, container) && selects(w.Node, node) &&
+		selects(w.Name, name)
+}
+
+func selects(selector, value string) bool {
+	return selector == "" || selector == value
+}
+
+// WaiverFunc returns a tnf.WaiverFunc applying the waivers that have not expired at now.
+func WaiverFunc(waivers []Waiver, now time.Time) tnf.WaiverFunc {
+	return func(testID string, o *tnf.CheckedObject) (string, bool) {
+		for i := range waivers {
+			if w := &waivers[i]; !w.Expired(now) && w.Covers(testID, o) {
+				return w.Justification, true
+			}
+		}
+		return "", false
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package configsections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+func TestWaiverValidate(t *testing.T) {
+	testCases := []struct {
+		waiver      Waiver
+		expectedErr bool
+	}{
+		{waiver: Waiver{TestID: "lifecycle-pod-owner-type", Justification: "singleton", Expiry: "2022-12-31"}},
+		{waiver: Waiver{Justification: "singleton", Expiry: "2022-12-31"}, expectedErr: true},
+		{waiver: Waiver{TestID: "lifecycle-pod-owner-type", Expiry: "2022-12-31"}, expectedErr: true},
+		{waiver: Waiver{TestID: "lifecycle-pod-owner-type", Justification: "singleton"}, expectedErr: true},
+		{waiver: Waiver{TestID: "lifecycle-pod-owner-type", Justification: "singleton", Expiry: "31/12/2022"}, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.waiver.Validate()
+		assert.Equal(t, tc.expectedErr, err != nil, "%+v", tc.waiver)
+	}
+}
+
+func TestWaiverExpired(t *testing.T) {
+	w := Waiver{TestID: "lifecycle-pod-owner-type", Justification: "singleton", Expiry: "2022-06-30"}
+	assert.False(t, w.Expired(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)))
+	// The waiver applies until the end of its expiry day.
+	assert.False(t, w.Expired(time.Date(2022, 6, 30, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, w.Expired(time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestWaiverCovers(t *testing.T) {
+	const testID = "access-control-host-resource-HOST_NETWORK_CHECK"
+	pod := &tnf.CheckedObject{Type: tnf.PodObject, Namespace: "tnf", Name: "test-0"}
+	container := &tnf.CheckedObject{Type: tnf.ContainerObject, Namespace: "tnf", Pod: "test-0", Name: "c1"}
+	node := &tnf.CheckedObject{Type: tnf.NodeObject, Name: "worker-0"}
+	port := &tnf.CheckedObject{Type: tnf.PortObject, Namespace: "tnf", Pod: "test-0", Name: "8080/TCP"}
+	serviceAccount := &tnf.CheckedObject{Type: tnf.ServiceAccountObject, Namespace: "tnf", Name: "cnf"}
+	deployment := &tnf.CheckedObject{Type: tnf.DeploymentObject, Namespace: "tnf", Name: "test"}
+	sibling := &tnf.CheckedObject{Type: tnf.DeploymentObject, Namespace: "tnf", Name: "test-2"}
+	statefulSet := &tnf.CheckedObject{Type: tnf.StatefulSetObject, Namespace: "tnf", Name: "db"}
+
+	testCases := []struct {
+		waiver   Waiver
+		object   *tnf.CheckedObject
+		expected bool
+	}{
+		{waiver: Waiver{TestID: testID}, object: pod, expected: true},
+		{waiver: Waiver{TestID: "lifecycle-pod-owner-type"}, object: pod, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Pod: "test-0"}, object: pod, expected: true},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Pod: "test-1"}, object: pod, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Pod: "test-0"}, object: container, expected: true},
+		{waiver: Waiver{TestID: testID, Container: "c1"}, object: container, expected: true},
+		{waiver: Waiver{TestID: testID, Container: "c1"}, object: pod, expected: false},
+		{waiver: Waiver{TestID: testID, Node: "worker-0"}, object: node, expected: true},
+		{waiver: Waiver{TestID: testID, Node: "worker-0"}, object: pod, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf"}, object: node, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Pod: "test-0"}, object: port, expected: true},
+		{waiver: Waiver{TestID: testID, Container: "c1"}, object: port, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf"}, object: serviceAccount, expected: true},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Pod: "test-0"}, object: serviceAccount, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf"}, object: deployment, expected: true},
+		{waiver: Waiver{TestID: testID, Namespace: "other"}, object: deployment, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Name: "test"}, object: deployment, expected: true},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Name: "test"}, object: sibling, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Name: "test"}, object: statefulSet, expected: false},
+		{waiver: Waiver{TestID: testID, Namespace: "tnf", Name: "cnf"}, object: serviceAccount, expected: true},
+		{waiver: Waiver{TestID: testID, Name: "test"}, object: pod, expected: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.waiver.Covers(testID, tc.object), "%+v %s", tc.waiver, tc.object)
+	}
+}
+
+func TestWaiverFunc(t *testing.T) {
+	waivers := []Waiver{
+		{TestID: "lifecycle-pod-owner-type", Pod: "old", Justification: "expired", Expiry: "2022-01-31"},
+		{TestID: "lifecycle-pod-owner-type", Pod: "standalone", Justification: "singleton", Expiry: "2022-12-31"},
+	}
+	waived := WaiverFunc(waivers, time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	justification, ok := waived("lifecycle-pod-owner-type", &tnf.CheckedObject{Type: tnf.PodObject, Namespace: "tnf", Name: "standalone"})
+	assert.True(t, ok)
+	assert.Equal(t, "singleton", justification)
+	_, ok = waived("lifecycle-pod-owner-type", &tnf.CheckedObject{Type: tnf.PodObject, Namespace: "tnf", Name: "old"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package containerruntime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// CRIO is the name of the CRI-O runtime.
+	CRIO = "cri-o"
+	// Containerd is the name of the containerd runtime.
+	Containerd = "containerd"
+	// Docker is the name of the docker runtime.
+	Docker = "docker"
+
+	// NoChanges is printed by the root filesystem diff commands after the changes, if any, so that their output is
+	// never empty.
+	NoChanges = "{}"
+
+	// containerdTaskRoot is where containerd mounts the root filesystem of the Kubernetes containers.
+	containerdTaskRoot = "/run/containerd/io.containerd.runtime.v2.task/k8s.io"
+)
+
+// Runtime builds the commands specific to a container runtime.
+type Runtime interface {
+	// Name returns the name of the runtime, as found in the container IDs.
+	Name() string
+	// PIDCommand returns the command printing the host PID of a container.
+	PIDCommand(containerID string) string
+	// FsDiffCommand returns the command listing the paths changed in the root filesystem of a container since it
+	// started, one per line and preceded by white space, followed by NoChanges.
+	FsDiffCommand(containerID string) []string
+	// NamespaceCommand returns command run in the network namespace of the container with PID pid.
+	NamespaceCommand(pid, command string) string
+}
+
+// runtimes are the supported runtimes by name, a package variable so that tests can add a Mock.
+var runtimes = map[string]Runtime{
+	CRIO:       crio{},
+	Containerd: containerd{},
+	Docker:     docker{},
+}
+
+// Get returns the runtime called name.
+func Get(name string) (Runtime, error) {
+	if r, ok := runtimes[name]; ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("container runtime %q is not supported, supported runtimes: %s", name, strings.Join(Names(), ", "))
+}
+
+// Register makes r available to Get under its name, replacing the runtime of the same name. It returns a function
+// restoring the previous runtime, for tests.
+func Register(r Runtime) (restore func()) {
+	previous, existed := runtimes[r.Name()]
+	runtimes[r.Name()] = r
+	return func() {
+		if existed {
+			runtimes[r.Name()] = previous
+		} else {
+			delete(runtimes, r.Name())
+		}
+	}
+}
+
+// Names returns the sorted names of the supported runtimes.
+func Names() []string {
+	names := make([]string, 0, len(runtimes))
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nsenter returns command run in the network namespace of the process pid.
+func nsenter(pid, command string) string {
+	return "nsenter -t " + pid + " -n " + command
+}
+
+// crio is the CRI-O runtime. Its containers are kept in containers/storage, which podman shares.
+type crio struct{}
+
+func (crio) Name() string {
+	return CRIO
+}
+
+func (crio) PIDCommand(containerID string) string {
+	return "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' " + containerID + " 2>/dev/null"
+}
+
+func (crio) FsDiffCommand(containerID string) []string {
+	return []string{"chroot", "/host", "podman", "diff", "--format", "json", containerID}
+}
+
+func (crio) NamespaceCommand(pid, command string) string {
+	return nsenter(pid, command)
+}
+
+// containerd is the containerd runtime. It has no diff command, so the changes are read from the upper directory of the
+// overlay mounted as the root filesystem of the container.
+type containerd struct{}
+
+func (containerd) Name() string {
+	return Containerd
+}
+
+func (containerd) PIDCommand(containerID string) string {
+	return "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' " + containerID + " 2>/dev/null"
+}
+
+func (containerd) FsDiffCommand(containerID string) []string {
+	script := `u=$(findmnt -n -o OPTIONS ` + containerdTaskRoot + `/` + containerID + `/rootfs | tr , "\n" | sed -n "s/^upperdir=//p");` +
+		` [ -n "$u" ] || exit 1; cd "$u" && find . -mindepth 1 | sed "s/^\./ /"; echo "` + NoChanges + `"`
+	return []string{"chroot", "/host", "sh", "-c", "'" + script + "'"}
+}
+
+func (containerd) NamespaceCommand(pid, command string) string {
+	return nsenter(pid, command)
+}
+
+// docker is the docker runtime, through dockershim.
+type docker struct{}
+
+func (docker) Name() string {
+	return Docker
+}
+
+func (docker) PIDCommand(containerID string) string {
+	return "chroot /host docker inspect -f '{{.State.Pid}}' " + containerID + " 2>/dev/null"
+}
+
+func (docker) FsDiffCommand(containerID string) []string {
+	script := `docker diff ` + containerID + ` | sed "s/^[ACD]//"; echo "` + NoChanges + `"`
+	return []string{"chroot", "/host", "sh", "-c", "'" + script + "'"}
+}
+
+func (docker) NamespaceCommand(pid, command string) string {
+	return nsenter(pid, command)
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package containerruntime_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/containerruntime"
+)
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedError bool
+	}{
+		{name: containerruntime.CRIO},
+		{name: containerruntime.Containerd},
+		{name: containerruntime.Docker},
+		{name: "rkt", expectedError: true},
+		{name: "", expectedError: true},
+	}
+	for _, tc := range testCases {
+		rt, err := containerruntime.Get(tc.name)
+		if tc.expectedError {
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "containerd, cri-o, docker")
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, tc.name, rt.Name())
+	}
+}
+
+func TestCommands(t *testing.T) {
+	testCases := []struct {
+		name            string
+		expectedPID     string
+		expectedFsDiff  string
+		expectedImage   string
+		expectedNsenter string
+	}{
+		{
+			name:            containerruntime.CRIO,
+			expectedPID:     "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' abc 2>/dev/null",
+			expectedFsDiff:  "chroot /host podman diff --format json abc",
+			expectedNsenter: "nsenter -t 42 -n ss -tulwnH",
+		},
+		{
+			name:        containerruntime.Containerd,
+			expectedPID: "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' abc 2>/dev/null",
+			expectedFsDiff: `chroot /host sh -c 'u=$(findmnt -n -o OPTIONS /run/containerd/io.containerd.runtime.v2.task/k8s.io/abc/rootfs` +
+				` | tr , "\n" | sed -n "s/^upperdir=//p"); [ -n "$u" ] || exit 1; cd "$u" && find . -mindepth 1 | sed "s/^\./ /"; echo "{}"'`,
+			expectedNsenter: "nsenter -t 42 -n ss -tulwnH",
+		},
+		{
+			name:            containerruntime.Docker,
+			expectedPID:     "chroot /host docker inspect -f '{{.State.Pid}}' abc 2>/dev/null",
+			expectedFsDiff:  `chroot /host sh -c 'docker diff abc | sed "s/^[ACD]//"; echo "{}"'`,
+			expectedNsenter: "nsenter -t 42 -n ss -tulwnH",
+		},
+	}
+	for _, tc := range testCases {
+		rt, err := containerruntime.Get(tc.name)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expectedPID, rt.PIDCommand("abc"))
+		assert.Equal(t, tc.expectedFsDiff, strings.Join(rt.FsDiffCommand("abc"), " "))
+		assert.Equal(t, tc.expectedNsenter, rt.NamespaceCommand("42", "ss -tulwnH"))
+	}
+}
+
+func TestRegisterMock(t *testing.T) {
+	mock := &containerruntime.Mock{PID: "42", Changes: []string{"/var/lib/rpm"}}
+	restore := containerruntime.Register(mock)
+	rt, err := containerruntime.Get("mock")
+	assert.Nil(t, err)
+	assert.Equal(t, "echo 42", rt.PIDCommand("abc"))
+	assert.Equal(t, `printf ' %s\n' '/var/lib/rpm' ; echo '{}'`, strings.Join(rt.FsDiffCommand("abc"), " "))
+	assert.Equal(t, "ss -tulwnH", rt.NamespaceCommand("42", "ss -tulwnH"))
+	restore()
+	_, err = containerruntime.Get("mock")
+	assert.NotNil(t, err)
+
+	// Replacing a runtime restores it.
+	restore = containerruntime.Register(&containerruntime.Mock{RuntimeName: containerruntime.CRIO, PID: "1"})
+	rt, _ = containerruntime.Get(containerruntime.CRIO)
+	assert.Equal(t, "echo 1", rt.PIDCommand("abc"))
+	restore()
+	rt, _ = containerruntime.Get(containerruntime.CRIO)
+	assert.NotEqual(t, "echo 1", rt.PIDCommand("abc"))
+}
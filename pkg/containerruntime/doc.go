@@ -0,0 +1,22 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package containerruntime builds the node commands that depend on the container runtime of a container: finding the PID
+of a container, listing the changes made to its root filesystem and entering the namespaces of a container. The runtime is the scheme of the container ID reported in the pod status, e.g. "cri-o" or "containerd". The
+commands are run from the node debug pods, with the node root filesystem mounted on /host.
+*/
+package containerruntime
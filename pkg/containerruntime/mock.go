@@ -0,0 +1,58 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package containerruntime
+
+// Mock is a runtime whose commands only print canned outputs, to test the code running runtime commands without a
+// node.
+type Mock struct {
+	// RuntimeName is the name of the runtime, "mock" when empty.
+	RuntimeName string
+	// PID is printed by the PID command.
+	PID string
+	// Changes are printed, preceded by a space, by the root filesystem diff command.
+	Changes []string
+}
+
+// Name returns the name of the mock runtime.
+func (m *Mock) Name() string {
+	if m.RuntimeName == "" {
+		return "mock"
+	}
+	return m.RuntimeName
+}
+
+// PIDCommand returns a command printing m.PID.
+func (m *Mock) PIDCommand(containerID string) string {
+	return "echo " + m.PID
+}
+
+// FsDiffCommand returns a command printing m.Changes.
+func (m *Mock) FsDiffCommand(containerID string) []string {
+	command := []string{"printf", `' %s\n'`}
+	for _, change := range m.Changes {
+		command = append(command, "'"+change+"'")
+	}
+	if len(m.Changes) == 0 {
+		command = []string{"true"}
+	}
+	return append(command, ";", "echo", "'"+NoChanges+"'")
+}
+
+// NamespaceCommand returns command unchanged.
+func (m *Mock) NamespaceCommand(pid, command string) string {
+	return command
+}
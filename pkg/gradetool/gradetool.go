@@ -22,6 +22,7 @@ import (
 	"os"
 	"path"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/jsonschema"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
@@ -30,6 +31,22 @@ import (
 
 const (
 	outputFilePermissions = 420
+
+	defaultWeight = 1.0
+	maxScore      = 100.0
+
+	stateField   = "state"
+	statePassed  = "passed"
+	stateSkipped = "skipped"
+)
+
+// testStatus is the outcome of a single test in the claim.
+type testStatus int
+
+const (
+	testFailed testStatus = iota
+	testPassed
+	testSkipped
 )
 
 var (
@@ -39,8 +56,61 @@ var (
 // Grade is a single grade object from policy file
 type Grade struct {
 	GradeName            string
-	RequiredPassingTests []identifier.Identifier
-	NextGrade            *Grade
+	RequiredPassingTests []RequiredTest
+	// AtLeast lists sets of tests of which a minimum number must pass.
+	AtLeast []TestSet
+	// MinScore is the minimum score, from 0 to 100, of the weighted tests of the grade.
+	MinScore *float64
+	// When restricts the grade to the claims whose versions match the conditions.
+	When      *Conditions
+	NextGrade *Grade
+}
+
+// RequiredTest is a test referenced by a grade.
+type RequiredTest struct {
+	identifier.Identifier
+	// Weight is the weight of the test in the grade score, 1 when not set.
+	Weight *float64
+	// AllowSkip lets the test be skipped, or missing from the claim, without failing the grade.
+	AllowSkip bool
+}
+
+// UnmarshalJSON decodes the identifier with its validation, then the test options.
+func (t *RequiredTest) UnmarshalJSON(b []byte) error {
+	if err := t.Identifier.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	var options struct {
+		Weight    *float64
+		AllowSkip bool
+	}
+	if err := json.Unmarshal(b, &options); err != nil {
+		return err
+	}
+	t.Weight = options.Weight
+	t.AllowSkip = options.AllowSkip
+	return nil
+}
+
+func (t *RequiredTest) weight() float64 {
+	if t.Weight == nil {
+		return defaultWeight
+	}
+	return *t.Weight
+}
+
+// TestSet is a set of tests of which at least MinPassing must pass.
+type TestSet struct {
+	MinPassing int
+	Tests      []RequiredTest
+}
+
+// Conditions are semantic version constraints, e.g. ">= 4.9", on the versions recorded in the claim.
+type Conditions struct {
+	OcpVersion      string
+	K8sVersion      string
+	OcClientVersion string
+	TnfVersion      string
 }
 
 // Policy is the object in the policy file
@@ -54,6 +124,12 @@ type GradeResult struct {
 	Propose bool
 	Pass    []identifier.Identifier
 	Fail    []identifier.Identifier
+	// Skipped lists the tests allowed to be skipped that did not run.
+	Skipped []identifier.Identifier `json:",omitempty"`
+	// Score is only reported for the grades with weighted tests or a minimum score.
+	Score *float64 `json:",omitempty"`
+	// Reasons explains why a grade is not proposed, except for the failed tests listed in Fail.
+	Reasons []string `json:",omitempty"`
 }
 
 // GenerateGrade outputs a grade file based on input test results and input grading policy
@@ -81,7 +157,7 @@ func GenerateGrade(resultsPath, policyPath, outputPath string) error {
 	}
 
 	// start grading process
-	gradingOutput, err := doGrading(policyObj, claimObj.Claim.Results)
+	gradingOutput, err := doGrading(policyObj, claimObj.Claim.Results, claimObj.Claim.Versions)
 	if err != nil {
 		return err
 	}
@@ -98,14 +174,14 @@ func GenerateGrade(resultsPath, policyPath, outputPath string) error {
 // NewGradeResult creates a new object without nil properties
 func NewGradeResult(gradeName string) GradeResult {
 	emptySlice := []identifier.Identifier{}
-	return GradeResult{gradeName, false, emptySlice, emptySlice}
+	return GradeResult{Name: gradeName, Pass: emptySlice, Fail: emptySlice}
 }
 
 func generateTestResultsKey(id identifier.Identifier) string {
 	return fmt.Sprintf("{\"url\":%q,\"version\":%q}", id.URL, id.SemanticVersion)
 }
 
-func doGrading(policy Policy, results map[string]interface{}) (interface{}, error) {
+func doGrading(policy Policy, results map[string]interface{}, versions *claim.Versions) (interface{}, error) {
 	gradingOutput := []GradeResult{}
 
 	grade := &policy.Grades
@@ -113,24 +189,45 @@ func doGrading(policy Policy, results map[string]interface{}) (interface{}, erro
 
 	for grade != nil {
 		gradeResult := NewGradeResult(grade.GradeName)
-		for _, id := range grade.RequiredPassingTests {
-			resultsKey := generateTestResultsKey(id)
-			results, ok := results[resultsKey]
-			if !ok {
-				gradeResult.Fail = append(gradeResult.Fail, id)
-				continue
-			}
-			testPass, err := processTestResults(results)
+		grader := testGrader{result: &gradeResult, results: results}
+		requiredPassed := true
+		for i := range grade.RequiredPassingTests {
+			status, err := grader.grade(&grade.RequiredPassingTests[i])
 			if err != nil {
 				return nil, err
 			}
-			if testPass {
-				gradeResult.Pass = append(gradeResult.Pass, id)
-			} else {
-				gradeResult.Fail = append(gradeResult.Fail, id)
+			if status == testFailed {
+				requiredPassed = false
+			}
+		}
+		for i := range grade.AtLeast {
+			set := &grade.AtLeast[i]
+			passed := 0
+			for j := range set.Tests {
+				status, err := grader.grade(&set.Tests[j])
+				if err != nil {
+					return nil, err
+				}
+				if status == testPassed {
+					passed++
+				}
+			}
+			if passed < set.MinPassing {
+				gradeResult.Reasons = append(gradeResult.Reasons,
+					fmt.Sprintf("%d tests passed in set %d, at least %d required", passed, i+1, set.MinPassing))
+			}
+		}
+		if grader.weighted || grade.MinScore != nil {
+			score := grader.score()
+			gradeResult.Score = &score
+			if grade.MinScore != nil && score < *grade.MinScore {
+				gradeResult.Reasons = append(gradeResult.Reasons, fmt.Sprintf("score %.2f is lower than %.2f", score, *grade.MinScore))
 			}
 		}
-		if previousGradePassed && len(gradeResult.Fail) == 0 {
+		if grade.When != nil {
+			gradeResult.Reasons = append(gradeResult.Reasons, checkConditions(grade.When, versions)...)
+		}
+		if previousGradePassed && requiredPassed && len(gradeResult.Reasons) == 0 {
 			gradeResult.Propose = true
 		}
 		gradingOutput = append(gradingOutput, gradeResult)
@@ -140,30 +237,127 @@ func doGrading(policy Policy, results map[string]interface{}) (interface{}, erro
 	return gradingOutput, nil
 }
 
-func processTestResults(results interface{}) (bool, error) {
-	pass := false
+// testGrader records the outcome of the tests of a grade and computes its score.
+type testGrader struct {
+	result        *GradeResult
+	results       map[string]interface{}
+	passedWeight  float64
+	countedWeight float64
+	weighted      bool
+}
+
+// grade records the outcome of a test in the grade result. A test allowed to be skipped that did not run is
+// reported as skipped and left out of the score, otherwise it fails.
+func (g *testGrader) grade(test *RequiredTest) (testStatus, error) {
+	status := testFailed
+	if results, ok := g.results[generateTestResultsKey(test.Identifier)]; ok {
+		var err error
+		status, err = processTestResults(results)
+		if err != nil {
+			return status, err
+		}
+	} else if test.AllowSkip {
+		status = testSkipped
+	}
+	if status == testSkipped && !test.AllowSkip {
+		status = testFailed
+	}
+
+	if test.Weight != nil {
+		g.weighted = true
+	}
+	switch status {
+	case testPassed:
+		g.result.Pass = append(g.result.Pass, test.Identifier)
+		g.passedWeight += test.weight()
+		g.countedWeight += test.weight()
+	case testFailed:
+		g.result.Fail = append(g.result.Fail, test.Identifier)
+		g.countedWeight += test.weight()
+	case testSkipped:
+		g.result.Skipped = append(g.result.Skipped, test.Identifier)
+	}
+	return status, nil
+}
+
+// score returns the weighted percentage of passed tests. A grade without any counted test scores 100.
+func (g *testGrader) score() float64 {
+	if g.countedWeight == 0 {
+		return maxScore
+	}
+	return maxScore * g.passedWeight / g.countedWeight
+}
+
+// checkConditions returns a reason for each version of the claim that does not match its constraint.
+func checkConditions(conditions *Conditions, versions *claim.Versions) []string {
+	if versions == nil {
+		versions = &claim.Versions{}
+	}
+	var reasons []string
+	for _, c := range []struct{ name, constraint, version string }{
+		{"ocp", conditions.OcpVersion, versions.Ocp},
+		{"k8s", conditions.K8sVersion, versions.K8s},
+		{"ocClient", conditions.OcClientVersion, versions.OcClient},
+		{"tnf", conditions.TnfVersion, versions.Tnf},
+	} {
+		if c.constraint == "" {
+			continue
+		}
+		// The constraints were validated with the policy.
+		constraint, _ := semver.NewConstraint(c.constraint)
+		version, err := semver.NewVersion(c.version)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s version %q is not a semantic version", c.name, c.version))
+			continue
+		}
+		if !constraint.Check(version) {
+			reasons = append(reasons, fmt.Sprintf("%s version %s does not match %s", c.name, c.version, c.constraint))
+		}
+	}
+	return reasons
+}
+
+// processTestResults returns the status of a test from its results. The "state" field written by the current
+// claims takes precedence over the "passed" field of the older ones.
+func processTestResults(results interface{}) (testStatus, error) {
+	status := testFailed
 	var resultsTyped []interface{}
 	resultsTyped, ok := results.([]interface{})
 	if !ok {
-		return pass, fmt.Errorf("the test results object is not of expected type. "+
+		return status, fmt.Errorf("the test results object is not of expected type. "+
 			"found: %T. expected: %T", results, resultsTyped)
 	}
 	for _, result := range resultsTyped {
 		resultTyped, ok := result.(map[string]interface{})
 		if !ok {
-			return pass, fmt.Errorf("the test result object is not of expected type. "+
+			return status, fmt.Errorf("the test result object is not of expected type. "+
 				"found: %T. expected: %T", result, resultTyped)
 		}
+		if state, ok := resultTyped[stateField]; ok {
+			switch state {
+			case statePassed:
+				status = testPassed
+			case stateSkipped:
+				status = testSkipped
+			default:
+				status = testFailed
+			}
+			continue
+		}
 		val, ok := resultTyped["passed"]
 		if !ok {
-			return pass, fmt.Errorf("the field 'passed' is missing in test result")
+			return status, fmt.Errorf("the field 'passed' is missing in test result")
 		}
-		pass, ok = val.(bool)
+		pass, ok := val.(bool)
 		if !ok {
-			return pass, fmt.Errorf("field 'passed' is not of type bool")
+			return status, fmt.Errorf("field 'passed' is not of type bool")
+		}
+		status = testFailed
+		if pass {
+			status = testPassed
 		}
 	}
-	return pass, nil
+	return status, nil
 }
 
 func generateOutput(outputObj interface{}, outputPath string) error {
@@ -188,6 +382,23 @@ func validatePolicy(policyObj *Policy) error {
 		}
 		gradeNames[grade.GradeName] = true
 
+		for i := range grade.AtLeast {
+			if n := len(grade.AtLeast[i].Tests); grade.AtLeast[i].MinPassing > n {
+				return fmt.Errorf("grade %s requires %d passing tests in set %d, which only has %d tests",
+					grade.GradeName, grade.AtLeast[i].MinPassing, i+1, n)
+			}
+		}
+		if grade.When != nil {
+			for _, constraint := range []string{grade.When.OcpVersion, grade.When.K8sVersion, grade.When.OcClientVersion, grade.When.TnfVersion} {
+				if constraint == "" {
+					continue
+				}
+				if _, err := semver.NewConstraint(constraint); err != nil {
+					return fmt.Errorf("invalid version constraint %q in grade %s: %v", constraint, grade.GradeName, err)
+				}
+			}
+		}
+
 		grade = grade.NextGrade
 	}
 	return nil
@@ -32,6 +32,12 @@ const (
 	goodPolicy   = testDataPath + "policy-good.json"
 	badPolicy    = testDataPath + "policy-duplicate-grade.json"
 	outPath      = testDataPath + "out.json"
+
+	statesClaim         = testDataPath + "claim-states.json"
+	extendedPolicy      = testDataPath + "policy-extended.json"
+	badSetPolicy        = testDataPath + "policy-bad-set.json"
+	badConstraintPolicy = testDataPath + "policy-bad-constraint.json"
+	extendedOutPath     = testDataPath + "out-extended.json"
 )
 
 var (
@@ -56,6 +62,19 @@ func TestGenerateGrade_ErrorInput(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestGenerateGrade_ExtendedPolicy(t *testing.T) {
+	err := GenerateGrade(statesClaim, extendedPolicy, testOutPath)
+	assert.Nil(t, err)
+	assertFilesMatch(t, extendedOutPath, testOutPath)
+}
+
+func TestGenerateGrade_InvalidExtendedPolicy(t *testing.T) {
+	err := GenerateGrade(statesClaim, badSetPolicy, testOutPath)
+	assert.NotNil(t, err)
+	err = GenerateGrade(statesClaim, badConstraintPolicy, testOutPath)
+	assert.NotNil(t, err)
+}
+
 func assertFilesMatch(t *testing.T, pathA, pathB string) {
 	command := exec.Command("cmp", "-s", pathA, pathB)
 	err := command.Run()
@@ -0,0 +1,124 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package networkpolicy
+
+import (
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PolicyTypes returns the policy types of p. When they are not set, a policy always applies to ingress, and to egress
+// if it has egress rules, as the API does.
+func PolicyTypes(p *networkingv1.NetworkPolicy) (ingress, egress bool) {
+	if len(p.Spec.PolicyTypes) == 0 {
+		return true, len(p.Spec.Egress) > 0
+	}
+	for _, t := range p.Spec.PolicyTypes {
+		switch t {
+		case networkingv1.PolicyTypeIngress:
+			ingress = true
+		case networkingv1.PolicyTypeEgress:
+			egress = true
+		}
+	}
+	return ingress, egress
+}
+
+// DefaultDeny returns whether policies deny all ingress and all egress traffic by default: a policy selecting all the
+// pods of the namespace applies to a direction without allowing any traffic in it.
+func DefaultDeny(policies []networkingv1.NetworkPolicy) (ingress, egress bool) {
+	for i := range policies {
+		p := &policies[i]
+		if len(p.Spec.PodSelector.MatchLabels) > 0 || len(p.Spec.PodSelector.MatchExpressions) > 0 {
+			continue
+		}
+		appliesToIngress, appliesToEgress := PolicyTypes(p)
+		ingress = ingress || (appliesToIngress && len(p.Spec.Ingress) == 0)
+		egress = egress || (appliesToEgress && len(p.Spec.Egress) == 0)
+	}
+	return ingress, egress
+}
+
+// Selecting returns the policies whose pod selector selects a pod with podLabels.
+func Selecting(policies []networkingv1.NetworkPolicy, podLabels map[string]string) ([]*networkingv1.NetworkPolicy, error) {
+	var selecting []*networkingv1.NetworkPolicy
+	for i := range policies {
+		p := &policies[i]
+		selector, err := metav1.LabelSelectorAsSelector(&p.Spec.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			selecting = append(selecting, p)
+		}
+	}
+	return selecting, nil
+}
+
+// AllowsIngress returns whether the policies selecting a pod allow ingress traffic to its declared port from some
+// peer. The ingress of a pod that no policy applies to is not restricted.
+func AllowsIngress(selecting []*networkingv1.NetworkPolicy, port *configsections.ContainerPort) bool {
+	isolated := false
+	for _, p := range selecting {
+		if ingress, _ := PolicyTypes(p); !ingress {
+			continue
+		}
+		isolated = true
+		for i := range p.Spec.Ingress {
+			if allowsPort(p.Spec.Ingress[i].Ports, port) {
+				return true
+			}
+		}
+	}
+	return !isolated
+}
+
+// allowsPort returns whether the ports of a rule match port, a rule without ports matching all of them.
+func allowsPort(ports []networkingv1.NetworkPolicyPort, port *configsections.ContainerPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for i := range ports {
+		p := &ports[i]
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		if string(protocol) != port.Protocol {
+			continue
+		}
+		switch {
+		case p.Port == nil:
+			return true
+		case p.Port.Type == intstr.String:
+			if port.Name != "" && p.Port.StrVal == port.Name {
+				return true
+			}
+		case p.EndPort != nil:
+			if port.Port >= p.Port.IntValue() && port.Port <= int(*p.EndPort) {
+				return true
+			}
+		case port.Port == p.Port.IntValue():
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func policy(selector map[string]string, types []networkingv1.PolicyType, ingress []networkingv1.NetworkPolicyIngressRule,
+	egress []networkingv1.NetworkPolicyEgressRule) networkingv1.NetworkPolicy {
+	return networkingv1.NetworkPolicy{
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: selector},
+			PolicyTypes: types,
+			Ingress:     ingress,
+			Egress:      egress,
+		},
+	}
+}
+
+func TestDefaultDeny(t *testing.T) {
+	both := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}
+	allowAll := []networkingv1.NetworkPolicyIngressRule{{}}
+	testCases := []struct {
+		policies        []networkingv1.NetworkPolicy
+		expectedIngress bool
+		expectedEgress  bool
+	}{
+		{policies: nil},
+		{
+			policies:        []networkingv1.NetworkPolicy{policy(nil, both, nil, nil)},
+			expectedIngress: true,
+			expectedEgress:  true,
+		},
+		{
+			// Without policy types only ingress is implied.
+			policies:        []networkingv1.NetworkPolicy{policy(nil, nil, nil, nil)},
+			expectedIngress: true,
+		},
+		{
+			// A policy selecting some pods only is not a default.
+			policies: []networkingv1.NetworkPolicy{policy(map[string]string{"app": "a"}, both, nil, nil)},
+		},
+		{
+			// Ingress is allowed by a rule.
+			policies:       []networkingv1.NetworkPolicy{policy(nil, both, allowAll, nil)},
+			expectedEgress: true,
+		},
+		{
+			policies: []networkingv1.NetworkPolicy{
+				policy(nil, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, nil, nil),
+				policy(nil, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, nil, nil),
+			},
+			expectedIngress: true,
+			expectedEgress:  true,
+		},
+	}
+	for _, tc := range testCases {
+		ingress, egress := DefaultDeny(tc.policies)
+		assert.Equal(t, tc.expectedIngress, ingress)
+		assert.Equal(t, tc.expectedEgress, egress)
+	}
+}
+
+func TestSelecting(t *testing.T) {
+	policies := []networkingv1.NetworkPolicy{
+		policy(nil, nil, nil, nil),
+		policy(map[string]string{"app": "a"}, nil, nil, nil),
+		policy(map[string]string{"app": "b"}, nil, nil, nil),
+	}
+	selecting, err := Selecting(policies, map[string]string{"app": "a", "tier": "web"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*networkingv1.NetworkPolicy{&policies[0], &policies[1]}, selecting)
+
+	selecting, err = Selecting(policies[1:], nil)
+	assert.Nil(t, err)
+	assert.Empty(t, selecting)
+
+	invalid := networkingv1.NetworkPolicy{}
+	invalid.Spec.PodSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Bogus"}}
+	_, err = Selecting([]networkingv1.NetworkPolicy{invalid}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestAllowsIngress(t *testing.T) {
+	udp := corev1.ProtocolUDP
+	endPort := int32(8090)
+	port8080 := intstr.FromInt(8080)
+	port8000 := intstr.FromInt(8000)
+	namedPort := intstr.FromString("http")
+	rule := func(ports ...networkingv1.NetworkPolicyPort) []networkingv1.NetworkPolicyIngressRule {
+		return []networkingv1.NetworkPolicyIngressRule{{Ports: ports}}
+	}
+	ingressOnly := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	egressOnly := []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+	httpPort := &configsections.ContainerPort{Name: "http", Port: 8080, Protocol: "TCP"}
+
+	testCases := []struct {
+		policies []networkingv1.NetworkPolicy
+		port     *configsections.ContainerPort
+		expected bool
+	}{
+		// No policy applies to ingress.
+		{policies: nil, port: httpPort, expected: true},
+		{policies: []networkingv1.NetworkPolicy{policy(nil, egressOnly, nil, nil)}, port: httpPort, expected: true},
+		// Deny all.
+		{policies: []networkingv1.NetworkPolicy{policy(nil, ingressOnly, nil, nil)}, port: httpPort, expected: false},
+		// A rule without ports.
+		{policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(), nil)}, port: httpPort, expected: true},
+		{
+			policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &port8080}), nil)},
+			port:     httpPort,
+			expected: true,
+		},
+		{
+			policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &port8000}), nil)},
+			port:     httpPort,
+			expected: false,
+		},
+		{
+			// The protocol defaults to TCP.
+			policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &port8080}), nil)},
+			port:     &configsections.ContainerPort{Port: 8080, Protocol: "UDP"},
+			expected: false,
+		},
+		{
+			policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Protocol: &udp}), nil)},
+			port:     &configsections.ContainerPort{Port: 53, Protocol: "UDP"},
+			expected: true,
+		},
+		{
+			policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &namedPort}), nil)},
+			port:     httpPort,
+			expected: true,
+		},
+		{
+			policies: []networkingv1.NetworkPolicy{policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &namedPort}), nil)},
+			port:     &configsections.ContainerPort{Port: 8080, Protocol: "TCP"},
+			expected: false,
+		},
+		{
+			policies: []networkingv1.NetworkPolicy{
+				policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &port8000, EndPort: &endPort}), nil),
+			},
+			port:     httpPort,
+			expected: true,
+		},
+		{
+			// Allowed by another policy selecting the pod.
+			policies: []networkingv1.NetworkPolicy{
+				policy(nil, ingressOnly, nil, nil),
+				policy(nil, nil, rule(networkingv1.NetworkPolicyPort{Port: &port8080}), nil),
+			},
+			port:     httpPort,
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		selecting, err := Selecting(tc.policies, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, AllowsIngress(selecting, tc.port))
+	}
+}
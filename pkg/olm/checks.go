@@ -0,0 +1,310 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package olm
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Check names, as listed in the operatortest section of testconfigure.yml.
+const (
+	CSVInstalled        = "CSV_INSTALLED"
+	CSVSCC              = "CSV_SCC"
+	InstallModeCheck    = "INSTALL_MODE"
+	SubscriptionChannel = "SUBSCRIPTION_CHANNEL"
+	InstallPlanApproval = "INSTALL_PLAN_APPROVAL"
+	RelatedImages       = "RELATED_IMAGES"
+	OwnedCRDs           = "OWNED_CRDS"
+)
+
+// InstalledViaOLM is the name of the check of the operator-install-source test, which is not configurable.
+const InstalledViaOLM = "INSTALLED_VIA_OLM"
+
+// CSV phases and install plan phases and approvals checked.
+const (
+	CSVPhaseSucceeded        = "Succeeded"
+	InstallPlanPhaseComplete = "Complete"
+	ApprovalAutomatic        = "Automatic"
+	ApprovalManual           = "Manual"
+)
+
+// Install modes of a CSV.
+const (
+	OwnNamespaceInstallMode    = "OwnNamespace"
+	SingleNamespaceInstallMode = "SingleNamespace"
+	MultiNamespaceInstallMode  = "MultiNamespace"
+	AllNamespacesInstallMode   = "AllNamespaces"
+)
+
+var digestPinned = regexp.MustCompile(`@sha256:[0-9a-f]{64}$`)
+
+// Failure is why an operator failed a check.
+type Failure struct {
+	// Check is the name of the failed check.
+	Check string `json:"check"`
+	// Resource is the failing resource, e.g. "subscription/my-operator".
+	Resource string `json:"resource"`
+	// Field is the failing field of the resource, e.g. "spec.channel".
+	Field string `json:"field,omitempty"`
+	// Expected and Actual are the expected and actual values of the field, when they apply.
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	// Reason explains the failure.
+	Reason string `json:"reason"`
+}
+
+func (f *Failure) String() string {
+	s := f.Resource
+	if f.Field != "" {
+		s += " " + f.Field
+	}
+	s += ": " + f.Reason
+	switch {
+	case f.Expected != "":
+		s += fmt.Sprintf(" (expected %q, got %q)", f.Expected, f.Actual)
+	case f.Actual != "":
+		s += fmt.Sprintf(" (got %q)", f.Actual)
+	}
+	return s
+}
+
+// Failures is the result of a check, no failures meaning the operator passed it.
+type Failures []Failure
+
+// String joins the failures, it is the reason recorded for the operator.
+func (f Failures) String() string {
+	s := make([]string, len(f))
+	for i := range f {
+		s[i] = f[i].String()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Check checks one aspect of how an operator was installed.
+type Check func(o *Operator) Failures
+
+// Checks are the built-in checks, by name.
+var Checks = map[string]Check{
+	CSVInstalled:        CheckCSVInstalled,
+	CSVSCC:              CheckCSVSCC,
+	InstallModeCheck:    CheckInstallMode,
+	SubscriptionChannel: CheckSubscriptionChannel,
+	InstallPlanApproval: CheckInstallPlanApproval,
+	RelatedImages:       CheckRelatedImages,
+	OwnedCRDs:           CheckOwnedCRDs,
+}
+
+// checkResources lists the resources each built-in check reads.
+var checkResources = map[string][]string{
+	CSVInstalled:        {CSVResource},
+	CSVSCC:              {CSVResource},
+	InstallModeCheck:    {CSVResource, OperatorGroupResource},
+	SubscriptionChannel: {CSVResource, SubscriptionResource},
+	InstallPlanApproval: {SubscriptionResource, InstallPlanResource},
+	RelatedImages:       {CSVResource},
+	OwnedCRDs:           {CSVResource, CRDResource},
+	InstalledViaOLM:     {SubscriptionResource},
+}
+
+// CheckNames returns the sorted names of the built-in checks.
+func CheckNames() []string {
+	names := make([]string, 0, len(Checks))
+	for name := range Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func resource(kind, name string) string {
+	return kind + "/" + name
+}
+
+// CheckCSVInstalled checks the CSV reached the Succeeded phase.
+func CheckCSVInstalled(o *Operator) Failures {
+	status := o.CSV.Status
+	if status.Phase == CSVPhaseSucceeded {
+		return nil
+	}
+	reason := "the CSV is not installed"
+	if status.Reason != "" {
+		reason += ", " + status.Reason
+	}
+	if status.Message != "" {
+		reason += ": " + status.Message
+	}
+	return Failures{{Check: CSVInstalled, Resource: resource(CSVResource, o.CSV.Metadata.Name), Field: "status.phase",
+		Expected: CSVPhaseSucceeded, Actual: status.Phase, Reason: reason}}
+}
+
+// CheckCSVSCC checks no cluster permission of the CSV is restricted to named resources, which is how operators
+// request the use of a specific SCC.
+func CheckCSVSCC(o *Operator) Failures {
+	var failures Failures
+	for i, permission := range o.CSV.Spec.Install.Spec.ClusterPermissions {
+		for j, rule := range permission.Rules {
+			if len(rule.ResourceNames) == 0 {
+				continue
+			}
+			failures = append(failures, Failure{Check: CSVSCC, Resource: resource(CSVResource, o.CSV.Metadata.Name),
+				Field:  fmt.Sprintf("spec.install.spec.clusterPermissions[%d].rules[%d].resourceNames", i, j),
+				Reason: fmt.Sprintf("service account %s is granted access to named resources %s", permission.ServiceAccountName, strings.Join(rule.ResourceNames, ","))})
+		}
+	}
+	return failures
+}
+
+// InstallModeOf returns the install mode the operator group gives the operators of its namespace.
+func InstallModeOf(group *OperatorGroup) string {
+	targets := group.Spec.TargetNamespaces
+	switch {
+	case len(targets) == 0:
+		return AllNamespacesInstallMode
+	case len(targets) > 1:
+		return MultiNamespaceInstallMode
+	case targets[0] == group.Metadata.Namespace:
+		return OwnNamespaceInstallMode
+	default:
+		return SingleNamespaceInstallMode
+	}
+}
+
+// CheckInstallMode checks the namespace of the operator has exactly one operator group, and that the CSV supports
+// the install mode of that group.
+func CheckInstallMode(o *Operator) Failures {
+	if n := len(o.OperatorGroups); n != 1 {
+		return Failures{{Check: InstallModeCheck, Resource: resource(OperatorGroupResource, "*"), Expected: "1", Actual: fmt.Sprint(n),
+			Reason: fmt.Sprintf("namespace %s must have exactly one operator group", o.CSV.Metadata.Namespace)}}
+	}
+	group := &o.OperatorGroups[0]
+	mode := InstallModeOf(group)
+	for _, m := range o.CSV.Spec.InstallModes {
+		if m.Type == mode && m.Supported {
+			return nil
+		}
+	}
+	return Failures{{Check: InstallModeCheck, Resource: resource(CSVResource, o.CSV.Metadata.Name), Field: "spec.installModes",
+		Reason: fmt.Sprintf("install mode %s of operator group %s is not supported", mode, group.Metadata.Name)}}
+}
+
+// CheckSubscriptionChannel checks the subscription follows a channel, the configured one if any, and installed the
+// CSV under test.
+func CheckSubscriptionChannel(o *Operator) Failures {
+	var failures Failures
+	s := o.Subscription
+	name := resource(SubscriptionResource, s.Metadata.Name)
+	switch {
+	case s.Spec.Channel == "":
+		failures = append(failures, Failure{Check: SubscriptionChannel, Resource: name, Field: "spec.channel", Reason: "no channel set"})
+	case o.ExpectedChannel != "" && s.Spec.Channel != o.ExpectedChannel:
+		failures = append(failures, Failure{Check: SubscriptionChannel, Resource: name, Field: "spec.channel",
+			Expected: o.ExpectedChannel, Actual: s.Spec.Channel, Reason: "unexpected channel"})
+	}
+	if s.Status.InstalledCSV != o.CSV.Metadata.Name {
+		failures = append(failures, Failure{Check: SubscriptionChannel, Resource: name, Field: "status.installedCSV",
+			Expected: o.CSV.Metadata.Name, Actual: s.Status.InstalledCSV, Reason: "the subscription did not install the CSV under test"})
+	}
+	return failures
+}
+
+// CheckInstalledViaOLM checks the CSV under test was installed by its subscription.  A subscription that does not
+// exist fails the check: only the other errors reading it are check errors.
+func CheckInstalledViaOLM(o *Operator) Failures {
+	s := o.Subscription
+	if errors.Is(o.Errors[SubscriptionResource], ErrNotFound) {
+		return Failures{{Check: InstalledViaOLM, Resource: resource(SubscriptionResource, s.Metadata.Name),
+			Reason: "the operator has no OLM subscription"}}
+	}
+	if s.Status.InstalledCSV != o.CSV.Metadata.Name {
+		return Failures{{Check: InstalledViaOLM, Resource: resource(SubscriptionResource, s.Metadata.Name), Field: "status.installedCSV",
+			Expected: o.CSV.Metadata.Name, Actual: s.Status.InstalledCSV, Reason: "the subscription did not install the CSV under test"}}
+	}
+	return nil
+}
+
+// CheckInstallPlanApproval checks the approval strategy of the subscription, the configured one if any, and that its
+// install plan was approved and completed.
+func CheckInstallPlanApproval(o *Operator) Failures {
+	var failures Failures
+	s := o.Subscription
+	approval := s.Spec.InstallPlanApproval
+	if approval == "" {
+		approval = ApprovalAutomatic
+	}
+	if o.ExpectedInstallPlanApproval != "" && approval != o.ExpectedInstallPlanApproval {
+		failures = append(failures, Failure{Check: InstallPlanApproval, Resource: resource(SubscriptionResource, s.Metadata.Name),
+			Field: "spec.installPlanApproval", Expected: o.ExpectedInstallPlanApproval, Actual: approval, Reason: "unexpected approval strategy"})
+	}
+	p := o.InstallPlan
+	if p == nil {
+		return append(failures, Failure{Check: InstallPlanApproval, Resource: resource(SubscriptionResource, s.Metadata.Name),
+			Field: "status.installPlanRef", Reason: "the subscription has no install plan"})
+	}
+	name := resource(InstallPlanResource, p.Metadata.Name)
+	if !p.Spec.Approved {
+		failures = append(failures, Failure{Check: InstallPlanApproval, Resource: name, Field: "spec.approved",
+			Reason: fmt.Sprintf("the install plan is waiting for a %s approval", p.Spec.Approval)})
+	}
+	if p.Status.Phase != InstallPlanPhaseComplete {
+		failures = append(failures, Failure{Check: InstallPlanApproval, Resource: name, Field: "status.phase",
+			Expected: InstallPlanPhaseComplete, Actual: p.Status.Phase, Reason: "the install plan is not complete"})
+	}
+	return failures
+}
+
+// CheckRelatedImages checks the related images of the CSV and the images of the deployments it installs are pinned
+// by digest, so the operator runs the images it was certified with.
+func CheckRelatedImages(o *Operator) Failures {
+	var failures Failures
+	name := resource(CSVResource, o.CSV.Metadata.Name)
+	for i, image := range o.CSV.Spec.RelatedImages {
+		if !digestPinned.MatchString(image.Image) {
+			failures = append(failures, Failure{Check: RelatedImages, Resource: name, Field: fmt.Sprintf("spec.relatedImages[%d]", i),
+				Actual: image.Image, Reason: fmt.Sprintf("related image %s is not pinned by digest", image.Name)})
+		}
+	}
+	for _, deployment := range o.CSV.Spec.Install.Spec.Deployments {
+		podSpec := deployment.Spec.Template.Spec
+		for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+			if !digestPinned.MatchString(c.Image) {
+				failures = append(failures, Failure{Check: RelatedImages, Resource: name, Field: "spec.install.spec.deployments",
+					Actual: c.Image, Reason: fmt.Sprintf("image of container %s of deployment %s is not pinned by digest", c.Name, deployment.Name)})
+			}
+		}
+	}
+	return failures
+}
+
+// CheckOwnedCRDs checks the CRDs owned by the CSV exist in the cluster.
+func CheckOwnedCRDs(o *Operator) Failures {
+	crds := make(map[string]bool, len(o.CRDNames))
+	for _, name := range o.CRDNames {
+		crds[name] = true
+	}
+	var failures Failures
+	for _, crd := range o.CSV.Spec.CustomResourceDefinitions.Owned {
+		if !crds[crd.Name] {
+			failures = append(failures, Failure{Check: OwnedCRDs, Resource: resource(CRDResource, crd.Name),
+				Reason: fmt.Sprintf("CRD of owned kind %s does not exist", crd.Kind)})
+		}
+	}
+	return failures
+}
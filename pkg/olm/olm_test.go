@@ -0,0 +1,235 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package olm_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/olm"
+)
+
+type fakeGetter struct {
+	files map[string]string
+	crds  []string
+}
+
+func (g *fakeGetter) GetJSON(resource, namespace, name string) ([]byte, error) {
+	file, ok := g.files[resource]
+	if !ok {
+		return nil, errors.New("oc get " + resource + " failed")
+	}
+	return os.ReadFile(path.Join("testdata", file))
+}
+
+func (g *fakeGetter) Names(resource string) ([]string, error) {
+	return g.crds, nil
+}
+
+func newFakeGetter() *fakeGetter {
+	return &fakeGetter{
+		files: map[string]string{
+			olm.CSVResource:           "csv.json",
+			olm.SubscriptionResource:  "subscription.json",
+			olm.InstallPlanResource:   "installplan.json",
+			olm.OperatorGroupResource: "operatorgroups.json",
+		},
+		crds: []string{"etcdclusters.etcd.database.coreos.com", "machines.machine.openshift.io"},
+	}
+}
+
+func fetch(t *testing.T) *olm.Operator {
+	o := olm.Fetch(newFakeGetter(), "tnf", "etcdoperator.v0.9.4", "etcd")
+	assert.Empty(t, o.Errors)
+	return o
+}
+
+func TestFetch(t *testing.T) {
+	o := fetch(t)
+	assert.Equal(t, "Succeeded", o.CSV.Status.Phase)
+	assert.Equal(t, "singlenamespace-alpha", o.Subscription.Spec.Channel)
+	assert.Equal(t, "install-x7k2p", o.InstallPlan.Metadata.Name)
+	assert.Len(t, o.OperatorGroups, 1)
+	assert.Len(t, o.CRDNames, 2)
+
+	g := newFakeGetter()
+	delete(g.files, olm.InstallPlanResource)
+	o = olm.Fetch(g, "tnf", "etcdoperator.v0.9.4", "etcd")
+	assert.NotNil(t, o.Err(olm.InstallPlanApproval))
+	assert.Nil(t, o.Err(olm.SubscriptionChannel))
+
+	// A missing subscription only fails the checks reading it.
+	g = newFakeGetter()
+	delete(g.files, olm.SubscriptionResource)
+	o = olm.Fetch(g, "tnf", "etcdoperator.v0.9.4", "etcd")
+	assert.Nil(t, o.InstallPlan)
+	for _, name := range olm.CheckNames() {
+		failed := name == olm.SubscriptionChannel || name == olm.InstallPlanApproval
+		assert.Equal(t, failed, o.Err(name) != nil, name)
+	}
+}
+
+func TestChecks(t *testing.T) {
+	testCases := []struct {
+		check    string
+		modify   func(o *olm.Operator)
+		expected []string
+	}{
+		{check: olm.CSVInstalled},
+		{
+			check: olm.CSVInstalled,
+			modify: func(o *olm.Operator) {
+				o.CSV.Status.Phase = "Failed"
+				o.CSV.Status.Reason = "InstallComponentFailed"
+				o.CSV.Status.Message = "install strategy failed"
+			},
+			expected: []string{`csv/etcdoperator.v0.9.4 status.phase: the CSV is not installed, InstallComponentFailed: install strategy failed (expected "Succeeded", got "Failed")`},
+		},
+		{
+			check:    olm.CSVSCC,
+			expected: []string{"csv/etcdoperator.v0.9.4 spec.install.spec.clusterPermissions[0].rules[1].resourceNames: service account etcd-operator is granted access to named resources privileged"},
+		},
+		{check: olm.InstallModeCheck},
+		{
+			check:    olm.InstallModeCheck,
+			modify:   func(o *olm.Operator) { o.OperatorGroups[0].Spec.TargetNamespaces = nil },
+			expected: []string{"csv/etcdoperator.v0.9.4 spec.installModes: install mode AllNamespaces of operator group tnf-group is not supported"},
+		},
+		{
+			check:    olm.InstallModeCheck,
+			modify:   func(o *olm.Operator) { o.OperatorGroups = append(o.OperatorGroups, o.OperatorGroups[0]) },
+			expected: []string{`operatorgroup/*: namespace tnf must have exactly one operator group (expected "1", got "2")`},
+		},
+		{check: olm.SubscriptionChannel},
+		{
+			check: olm.SubscriptionChannel,
+			modify: func(o *olm.Operator) {
+				o.ExpectedChannel = "stable"
+				o.Subscription.Status.InstalledCSV = "etcdoperator.v0.9.2"
+			},
+			expected: []string{
+				`subscription/etcd spec.channel: unexpected channel (expected "stable", got "singlenamespace-alpha")`,
+				`subscription/etcd status.installedCSV: the subscription did not install the CSV under test (expected "etcdoperator.v0.9.4", got "etcdoperator.v0.9.2")`,
+			},
+		},
+		{check: olm.InstallPlanApproval, modify: func(o *olm.Operator) { o.ExpectedInstallPlanApproval = olm.ApprovalManual }},
+		{
+			check: olm.InstallPlanApproval,
+			modify: func(o *olm.Operator) {
+				o.ExpectedInstallPlanApproval = olm.ApprovalAutomatic
+				o.InstallPlan.Spec.Approved = false
+				o.InstallPlan.Status.Phase = "RequiresApproval"
+			},
+			expected: []string{
+				`subscription/etcd spec.installPlanApproval: unexpected approval strategy (expected "Automatic", got "Manual")`,
+				"installplan/install-x7k2p spec.approved: the install plan is waiting for a Manual approval",
+				`installplan/install-x7k2p status.phase: the install plan is not complete (expected "Complete", got "RequiresApproval")`,
+			},
+		},
+		{
+			check:    olm.InstallPlanApproval,
+			modify:   func(o *olm.Operator) { o.InstallPlan = nil },
+			expected: []string{"subscription/etcd status.installPlanRef: the subscription has no install plan"},
+		},
+		{
+			check: olm.RelatedImages,
+			expected: []string{
+				`csv/etcdoperator.v0.9.4 spec.relatedImages[1]: related image etcd is not pinned by digest (got "quay.io/coreos/etcd:v3.4.0")`,
+				`csv/etcdoperator.v0.9.4 spec.install.spec.deployments: image of container etcd-backup-operator of deployment etcd-operator is not pinned by digest (got "quay.io/coreos/etcd-operator:v0.9.4")`,
+			},
+		},
+		{
+			check:    olm.OwnedCRDs,
+			expected: []string{"crd/etcdbackups.etcd.database.coreos.com: CRD of owned kind EtcdBackup does not exist"},
+		},
+	}
+
+	for _, tc := range testCases {
+		o := fetch(t)
+		if tc.modify != nil {
+			tc.modify(o)
+		}
+		failures := olm.Checks[tc.check](o)
+		actual := make([]string, len(failures))
+		for i := range failures {
+			assert.Equal(t, tc.check, failures[i].Check)
+			actual[i] = failures[i].String()
+		}
+		assert.Equal(t, len(tc.expected), len(actual), tc.check)
+		if len(tc.expected) > 0 {
+			assert.Equal(t, tc.expected, actual)
+		}
+	}
+}
+
+func TestCheckInstalledViaOLM(t *testing.T) {
+	o := fetch(t)
+	assert.Empty(t, olm.CheckInstalledViaOLM(o))
+
+	o.Subscription.Status.InstalledCSV = "etcdoperator.v0.9.2"
+	assert.Equal(t, `subscription/etcd status.installedCSV: the subscription did not install the CSV under test (expected "etcdoperator.v0.9.4", got "etcdoperator.v0.9.2")`,
+		olm.CheckInstalledViaOLM(o).String())
+
+	// A subscription that does not exist fails the check, the other errors reading it are check errors.
+	o = olm.Fetch(&notFoundGetter{newFakeGetter()}, "tnf", "etcdoperator.v0.9.4", "etcd")
+	assert.True(t, errors.Is(o.Err(olm.InstalledViaOLM), olm.ErrNotFound))
+	assert.Equal(t, "subscription/etcd: the operator has no OLM subscription", olm.CheckInstalledViaOLM(o).String())
+	g := newFakeGetter()
+	delete(g.files, olm.SubscriptionResource)
+	o = olm.Fetch(g, "tnf", "etcdoperator.v0.9.4", "etcd")
+	assert.NotNil(t, o.Err(olm.InstalledViaOLM))
+	assert.False(t, errors.Is(o.Err(olm.InstalledViaOLM), olm.ErrNotFound))
+}
+
+// notFoundGetter is a fakeGetter for which the subscription does not exist.
+type notFoundGetter struct {
+	*fakeGetter
+}
+
+func (g *notFoundGetter) GetJSON(resource, namespace, name string) ([]byte, error) {
+	if resource == olm.SubscriptionResource {
+		return nil, fmt.Errorf("oc get subscription %s: %w", name, olm.ErrNotFound)
+	}
+	return g.fakeGetter.GetJSON(resource, namespace, name)
+}
+
+func TestInstallModeOf(t *testing.T) {
+	testCases := []struct {
+		targets  []string
+		expected string
+	}{
+		{expected: olm.AllNamespacesInstallMode},
+		{targets: []string{"tnf"}, expected: olm.OwnNamespaceInstallMode},
+		{targets: []string{"other"}, expected: olm.SingleNamespaceInstallMode},
+		{targets: []string{"tnf", "other"}, expected: olm.MultiNamespaceInstallMode},
+	}
+
+	for _, tc := range testCases {
+		group := &olm.OperatorGroup{}
+		group.Metadata.Namespace = "tnf"
+		group.Spec.TargetNamespaces = tc.targets
+		assert.Equal(t, tc.expected, olm.InstallModeOf(group))
+	}
+}
+
+func TestCheckNames(t *testing.T) {
+	assert.Equal(t, []string{"CSV_INSTALLED", "CSV_SCC", "INSTALL_MODE", "INSTALL_PLAN_APPROVAL", "OWNED_CRDS", "RELATED_IMAGES", "SUBSCRIPTION_CHANNEL"}, olm.CheckNames())
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package olm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/utils"
+)
+
+// Resource types, as named by oc.
+const (
+	CSVResource           = "csv"
+	SubscriptionResource  = "subscription"
+	InstallPlanResource   = "installplan"
+	OperatorGroupResource = "operatorgroup"
+	CRDResource           = "crd"
+
+	ocErrorPrefix    = "Error from server"
+	ocNotFoundPrefix = ocErrorPrefix + " (NotFound)"
+)
+
+// ErrNotFound is wrapped by the error reading a resource that does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ObjectMeta holds the metadata fields used by the checks.
+type ObjectMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// InstallMode is an install mode of a CSV, e.g. OwnNamespace.
+type InstallMode struct {
+	Type      string `json:"type"`
+	Supported bool   `json:"supported"`
+}
+
+// PolicyRule is an RBAC rule requested by a CSV.
+type PolicyRule struct {
+	APIGroups     []string `json:"apiGroups"`
+	Resources     []string `json:"resources"`
+	ResourceNames []string `json:"resourceNames"`
+	Verbs         []string `json:"verbs"`
+}
+
+// Container is a container of a deployment installed by a CSV.
+type Container struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// CSV is a ClusterServiceVersion.
+type CSV struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		InstallModes  []InstallMode `json:"installModes"`
+		RelatedImages []struct {
+			Name  string `json:"name"`
+			Image string `json:"image"`
+		} `json:"relatedImages"`
+		CustomResourceDefinitions struct {
+			Owned []struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+				Kind    string `json:"kind"`
+			} `json:"owned"`
+		} `json:"customresourcedefinitions"`
+		Install struct {
+			Spec struct {
+				ClusterPermissions []struct {
+					ServiceAccountName string       `json:"serviceAccountName"`
+					Rules              []PolicyRule `json:"rules"`
+				} `json:"clusterPermissions"`
+				Deployments []struct {
+					Name string `json:"name"`
+					Spec struct {
+						Template struct {
+							Spec struct {
+								InitContainers []Container `json:"initContainers"`
+								Containers     []Container `json:"containers"`
+							} `json:"spec"`
+						} `json:"template"`
+					} `json:"spec"`
+				} `json:"deployments"`
+			} `json:"spec"`
+		} `json:"install"`
+	} `json:"spec"`
+	Status struct {
+		Phase   string `json:"phase"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+// Subscription is an OLM Subscription.
+type Subscription struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Channel             string `json:"channel"`
+		Package             string `json:"name"`
+		Source              string `json:"source"`
+		InstallPlanApproval string `json:"installPlanApproval"`
+	} `json:"spec"`
+	Status struct {
+		InstalledCSV   string `json:"installedCSV"`
+		CurrentCSV     string `json:"currentCSV"`
+		State          string `json:"state"`
+		InstallPlanRef *struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"installPlanRef"`
+	} `json:"status"`
+}
+
+// InstallPlan is an OLM InstallPlan.
+type InstallPlan struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Approval                   string   `json:"approval"`
+		Approved                   bool     `json:"approved"`
+		ClusterServiceVersionNames []string `json:"clusterServiceVersionNames"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// OperatorGroup is an OLM OperatorGroup.
+type OperatorGroup struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		TargetNamespaces []string `json:"targetNamespaces"`
+	} `json:"spec"`
+}
+
+// Getter reads resources from the cluster.
+type Getter interface {
+	// GetJSON returns a resource as `oc get -o json` outputs it, or the list of the resources of the namespace when
+	// name is empty.
+	GetJSON(resource, namespace, name string) ([]byte, error)
+	// Names returns the names of all the resources of a cluster scoped type.
+	Names(resource string) ([]string, error)
+}
+
+// Operator holds the OLM resources of an operator under test, and what the configuration expects of them.
+type Operator struct {
+	CSV            *CSV
+	Subscription   *Subscription
+	InstallPlan    *InstallPlan
+	OperatorGroups []OperatorGroup
+	// CRDNames are the names of the CRDs of the cluster.
+	CRDNames []string
+	// Errors holds the errors reading the resources, by resource type.  A resource that could not be read is left
+	// empty, failing only the checks reading it.
+	Errors map[string]error
+
+	// ExpectedChannel and ExpectedInstallPlanApproval are checked when set.
+	ExpectedChannel             string
+	ExpectedInstallPlanApproval string
+}
+
+// Fetch reads the OLM resources of the operator whose CSV is csvName and whose subscription is subscriptionName.
+// Each resource is read on its own, the errors are kept in Errors.  A subscription that could not be read keeps its
+// name.  A subscription without an install plan, e.g.
+// while it is being resolved, leaves InstallPlan nil.
+func Fetch(getter Getter, namespace, csvName, subscriptionName string) *Operator {
+	o := &Operator{CSV: &CSV{}, Subscription: &Subscription{Metadata: ObjectMeta{Name: subscriptionName, Namespace: namespace}},
+		Errors: map[string]error{}}
+	o.setError(CSVResource, get(getter, CSVResource, namespace, csvName, o.CSV))
+	o.setError(SubscriptionResource, get(getter, SubscriptionResource, namespace, subscriptionName, o.Subscription))
+	if ref := o.Subscription.Status.InstallPlanRef; ref != nil {
+		o.InstallPlan = &InstallPlan{}
+		o.setError(InstallPlanResource, get(getter, InstallPlanResource, ref.Namespace, ref.Name, o.InstallPlan))
+	}
+	var groups struct {
+		Items []OperatorGroup `json:"items"`
+	}
+	o.setError(OperatorGroupResource, get(getter, OperatorGroupResource, namespace, "", &groups))
+	o.OperatorGroups = groups.Items
+	var err error
+	o.CRDNames, err = getter.Names(CRDResource)
+	o.setError(CRDResource, err)
+	return o
+}
+
+func (o *Operator) setError(resource string, err error) {
+	if err != nil {
+		o.Errors[resource] = err
+	}
+}
+
+// Err returns the error reading one of the resources the check named checkName reads, nil if they were all read.
+func (o *Operator) Err(checkName string) error {
+	for _, resource := range checkResources[checkName] {
+		if err := o.Errors[resource]; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func get(getter Getter, resource, namespace, name string, v interface{}) error {
+	data, err := getter.GetJSON(resource, namespace, name)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s %s/%s: %v", resource, namespace, name, err)
+	}
+	return nil
+}
+
+// OcGetter is a Getter running oc commands in a shell.
+type OcGetter struct {
+	context *interactive.Context
+	timeout time.Duration
+}
+
+// NewOcGetter creates an OcGetter running its commands in context.
+func NewOcGetter(context *interactive.Context, timeout time.Duration) *OcGetter {
+	return &OcGetter{context: context, timeout: timeout}
+}
+
+// GetJSON runs `oc get -o json`.
+func (g *OcGetter) GetJSON(resource, namespace, name string) ([]byte, error) {
+	command := fmt.Sprintf("oc get %s -n %s -o json", resource, namespace)
+	if name != "" {
+		command = fmt.Sprintf("oc get %s %s -n %s -o json", resource, name, namespace)
+	}
+	out, err := g.run(command)
+	return []byte(out), err
+}
+
+// Names lists the names only, the full resources may be too big for the session buffer, e.g. CRDs and their
+// schemas.
+func (g *OcGetter) Names(resource string) ([]string, error) {
+	out, err := g.run(fmt.Sprintf("oc get %s -o jsonpath={.items[*].metadata.name}", resource))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+func (g *OcGetter) run(command string) (string, error) {
+	out, err := utils.ExecuteCommand(command, g.timeout, g.context)
+	if err != nil {
+		return "", err
+	}
+	if out = strings.TrimSpace(out); strings.HasPrefix(out, ocNotFoundPrefix) {
+		return "", fmt.Errorf("%s: %s: %w", command, out, ErrNotFound)
+	}
+	if strings.HasPrefix(out, ocErrorPrefix) {
+		return "", fmt.Errorf("%s: %s", command, out)
+	}
+	return out, nil
+}
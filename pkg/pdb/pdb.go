@@ -0,0 +1,120 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package pdb
+
+import (
+	"fmt"
+	"strings"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Finding is a missing PodDisruptionBudget, or one that keeps the pods of a podset from being evicted.
+type Finding struct {
+	// PodDisruptionBudget is the name of the PodDisruptionBudget, empty when none selects the pods.
+	PodDisruptionBudget string `json:"podDisruptionBudget,omitempty"`
+	// Detail tells what is wrong.
+	Detail string `json:"detail"`
+}
+
+func (f *Finding) String() string {
+	if f.PodDisruptionBudget == "" {
+		return f.Detail
+	}
+	return fmt.Sprintf("PodDisruptionBudget %s: %s", f.PodDisruptionBudget, f.Detail)
+}
+
+// Selecting returns the PodDisruptionBudgets whose selector selects a pod with podLabels.  A PodDisruptionBudget
+// without a selector selects no pod, one with an empty selector all the pods of its namespace, as in policy/v1.
+func Selecting(pdbs []policyv1.PodDisruptionBudget, podLabels map[string]string) ([]*policyv1.PodDisruptionBudget, error) {
+	var selecting []*policyv1.PodDisruptionBudget
+	for i := range pdbs {
+		p := &pdbs[i]
+		if p.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(p.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			selecting = append(selecting, p)
+		}
+	}
+	return selecting, nil
+}
+
+// BlockingReason returns why p blocks the eviction of every pod of a podset of replicas pods, or an empty string when
+// it does not.  Percentages are rounded up, as the disruption controller does.
+func BlockingReason(p *policyv1.PodDisruptionBudget, replicas int) (string, error) {
+	if p.Spec.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(p.Spec.MaxUnavailable, replicas, true)
+		if err != nil {
+			return "", err
+		}
+		if maxUnavailable <= 0 {
+			return fmt.Sprintf("maxUnavailable is %s", p.Spec.MaxUnavailable.String()), nil
+		}
+	}
+	if p.Spec.MinAvailable != nil {
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(p.Spec.MinAvailable, replicas, true)
+		if err != nil {
+			return "", err
+		}
+		if minAvailable >= replicas {
+			return fmt.Sprintf("minAvailable %s is not lower than the %d replicas", p.Spec.MinAvailable.String(), replicas), nil
+		}
+	}
+	return "", nil
+}
+
+// Check returns the findings about the PodDisruptionBudgets, among pdbs, of a podset of replicas pods with podLabels.
+// The pods need exactly one PodDisruptionBudget: the eviction of a pod selected by several ones is refused.
+func Check(pdbs []policyv1.PodDisruptionBudget, podLabels map[string]string, replicas int) ([]Finding, error) {
+	selecting, err := Selecting(pdbs, podLabels)
+	if err != nil {
+		return nil, err
+	}
+	if len(selecting) == 0 {
+		return []Finding{{Detail: "no PodDisruptionBudget selects the pods"}}, nil
+	}
+	if len(selecting) > 1 {
+		names := make([]string, len(selecting))
+		for i, p := range selecting {
+			names[i] = p.Name
+		}
+		return []Finding{{Detail: fmt.Sprintf("%d PodDisruptionBudgets select the pods (%s), evictions are refused",
+			len(selecting), strings.Join(names, ", "))}}, nil
+	}
+	reason, err := BlockingReason(selecting[0], replicas)
+	if err != nil || reason == "" {
+		return nil, err
+	}
+	return []Finding{{PodDisruptionBudget: selecting[0].Name, Detail: reason + ", no pod can be evicted"}}, nil
+}
+
+// Reason returns a one-line summary of the findings, for the claim.
+func Reason(findings []Finding) string {
+	reasons := make([]string, len(findings))
+	for i := range findings {
+		reasons[i] = findings[i].String()
+	}
+	return strings.Join(reasons, "; ")
+}
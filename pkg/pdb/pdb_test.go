@@ -0,0 +1,132 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package pdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newPDB(name string, matchLabels map[string]string, minAvailable, maxUnavailable *intstr.IntOrString) policyv1.PodDisruptionBudget {
+	return policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tnf"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: matchLabels},
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}
+}
+
+func intOrString(s string) *intstr.IntOrString {
+	v := intstr.Parse(s)
+	return &v
+}
+
+func TestSelecting(t *testing.T) {
+	pdbs := []policyv1.PodDisruptionBudget{
+		newPDB("test", map[string]string{"app": "test"}, intOrString("1"), nil),
+		newPDB("other", map[string]string{"app": "other"}, intOrString("1"), nil),
+		newPDB("all", map[string]string{}, intOrString("1"), nil),
+		{ObjectMeta: metav1.ObjectMeta{Name: "none"}},
+	}
+	selecting, err := Selecting(pdbs, map[string]string{"app": "test", "tier": "front"})
+	assert.Nil(t, err)
+	assert.Len(t, selecting, 2)
+	assert.Equal(t, "test", selecting[0].Name)
+	assert.Equal(t, "all", selecting[1].Name)
+
+	invalid := newPDB("invalid", nil, nil, nil)
+	invalid.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}}
+	_, err = Selecting([]policyv1.PodDisruptionBudget{invalid}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestBlockingReason(t *testing.T) {
+	testCases := []struct {
+		minAvailable   *intstr.IntOrString
+		maxUnavailable *intstr.IntOrString
+		replicas       int
+		expected       string
+	}{
+		{minAvailable: intOrString("1"), replicas: 2},
+		{minAvailable: intOrString("2"), replicas: 2, expected: "minAvailable 2 is not lower than the 2 replicas"},
+		{minAvailable: intOrString("3"), replicas: 2, expected: "minAvailable 3 is not lower than the 2 replicas"},
+		{minAvailable: intOrString("50%"), replicas: 3},
+		{minAvailable: intOrString("60%"), replicas: 2, expected: "minAvailable 60% is not lower than the 2 replicas"},
+		{minAvailable: intOrString("100%"), replicas: 3, expected: "minAvailable 100% is not lower than the 3 replicas"},
+		{maxUnavailable: intOrString("1"), replicas: 2},
+		{maxUnavailable: intOrString("0"), replicas: 2, expected: "maxUnavailable is 0"},
+		{maxUnavailable: intOrString("0%"), replicas: 2, expected: "maxUnavailable is 0%"},
+		{maxUnavailable: intOrString("10%"), replicas: 2},
+		{replicas: 2},
+	}
+	for _, tc := range testCases {
+		p := newPDB("test", nil, tc.minAvailable, tc.maxUnavailable)
+		reason, err := BlockingReason(&p, tc.replicas)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, reason)
+	}
+
+	p := newPDB("test", nil, intOrString("half"), nil)
+	_, err := BlockingReason(&p, 2)
+	assert.NotNil(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	podLabels := map[string]string{"app": "test"}
+	testCases := []struct {
+		name     string
+		pdbs     []policyv1.PodDisruptionBudget
+		expected []Finding
+	}{
+		{name: "sound", pdbs: []policyv1.PodDisruptionBudget{newPDB("test", podLabels, intOrString("1"), nil)}},
+		{
+			name:     "missing",
+			pdbs:     []policyv1.PodDisruptionBudget{newPDB("other", map[string]string{"app": "other"}, intOrString("1"), nil)},
+			expected: []Finding{{Detail: "no PodDisruptionBudget selects the pods"}},
+		},
+		{
+			name: "several",
+			pdbs: []policyv1.PodDisruptionBudget{
+				newPDB("test", podLabels, intOrString("1"), nil),
+				newPDB("all", map[string]string{}, nil, intOrString("1")),
+			},
+			expected: []Finding{{Detail: "2 PodDisruptionBudgets select the pods (test, all), evictions are refused"}},
+		},
+		{
+			name:     "blocking",
+			pdbs:     []policyv1.PodDisruptionBudget{newPDB("test", podLabels, nil, intOrString("0"))},
+			expected: []Finding{{PodDisruptionBudget: "test", Detail: "maxUnavailable is 0, no pod can be evicted"}},
+		},
+	}
+	for _, tc := range testCases {
+		findings, err := Check(tc.pdbs, podLabels, 2)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expected, findings, tc.name)
+	}
+}
+
+func TestReason(t *testing.T) {
+	assert.Equal(t, "", Reason(nil))
+	assert.Equal(t, "no PodDisruptionBudget selects the pods; PodDisruptionBudget test: maxUnavailable is 0",
+		Reason([]Finding{{Detail: "no PodDisruptionBudget selects the pods"}, {PodDisruptionBudget: "test", Detail: "maxUnavailable is 0"}}))
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package podsecurity evaluates pod specs against the baseline and restricted profiles of the Kubernetes Pod Security
+Standards (https://kubernetes.io/docs/concepts/security/pod-security-standards/). Each violation names the control of
+the standard it breaks and, when the control applies to containers, the container breaking it, so that it can be
+reported the way the admission controller would.
+*/
+package podsecurity
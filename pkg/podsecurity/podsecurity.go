@@ -0,0 +1,332 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podsecurity
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Level is a profile of the Pod Security Standards.
+type Level string
+
+// Profiles of the Pod Security Standards. A pod meeting the restricted profile meets the baseline one.
+const (
+	Baseline   Level = "baseline"
+	Restricted Level = "restricted"
+)
+
+// Names of the controls of the Pod Security Standards.
+const (
+	ControlHostProcess         = "HostProcess"
+	ControlHostNamespaces      = "Host Namespaces"
+	ControlPrivileged          = "Privileged Containers"
+	ControlCapabilities        = "Capabilities"
+	ControlHostPathVolumes     = "HostPath Volumes"
+	ControlHostPorts           = "Host Ports"
+	ControlAppArmor            = "AppArmor"
+	ControlSELinux             = "SELinux"
+	ControlProcMount           = "/proc Mount Type"
+	ControlSeccomp             = "Seccomp"
+	ControlSysctls             = "Sysctls"
+	ControlVolumeTypes         = "Volume Types"
+	ControlPrivilegeEscalation = "Privilege Escalation"
+	ControlRunAsNonRoot        = "Running as Non-root"
+	ControlRunAsNonRootUser    = "Running as Non-root user"
+)
+
+const (
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+	appArmorRuntimeDefault   = "runtime/default"
+	appArmorLocalhostPrefix  = "localhost/"
+	capabilityAll            = "ALL"
+	capabilityNetBindService = "NET_BIND_SERVICE"
+	hostPathVolumeType       = "hostPath"
+)
+
+var (
+	// baselineCapabilities are the capabilities the baseline profile allows to add.
+	baselineCapabilities = map[corev1.Capability]bool{
+		"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true,
+		"MKNOD": true, capabilityNetBindService: true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true,
+		"SYS_CHROOT": true,
+	}
+	// baselineSELinuxTypes are the SELinux types the baseline profile allows.
+	baselineSELinuxTypes = map[string]bool{"": true, "container_t": true, "container_init_t": true, "container_kvm_t": true}
+	// baselineSysctls are the safe sysctls the baseline profile allows.
+	baselineSysctls = map[string]bool{
+		"kernel.shm_rmid_forced": true, "net.ipv4.ip_local_port_range": true, "net.ipv4.ip_unprivileged_port_start": true,
+		"net.ipv4.tcp_syncookies": true, "net.ipv4.ping_group_range": true,
+	}
+	// restrictedVolumeTypes are the volume types the restricted profile allows.
+	restrictedVolumeTypes = map[string]bool{
+		"configMap": true, "csi": true, "downwardAPI": true, "emptyDir": true, "ephemeral": true,
+		"persistentVolumeClaim": true, "projected": true, "secret": true,
+	}
+)
+
+// Violation is a control of the Pod Security Standards a pod does not meet.
+type Violation struct {
+	// Level is the profile the control belongs to.
+	Level Level `json:"level"`
+	// Control is the name of the control in the standard, e.g. "Privilege Escalation".
+	Control string `json:"control"`
+	// Container is the container breaking the control, empty when the pod spec itself does.
+	Container string `json:"container,omitempty"`
+	// Detail tells which field breaks the control.
+	Detail string `json:"detail"`
+}
+
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s (%s): %s", v.Control, v.Level, v.Detail)
+}
+
+// container is the part of the init, regular and ephemeral containers the controls look at.
+type container struct {
+	name            string
+	securityContext *corev1.SecurityContext
+	ports           []corev1.ContainerPort
+}
+
+func podContainers(spec *corev1.PodSpec) []container {
+	var containers []container
+	for i := range spec.InitContainers {
+		c := &spec.InitContainers[i]
+		containers = append(containers, container{name: c.Name, securityContext: c.SecurityContext, ports: c.Ports})
+	}
+	for i := range spec.Containers {
+		c := &spec.Containers[i]
+		containers = append(containers, container{name: c.Name, securityContext: c.SecurityContext, ports: c.Ports})
+	}
+	for i := range spec.EphemeralContainers {
+		c := &spec.EphemeralContainers[i]
+		containers = append(containers, container{name: c.Name, securityContext: c.SecurityContext, ports: c.Ports})
+	}
+	return containers
+}
+
+// ContainerNames returns the names of the init, regular and ephemeral containers of pod, in the order of the spec.
+func ContainerNames(pod *corev1.Pod) []string {
+	containers := podContainers(&pod.Spec)
+	names := make([]string, len(containers))
+	for i := range containers {
+		names[i] = containers[i].name
+	}
+	return names
+}
+
+// evaluation collects the violations of a pod.
+type evaluation struct {
+	pod        *corev1.Pod
+	podContext *corev1.PodSecurityContext
+	violations []Violation
+}
+
+func (e *evaluation) add(level Level, control, containerName, format string, args ...interface{}) {
+	e.violations = append(e.violations, Violation{Level: level, Control: control, Container: containerName, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Evaluate returns the controls of the given profile pod does not meet: the pod-level ones first, then the ones of its
+// init, regular and ephemeral containers in the order of the spec.
+func Evaluate(pod *corev1.Pod, level Level) []Violation {
+	e := evaluation{pod: pod, podContext: pod.Spec.SecurityContext}
+	if e.podContext == nil {
+		e.podContext = &corev1.PodSecurityContext{}
+	}
+	e.checkPodBaseline()
+	if level == Restricted {
+		e.checkPodRestricted()
+	}
+	for _, c := range podContainers(&pod.Spec) {
+		sc := c.securityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		e.checkContainerBaseline(c.name, sc, c.ports)
+		if level == Restricted {
+			e.checkContainerRestricted(c.name, sc)
+		}
+	}
+	return e.violations
+}
+
+func (e *evaluation) checkPodBaseline() {
+	spec := &e.pod.Spec
+	if opts := e.podContext.WindowsOptions; opts != nil && opts.HostProcess != nil && *opts.HostProcess {
+		e.add(Baseline, ControlHostProcess, "", "securityContext.windowsOptions.hostProcess is true")
+	}
+	if spec.HostNetwork {
+		e.add(Baseline, ControlHostNamespaces, "", "hostNetwork is true")
+	}
+	if spec.HostPID {
+		e.add(Baseline, ControlHostNamespaces, "", "hostPID is true")
+	}
+	if spec.HostIPC {
+		e.add(Baseline, ControlHostNamespaces, "", "hostIPC is true")
+	}
+	for i := range spec.Volumes {
+		if spec.Volumes[i].HostPath != nil {
+			e.add(Baseline, ControlHostPathVolumes, "", "volume %s is a hostPath volume", spec.Volumes[i].Name)
+		}
+	}
+	if opts := e.podContext.SELinuxOptions; opts != nil {
+		e.checkSELinux("", "securityContext", opts)
+	}
+	if p := e.podContext.SeccompProfile; p != nil && p.Type == corev1.SeccompProfileTypeUnconfined {
+		e.add(Baseline, ControlSeccomp, "", "securityContext.seccompProfile.type is %s", p.Type)
+	}
+	for _, s := range e.podContext.Sysctls {
+		if !baselineSysctls[s.Name] {
+			e.add(Baseline, ControlSysctls, "", "sysctl %s is not a safe sysctl", s.Name)
+		}
+	}
+}
+
+func (e *evaluation) checkSELinux(containerName, field string, opts *corev1.SELinuxOptions) {
+	if !baselineSELinuxTypes[opts.Type] {
+		e.add(Baseline, ControlSELinux, containerName, "%s.seLinuxOptions.type is %s", field, opts.Type)
+	}
+	if opts.User != "" {
+		e.add(Baseline, ControlSELinux, containerName, "%s.seLinuxOptions.user is set", field)
+	}
+	if opts.Role != "" {
+		e.add(Baseline, ControlSELinux, containerName, "%s.seLinuxOptions.role is set", field)
+	}
+}
+
+func (e *evaluation) checkContainerBaseline(name string, sc *corev1.SecurityContext, ports []corev1.ContainerPort) {
+	if opts := sc.WindowsOptions; opts != nil && opts.HostProcess != nil && *opts.HostProcess {
+		e.add(Baseline, ControlHostProcess, name, "securityContext.windowsOptions.hostProcess is true")
+	}
+	if sc.Privileged != nil && *sc.Privileged {
+		e.add(Baseline, ControlPrivileged, name, "securityContext.privileged is true")
+	}
+	if sc.Capabilities != nil {
+		for _, c := range sc.Capabilities.Add {
+			if !baselineCapabilities[c] {
+				e.add(Baseline, ControlCapabilities, name, "capability %s is added", c)
+			}
+		}
+	}
+	for _, p := range ports {
+		if p.HostPort != 0 {
+			e.add(Baseline, ControlHostPorts, name, "port %d/%s uses host port %d", p.ContainerPort, p.Protocol, p.HostPort)
+		}
+	}
+	if profile, ok := e.pod.Annotations[appArmorAnnotationPrefix+name]; ok && profile != appArmorRuntimeDefault &&
+		!strings.HasPrefix(profile, appArmorLocalhostPrefix) {
+		e.add(Baseline, ControlAppArmor, name, "AppArmor profile is %s", profile)
+	}
+	if sc.SELinuxOptions != nil {
+		e.checkSELinux(name, "securityContext", sc.SELinuxOptions)
+	}
+	if sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+		e.add(Baseline, ControlProcMount, name, "securityContext.procMount is %s", *sc.ProcMount)
+	}
+	if p := sc.SeccompProfile; p != nil && p.Type == corev1.SeccompProfileTypeUnconfined {
+		e.add(Baseline, ControlSeccomp, name, "securityContext.seccompProfile.type is %s", p.Type)
+	}
+}
+
+func (e *evaluation) checkPodRestricted() {
+	for i := range e.pod.Spec.Volumes {
+		v := &e.pod.Spec.Volumes[i]
+		for _, t := range volumeTypes(&v.VolumeSource) {
+			// A hostPath volume breaks the baseline profile already.
+			if t != hostPathVolumeType && !restrictedVolumeTypes[t] {
+				e.add(Restricted, ControlVolumeTypes, "", "volume %s is a %s volume", v.Name, t)
+			}
+		}
+	}
+	if e.podContext.RunAsUser != nil && *e.podContext.RunAsUser == 0 {
+		e.add(Restricted, ControlRunAsNonRootUser, "", "securityContext.runAsUser is 0")
+	}
+}
+
+// volumeTypes returns the names of the volume source fields that are set, e.g. "hostPath".
+func volumeTypes(source *corev1.VolumeSource) []string {
+	// The field names are the JSON keys of the source, only the ones set are output.
+	data, err := json.Marshal(source)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	types := make([]string, 0, len(fields))
+	for t := range fields {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (e *evaluation) checkContainerRestricted(name string, sc *corev1.SecurityContext) {
+	if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+		e.add(Restricted, ControlPrivilegeEscalation, name, "securityContext.allowPrivilegeEscalation is not false")
+	}
+	runAsNonRoot := e.podContext.RunAsNonRoot != nil && *e.podContext.RunAsNonRoot
+	if sc.RunAsNonRoot != nil {
+		runAsNonRoot = *sc.RunAsNonRoot
+	}
+	if !runAsNonRoot {
+		e.add(Restricted, ControlRunAsNonRoot, name, "runAsNonRoot is not true in the container or pod securityContext")
+	}
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		e.add(Restricted, ControlRunAsNonRootUser, name, "securityContext.runAsUser is 0")
+	}
+	// An unconfined profile breaks the baseline profile already.
+	if sc.SeccompProfile == nil && e.podContext.SeccompProfile == nil {
+		e.add(Restricted, ControlSeccomp, name, "seccompProfile.type is not set in the container or pod securityContext")
+	}
+	var add, drop []corev1.Capability
+	if sc.Capabilities != nil {
+		add, drop = sc.Capabilities.Add, sc.Capabilities.Drop
+	}
+	if !hasCapability(drop, capabilityAll) {
+		e.add(Restricted, ControlCapabilities, name, "capability %s is not dropped", capabilityAll)
+	}
+	for _, c := range add {
+		// The ones outside of the baseline set break the baseline profile already.
+		if c != capabilityNetBindService && baselineCapabilities[c] {
+			e.add(Restricted, ControlCapabilities, name, "capability %s is added", c)
+		}
+	}
+}
+
+func hasCapability(capabilities []corev1.Capability, c corev1.Capability) bool {
+	for _, capability := range capabilities {
+		if capability == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Reason returns the violations of a pod, or of one of its containers, as the reason of a non-compliant object.
+func Reason(violations []Violation) string {
+	reasons := make([]string, len(violations))
+	for i := range violations {
+		reasons[i] = violations[i].String()
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package podsecurity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// restrictedPod returns a pod meeting the restricted profile.
+func restrictedPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "tnf"},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   boolPtr(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []corev1.Container{{
+				Name: "test",
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: boolPtr(false),
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+						Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+					},
+				},
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+			},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(pod *corev1.Pod)
+		level    Level
+		expected []Violation
+	}{
+		{name: "restricted pod", modify: func(pod *corev1.Pod) {}, level: Restricted},
+		{
+			name: "host namespaces and hostPath",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.HostNetwork = true
+				pod.Spec.HostPID = true
+				pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: "host",
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}}})
+			},
+			level: Restricted,
+			expected: []Violation{
+				{Level: Baseline, Control: ControlHostNamespaces, Detail: "hostNetwork is true"},
+				{Level: Baseline, Control: ControlHostNamespaces, Detail: "hostPID is true"},
+				{Level: Baseline, Control: ControlHostPathVolumes, Detail: "volume host is a hostPath volume"},
+			},
+		},
+		{
+			name: "volume type outside the restricted profile",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: "share",
+					VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"}}})
+			},
+			level:    Restricted,
+			expected: []Violation{{Level: Restricted, Control: ControlVolumeTypes, Detail: "volume share is a nfs volume"}},
+		},
+		{
+			name: "privileged container",
+			modify: func(pod *corev1.Pod) {
+				sc := pod.Spec.Containers[0].SecurityContext
+				sc.Privileged = boolPtr(true)
+				sc.AllowPrivilegeEscalation = nil
+				sc.Capabilities = &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN", "CHOWN"}}
+				pm := corev1.UnmaskedProcMount
+				sc.ProcMount = &pm
+				pod.Spec.Containers[0].Ports[0].HostPort = 80
+			},
+			level: Restricted,
+			expected: []Violation{
+				{Level: Baseline, Control: ControlPrivileged, Container: "test", Detail: "securityContext.privileged is true"},
+				{Level: Baseline, Control: ControlCapabilities, Container: "test", Detail: "capability SYS_ADMIN is added"},
+				{Level: Baseline, Control: ControlHostPorts, Container: "test", Detail: "port 8080/TCP uses host port 80"},
+				{Level: Baseline, Control: ControlProcMount, Container: "test", Detail: "securityContext.procMount is Unmasked"},
+				{Level: Restricted, Control: ControlPrivilegeEscalation, Container: "test",
+					Detail: "securityContext.allowPrivilegeEscalation is not false"},
+				{Level: Restricted, Control: ControlCapabilities, Container: "test", Detail: "capability ALL is not dropped"},
+				{Level: Restricted, Control: ControlCapabilities, Container: "test", Detail: "capability CHOWN is added"},
+			},
+		},
+		{
+			name: "privileged container, baseline only",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: boolPtr(true)}
+				pod.Spec.SecurityContext = nil
+			},
+			level: Baseline,
+			expected: []Violation{
+				{Level: Baseline, Control: ControlPrivileged, Container: "test", Detail: "securityContext.privileged is true"},
+			},
+		},
+		{
+			name: "root and unconfined",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.SecurityContext = &corev1.PodSecurityContext{
+					RunAsUser:      int64Ptr(0),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+					Sysctls:        []corev1.Sysctl{{Name: "net.ipv4.tcp_syncookies"}, {Name: "kernel.msgmax"}},
+				}
+			},
+			level: Restricted,
+			expected: []Violation{
+				{Level: Baseline, Control: ControlSeccomp, Detail: "securityContext.seccompProfile.type is Unconfined"},
+				{Level: Baseline, Control: ControlSysctls, Detail: "sysctl kernel.msgmax is not a safe sysctl"},
+				{Level: Restricted, Control: ControlRunAsNonRootUser, Detail: "securityContext.runAsUser is 0"},
+				{Level: Restricted, Control: ControlRunAsNonRoot, Container: "test",
+					Detail: "runAsNonRoot is not true in the container or pod securityContext"},
+			},
+		},
+		{
+			name: "container overrides the pod",
+			modify: func(pod *corev1.Pod) {
+				sc := pod.Spec.Containers[0].SecurityContext
+				sc.RunAsNonRoot = boolPtr(false)
+				sc.SELinuxOptions = &corev1.SELinuxOptions{Type: "spc_t"}
+				pod.Annotations = map[string]string{appArmorAnnotationPrefix + "test": "unconfined"}
+				pod.Spec.InitContainers = []corev1.Container{{Name: "init", SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: boolPtr(false),
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost},
+				}}}
+			},
+			level: Restricted,
+			expected: []Violation{
+				{Level: Baseline, Control: ControlAppArmor, Container: "test", Detail: "AppArmor profile is unconfined"},
+				{Level: Baseline, Control: ControlSELinux, Container: "test", Detail: "securityContext.seLinuxOptions.type is spc_t"},
+				{Level: Restricted, Control: ControlRunAsNonRoot, Container: "test",
+					Detail: "runAsNonRoot is not true in the container or pod securityContext"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		pod := restrictedPod()
+		tc.modify(pod)
+		assert.Equal(t, tc.expected, Evaluate(pod, tc.level), tc.name)
+	}
+}
+
+func TestReason(t *testing.T) {
+	assert.Equal(t, "", Reason(nil))
+	assert.Equal(t, "Privileged Containers (baseline): securityContext.privileged is true; Seccomp (restricted): not set",
+		Reason([]Violation{
+			{Level: Baseline, Control: ControlPrivileged, Container: "test", Detail: "securityContext.privileged is true"},
+			{Level: Restricted, Control: ControlSeccomp, Container: "test", Detail: "not set"},
+		}))
+}
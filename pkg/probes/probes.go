@@ -0,0 +1,183 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package probes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ProbeType is the kind of a probe.
+type ProbeType string
+
+// Kinds of probes.
+const (
+	Liveness  ProbeType = "liveness"
+	Readiness ProbeType = "readiness"
+	Startup   ProbeType = "startup"
+)
+
+const (
+	// SlowStartThreshold is the liveness probe delay, or the observed start time, above which a container needs a
+	// startup probe.  It is the time a liveness probe with the default settings lets a container answer.
+	SlowStartThreshold = 30 * time.Second
+	// MinLivenessFailureWindow is the lowest time a liveness probe may take to restart a container that stopped
+	// answering, so that a transient slowdown does not restart it.
+	MinLivenessFailureWindow = 10 * time.Second
+)
+
+// Defaults Kubernetes applies to the unset probe fields.
+const (
+	defaultTimeoutSeconds   = 1
+	defaultPeriodSeconds    = 10
+	defaultFailureThreshold = 3
+)
+
+// Finding is a probe a container misses or whose timing is not sound.
+type Finding struct {
+	// Probe is the kind of the probe.
+	Probe ProbeType `json:"probe"`
+	// Detail tells what is wrong with the probe.
+	Detail string `json:"detail"`
+}
+
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s probe: %s", f.Probe, f.Detail)
+}
+
+// timing is the timing of a probe, with the defaults applied.
+type timing struct {
+	initialDelay     time.Duration
+	timeout          time.Duration
+	period           time.Duration
+	failureThreshold int32
+}
+
+func probeTiming(p *corev1.Probe) timing {
+	t := timing{
+		initialDelay:     time.Duration(p.InitialDelaySeconds) * time.Second,
+		timeout:          time.Duration(p.TimeoutSeconds) * time.Second,
+		period:           time.Duration(p.PeriodSeconds) * time.Second,
+		failureThreshold: p.FailureThreshold,
+	}
+	if t.timeout == 0 {
+		t.timeout = defaultTimeoutSeconds * time.Second
+	}
+	if t.period == 0 {
+		t.period = defaultPeriodSeconds * time.Second
+	}
+	if t.failureThreshold == 0 {
+		t.failureThreshold = defaultFailureThreshold
+	}
+	return t
+}
+
+// failureWindow is the time the probe takes to fail once the container stops answering.
+func (t *timing) failureWindow() time.Duration {
+	return t.period * time.Duration(t.failureThreshold)
+}
+
+// check holds the findings of the probes of a container.
+type check struct {
+	container *corev1.Container
+	startTime time.Duration
+	findings  []Finding
+}
+
+func (c *check) add(probe ProbeType, format string, args ...interface{}) {
+	c.findings = append(c.findings, Finding{Probe: probe, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Check checks the probes of container c, which took startTime to start, 0 if unknown.  Its liveness and readiness
+// probes must be defined, and its startup probe if it took more than SlowStartThreshold to start or if its liveness
+// probe is delayed by more than SlowStartThreshold to let it start.
+func Check(c *corev1.Container, startTime time.Duration) []Finding {
+	ch := check{container: c, startTime: startTime}
+	for _, p := range []struct {
+		probeType ProbeType
+		probe     *corev1.Probe
+	}{{Liveness, c.LivenessProbe}, {Readiness, c.ReadinessProbe}, {Startup, c.StartupProbe}} {
+		if p.probe == nil {
+			if p.probeType != Startup {
+				ch.add(p.probeType, "not defined")
+			}
+			continue
+		}
+		if t := probeTiming(p.probe); t.timeout >= t.period {
+			ch.add(p.probeType, "timeout (%v) is not lower than period (%v)", t.timeout, t.period)
+		}
+	}
+	if c.LivenessProbe != nil {
+		ch.checkLiveness()
+	}
+	return ch.findings
+}
+
+// checkLiveness checks that the liveness probe does not restart the container as soon as it stops answering, and
+// that a startup probe lets the container start when the liveness probe is delayed to do so.
+func (c *check) checkLiveness() {
+	liveness := probeTiming(c.container.LivenessProbe)
+	if window := liveness.failureWindow(); window < MinLivenessFailureWindow {
+		c.add(Liveness, "restarts the container after %v of failures (period %v x failureThreshold %d), less than %v",
+			window, liveness.period, liveness.failureThreshold, MinLivenessFailureWindow)
+	}
+	switch {
+	case c.container.StartupProbe != nil:
+	case liveness.initialDelay > SlowStartThreshold:
+		c.add(Startup, "not defined while the liveness probe is delayed by %v", liveness.initialDelay)
+	case c.startTime > SlowStartThreshold:
+		c.add(Startup, "not defined while the container took %v to start", c.startTime)
+	}
+}
+
+// StartTime estimates the time the container named name took to start, from its start to the time the containers of
+// pod became ready, or returns 0 if it cannot be told: the container restarted, or is not running and ready.  As the
+// ContainersReady condition records the last transition only, it is an upper bound for a pod whose containers do not
+// start together or whose readiness changed since.
+func StartTime(pod *corev1.Pod, name string) time.Duration {
+	var ready *corev1.PodCondition
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.ContainersReady && pod.Status.Conditions[i].Status == corev1.ConditionTrue {
+			ready = &pod.Status.Conditions[i]
+		}
+	}
+	if ready == nil {
+		return 0
+	}
+	for i := range pod.Status.ContainerStatuses {
+		status := &pod.Status.ContainerStatuses[i]
+		if status.Name != name || status.RestartCount > 0 || !status.Ready || status.State.Running == nil {
+			continue
+		}
+		if startTime := ready.LastTransitionTime.Sub(status.State.Running.StartedAt.Time); startTime > 0 {
+			return startTime
+		}
+	}
+	return 0
+}
+
+// Reason returns a one-line summary of the findings, for the claim.
+func Reason(findings []Finding) string {
+	reasons := make([]string, len(findings))
+	for i := range findings {
+		reasons[i] = findings[i].String()
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package probes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// soundContainer returns a container with sound liveness and readiness probes.
+func soundContainer() *corev1.Container {
+	probe := func() *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler:  corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"}},
+			PeriodSeconds: 10, TimeoutSeconds: 1, FailureThreshold: 3,
+		}
+	}
+	return &corev1.Container{Name: "test", LivenessProbe: probe(), ReadinessProbe: probe()}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modify    func(c *corev1.Container)
+		startTime time.Duration
+		expected  []Finding
+	}{
+		{name: "sound probes", modify: func(c *corev1.Container) {}},
+		{
+			name:     "missing probes",
+			modify:   func(c *corev1.Container) { c.LivenessProbe, c.ReadinessProbe = nil, nil },
+			expected: []Finding{{Probe: Liveness, Detail: "not defined"}, {Probe: Readiness, Detail: "not defined"}},
+		},
+		{
+			name: "defaults applied",
+			modify: func(c *corev1.Container) {
+				c.LivenessProbe = &corev1.Probe{ProbeHandler: c.LivenessProbe.ProbeHandler}
+			},
+		},
+		{
+			name: "instant restart",
+			modify: func(c *corev1.Container) {
+				c.LivenessProbe.PeriodSeconds, c.LivenessProbe.FailureThreshold = 2, 1
+			},
+			expected: []Finding{{Probe: Liveness, Detail: "restarts the container after 2s of failures (period 2s x failureThreshold 1), less than 10s"}},
+		},
+		{
+			name:     "timeout not lower than period",
+			modify:   func(c *corev1.Container) { c.ReadinessProbe.TimeoutSeconds = 10 },
+			expected: []Finding{{Probe: Readiness, Detail: "timeout (10s) is not lower than period (10s)"}},
+		},
+		{
+			name:     "delayed liveness probe without startup probe",
+			modify:   func(c *corev1.Container) { c.LivenessProbe.InitialDelaySeconds = 120 },
+			expected: []Finding{{Probe: Startup, Detail: "not defined while the liveness probe is delayed by 2m0s"}},
+		},
+		{
+			name: "delayed liveness probe with startup probe",
+			modify: func(c *corev1.Container) {
+				c.LivenessProbe.InitialDelaySeconds = 120
+				c.StartupProbe = &corev1.Probe{PeriodSeconds: 10, TimeoutSeconds: 1, FailureThreshold: 30}
+			},
+		},
+		{
+			name:      "slow start without startup probe",
+			modify:    func(c *corev1.Container) {},
+			startTime: 45 * time.Second,
+			expected:  []Finding{{Probe: Startup, Detail: "not defined while the container took 45s to start"}},
+		},
+		{
+			name: "slow start with startup probe",
+			modify: func(c *corev1.Container) {
+				c.StartupProbe = &corev1.Probe{PeriodSeconds: 10, TimeoutSeconds: 1, FailureThreshold: 30}
+			},
+			startTime: 45 * time.Second,
+		},
+		{
+			name:      "quick start",
+			modify:    func(c *corev1.Container) {},
+			startTime: 5 * time.Second,
+		},
+		{
+			name: "startup probe timeout not lower than period",
+			modify: func(c *corev1.Container) {
+				c.StartupProbe = &corev1.Probe{PeriodSeconds: 5, TimeoutSeconds: 5, FailureThreshold: 30}
+			},
+			expected: []Finding{{Probe: Startup, Detail: "timeout (5s) is not lower than period (5s)"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		c := soundContainer()
+		tc.modify(c)
+		assert.Equal(t, tc.expected, Check(c, tc.startTime), tc.name)
+	}
+}
+
+func TestStartTime(t *testing.T) {
+	started := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	pod := func(ready corev1.ConditionStatus, restartCount int32) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{
+				Type: corev1.ContainersReady, Status: ready, LastTransitionTime: metav1.NewTime(started.Add(45 * time.Second)),
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "test", Ready: ready == corev1.ConditionTrue, RestartCount: restartCount,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}},
+			}},
+		}}
+	}
+
+	assert.Equal(t, 45*time.Second, StartTime(pod(corev1.ConditionTrue, 0), "test"))
+	assert.Zero(t, StartTime(pod(corev1.ConditionTrue, 0), "other"))
+	// The start of a restarted container is not the one that made the pod ready.
+	assert.Zero(t, StartTime(pod(corev1.ConditionTrue, 1), "test"))
+	assert.Zero(t, StartTime(pod(corev1.ConditionFalse, 0), "test"))
+}
+
+func TestReason(t *testing.T) {
+	assert.Equal(t, "", Reason(nil))
+	assert.Equal(t, "liveness probe: not defined; startup probe: not defined while the liveness probe is delayed by 2m0s",
+		Reason([]Finding{
+			{Probe: Liveness, Detail: "not defined"},
+			{Probe: Startup, Detail: "not defined while the liveness probe is delayed by 2m0s"},
+		}))
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package rbac resolves the Roles and ClusterRoles bound to a service account, through the bindings naming it or the group
+of the service accounts of its namespace, and flags the rules granting dangerous permissions: exec into pods, reading
+secrets, escalating, binding or impersonating, proxying to nodes and wildcard verbs or resources. Aggregated ClusterRoles
+are expanded into the roles they aggregate, and wildcards in rules are matched against the dangerous permissions, so that
+each finding carries the chain of bindings and roles granting it.
+*/
+package rbac
@@ -0,0 +1,334 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbac
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	serviceAccountUserFormat      = "system:serviceaccount:%s:%s"
+	serviceAccountsGroup          = "system:serviceaccounts"
+	namespaceServiceAccountsGroup = "system:serviceaccounts:%s"
+	authenticatedGroup            = "system:authenticated"
+
+	wildcard            = "*"
+	coreGroup           = ""
+	rbacGroup           = "rbac.authorization.k8s.io"
+	subresourceSep      = "/"
+	chainSeparator      = " > "
+	permissionSeparator = ", "
+)
+
+// Names of the dangerous permissions.
+const (
+	PodsExec          = "pods/exec"
+	SecretsRead       = "secrets read"
+	Escalate          = "escalate"
+	Bind              = "bind"
+	Impersonate       = "impersonate"
+	NodesProxy        = "nodes/proxy"
+	WildcardVerbs     = "wildcard verbs"
+	WildcardResources = "wildcard resources"
+)
+
+// permission is a dangerous permission. A rule grants it when it allows one of its verbs on one of its resources of its
+// API group.
+type permission struct {
+	name      string
+	apiGroup  string
+	resources []string
+	verbs     []string
+	// scopedByNames tells that a rule limited to some resource names does not grant it, e.g. reading the secrets of the
+	// CNF by name is fine.
+	scopedByNames bool
+}
+
+var dangerousPermissions = []permission{
+	{name: PodsExec, apiGroup: coreGroup, resources: []string{"pods/exec"}, verbs: []string{"create", "get"}},
+	{name: SecretsRead, apiGroup: coreGroup, resources: []string{"secrets"}, verbs: []string{"get", "list", "watch"}, scopedByNames: true},
+	{name: Escalate, apiGroup: rbacGroup, resources: []string{"roles", "clusterroles"}, verbs: []string{"escalate"}},
+	{name: Bind, apiGroup: rbacGroup, resources: []string{"roles", "clusterroles"}, verbs: []string{"bind"}},
+	{name: Impersonate, apiGroup: coreGroup, resources: []string{"users", "groups", "serviceaccounts"}, verbs: []string{"impersonate"}},
+	{name: NodesProxy, apiGroup: coreGroup, resources: []string{"nodes/proxy"}, verbs: []string{wildcard}},
+}
+
+// ServiceAccountUser returns the user name of the service account serviceAccount of namespace.
+func ServiceAccountUser(namespace, serviceAccount string) string {
+	return fmt.Sprintf(serviceAccountUserFormat, namespace, serviceAccount)
+}
+
+// ServiceAccountGroups returns the groups the service accounts of namespace are members of.
+func ServiceAccountGroups(namespace string) []string {
+	return []string{serviceAccountsGroup, NamespaceServiceAccountsGroup(namespace), authenticatedGroup}
+}
+
+// NamespaceServiceAccountsGroup returns the group of the service accounts of namespace.
+func NamespaceServiceAccountsGroup(namespace string) string {
+	return fmt.Sprintf(namespaceServiceAccountsGroup, namespace)
+}
+
+// Finding is a rule granting dangerous permissions to a service account.
+type Finding struct {
+	// Namespace is the namespace the permissions are granted in, empty when they are granted in all of them.
+	Namespace string `json:"namespace,omitempty"`
+	// Permissions are the names of the dangerous permissions the rule grants, e.g. "pods/exec".
+	Permissions []string `json:"permissions"`
+	// Rule is the rule granting them.
+	Rule string `json:"rule"`
+	// Chain is the binding, then the roles, granting the rule, e.g. ["ClusterRoleBinding cnf", "ClusterRole admin",
+	// "ClusterRole aggregate-to-admin"] for a rule of a ClusterRole aggregated by the bound one.
+	Chain []string `json:"chain"`
+}
+
+func (f *Finding) String() string {
+	scope := "all namespaces"
+	if f.Namespace != "" {
+		scope = "namespace " + f.Namespace
+	}
+	return fmt.Sprintf("%s in %s by %s (%s)", strings.Join(f.Permissions, permissionSeparator), scope,
+		strings.Join(f.Chain, chainSeparator), f.Rule)
+}
+
+// Reason returns findings as the reason of a non-compliant object.
+func Reason(findings []Finding) string {
+	reasons := make([]string, len(findings))
+	for i := range findings {
+		reasons[i] = findings[i].String()
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesResource returns whether the resource ruleResource of a rule, possibly with wildcards, e.g. "pods/*", covers
+// resource.
+func matchesResource(ruleResource, resource string) bool {
+	if ruleResource == wildcard || ruleResource == resource {
+		return true
+	}
+	parts := strings.SplitN(resource, subresourceSep, 2)
+	if len(parts) != 2 {
+		return false
+	}
+	return ruleResource == parts[0]+subresourceSep+wildcard || ruleResource == wildcard+subresourceSep+parts[1]
+}
+
+func (p *permission) grantedBy(rule *rbacv1.PolicyRule) bool {
+	if len(rule.Resources) == 0 || (p.scopedByNames && len(rule.ResourceNames) > 0) {
+		return false
+	}
+	verbGranted := contains(rule.Verbs, wildcard)
+	for _, v := range p.verbs {
+		verbGranted = verbGranted || v == wildcard || contains(rule.Verbs, v)
+	}
+	if !verbGranted {
+		return false
+	}
+	if !contains(rule.APIGroups, wildcard) && !contains(rule.APIGroups, p.apiGroup) {
+		return false
+	}
+	for _, r := range rule.Resources {
+		for _, resource := range p.resources {
+			if matchesResource(r, resource) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dangerousPermissionsOf returns the names of the dangerous permissions rule grants.
+func dangerousPermissionsOf(rule *rbacv1.PolicyRule) []string {
+	var names []string
+	if contains(rule.Verbs, wildcard) {
+		names = append(names, WildcardVerbs)
+	}
+	if contains(rule.Resources, wildcard) {
+		names = append(names, WildcardResources)
+	}
+	for i := range dangerousPermissions {
+		if dangerousPermissions[i].grantedBy(rule) {
+			names = append(names, dangerousPermissions[i].name)
+		}
+	}
+	return names
+}
+
+func ruleString(rule *rbacv1.PolicyRule) string {
+	s := fmt.Sprintf("verbs=%v apiGroups=%q resources=%v", rule.Verbs, rule.APIGroups, rule.Resources)
+	if len(rule.ResourceNames) > 0 {
+		s += fmt.Sprintf(" resourceNames=%v", rule.ResourceNames)
+	}
+	if len(rule.NonResourceURLs) > 0 {
+		s += fmt.Sprintf(" nonResourceURLs=%v", rule.NonResourceURLs)
+	}
+	return s
+}
+
+// roleRules are the rules of a role, along with the chain of roles they come from.
+type roleRules struct {
+	chain []string
+	rules []rbacv1.PolicyRule
+}
+
+// analyzer resolves the roles of the RBAC objects of the cluster.
+type analyzer struct {
+	list         *autodiscover.RBACList
+	clusterRoles map[string]*rbacv1.ClusterRole
+}
+
+// expandClusterRole returns the rules of the ClusterRole named name. The rules of an aggregated ClusterRole are the
+// ones of the ClusterRoles its selectors select, expanded in turn. visited holds the roles being expanded, so that
+// aggregation loops end.
+func (a *analyzer) expandClusterRole(name string, visited map[string]bool) []roleRules {
+	role, ok := a.clusterRoles[name]
+	if !ok || visited[name] {
+		return nil
+	}
+	link := "ClusterRole " + name
+	if role.AggregationRule == nil {
+		return []roleRules{{chain: []string{link}, rules: role.Rules}}
+	}
+	visited[name] = true
+	defer delete(visited, name)
+	var expanded []roleRules
+	for i := range a.list.ClusterRoles {
+		aggregated := &a.list.ClusterRoles[i]
+		if !a.aggregates(role, aggregated) {
+			continue
+		}
+		for _, r := range a.expandClusterRole(aggregated.Name, visited) {
+			expanded = append(expanded, roleRules{chain: append([]string{link}, r.chain...), rules: r.rules})
+		}
+	}
+	return expanded
+}
+
+// aggregates returns whether one of the selectors of the aggregation rule of role selects aggregated.
+func (a *analyzer) aggregates(role, aggregated *rbacv1.ClusterRole) bool {
+	for i := range role.AggregationRule.ClusterRoleSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&role.AggregationRule.ClusterRoleSelectors[i])
+		if err != nil {
+			continue
+		}
+		// An empty selector selects nothing for aggregation.
+		if !selector.Empty() && selector.Matches(labels.Set(aggregated.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandRoleRef returns the rules of the role a binding of namespace refers to.
+func (a *analyzer) expandRoleRef(namespace string, ref *rbacv1.RoleRef) []roleRules {
+	if ref.Kind == "ClusterRole" {
+		return a.expandClusterRole(ref.Name, map[string]bool{})
+	}
+	for i := range a.list.Roles {
+		role := &a.list.Roles[i]
+		if role.Namespace == namespace && role.Name == ref.Name {
+			return []roleRules{{chain: []string{fmt.Sprintf("Role %s/%s", namespace, role.Name)}, rules: role.Rules}}
+		}
+	}
+	return nil
+}
+
+// boundSubject returns how subjects bind the service account serviceAccount of namespace, e.g. "Group
+// system:serviceaccounts:tnf", empty when they do not. Subjects without a namespace are in bindingNamespace. The
+// groups of all the service accounts, or of all the authenticated users, are not looked at as the CNF does not
+// control them.
+func boundSubject(subjects []rbacv1.Subject, bindingNamespace, namespace, serviceAccount string) (string, bool) {
+	for _, s := range subjects {
+		switch s.Kind {
+		case rbacv1.ServiceAccountKind:
+			ns := s.Namespace
+			if ns == "" {
+				ns = bindingNamespace
+			}
+			if ns == namespace && s.Name == serviceAccount {
+				return "", true
+			}
+		case rbacv1.UserKind:
+			if s.Name == ServiceAccountUser(namespace, serviceAccount) {
+				return "", true
+			}
+		case rbacv1.GroupKind:
+			if s.Name == NamespaceServiceAccountsGroup(namespace) {
+				return "Group " + s.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// findings returns the findings of the rules a binding grants, in namespace or in all of them when empty.
+func (a *analyzer) findings(namespace, binding, subject string, ref *rbacv1.RoleRef) []Finding {
+	var findings []Finding
+	head := []string{binding}
+	if subject != "" {
+		head = []string{subject, binding}
+	}
+	for _, r := range a.expandRoleRef(namespace, ref) {
+		for i := range r.rules {
+			permissions := dangerousPermissionsOf(&r.rules[i])
+			if len(permissions) == 0 {
+				continue
+			}
+			chain := append(append([]string{}, head...), r.chain...)
+			findings = append(findings, Finding{Namespace: namespace, Permissions: permissions, Rule: ruleString(&r.rules[i]), Chain: chain})
+		}
+	}
+	return findings
+}
+
+// Analyze returns the rules of the roles bound to the service account serviceAccount of namespace in list granting
+// dangerous permissions: the ones bound by RoleBindings first, then by ClusterRoleBindings.
+func Analyze(list *autodiscover.RBACList, namespace, serviceAccount string) []Finding {
+	a := analyzer{list: list, clusterRoles: map[string]*rbacv1.ClusterRole{}}
+	for i := range list.ClusterRoles {
+		a.clusterRoles[list.ClusterRoles[i].Name] = &list.ClusterRoles[i]
+	}
+	var findings []Finding
+	for i := range list.RoleBindings {
+		b := &list.RoleBindings[i]
+		if subject, ok := boundSubject(b.Subjects, b.Namespace, namespace, serviceAccount); ok {
+			binding := fmt.Sprintf("RoleBinding %s/%s", b.Namespace, b.Name)
+			findings = append(findings, a.findings(b.Namespace, binding, subject, &b.RoleRef)...)
+		}
+	}
+	for i := range list.ClusterRoleBindings {
+		b := &list.ClusterRoleBindings[i]
+		if subject, ok := boundSubject(b.Subjects, "", namespace, serviceAccount); ok {
+			findings = append(findings, a.findings("", "ClusterRoleBinding "+b.Name, subject, &b.RoleRef)...)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rule(groups, resources, verbs []string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{APIGroups: groups, Resources: resources, Verbs: verbs}
+}
+
+func TestDangerousPermissionsOf(t *testing.T) {
+	core := []string{""}
+	testCases := []struct {
+		rule     rbacv1.PolicyRule
+		expected []string
+	}{
+		{rule: rule(core, []string{"pods", "pods/log"}, []string{"get", "list"})},
+		{rule: rule(core, []string{"pods/exec"}, []string{"create"}), expected: []string{PodsExec}},
+		{rule: rule(core, []string{"pods/*"}, []string{"get"}), expected: []string{PodsExec}},
+		{rule: rule([]string{"apps"}, []string{"pods/exec"}, []string{"create"})},
+		{rule: rule(core, []string{"secrets"}, []string{"list"}), expected: []string{SecretsRead}},
+		{
+			// Reading secrets by name is fine.
+			rule: rbacv1.PolicyRule{APIGroups: core, Resources: []string{"secrets"}, ResourceNames: []string{"cnf-tls"},
+				Verbs: []string{"get"}},
+		},
+		{rule: rule([]string{"rbac.authorization.k8s.io"}, []string{"clusterroles"}, []string{"bind", "escalate"}),
+			expected: []string{Escalate, Bind}},
+		{rule: rule(core, []string{"serviceaccounts"}, []string{"impersonate"}), expected: []string{Impersonate}},
+		{rule: rule(core, []string{"nodes/proxy"}, []string{"get"}), expected: []string{NodesProxy}},
+		{
+			rule:     rule([]string{"*"}, []string{"*"}, []string{"*"}),
+			expected: []string{WildcardVerbs, WildcardResources, PodsExec, SecretsRead, Escalate, Bind, Impersonate, NodesProxy},
+		},
+		// Wildcard verbs on unrelated resources grant neither escalate, bind nor impersonate.
+		{rule: rule([]string{"apps"}, []string{"deployments"}, []string{"*"}), expected: []string{WildcardVerbs}},
+		{rule: rule(core, []string{"configmaps"}, []string{"*"}), expected: []string{WildcardVerbs}},
+		{rule: rule(core, []string{"clusterroles"}, []string{"bind", "escalate"})},
+		{rule: rule([]string{"rbac.authorization.k8s.io"}, []string{"roles"}, []string{"*"}), expected: []string{WildcardVerbs, Escalate, Bind}},
+		{rule: rule(core, []string{"users", "groups"}, []string{"impersonate"}), expected: []string{Impersonate}},
+		{rule: rbacv1.PolicyRule{NonResourceURLs: []string{"*"}, Verbs: []string{"get"}}},
+	}
+	for i := range testCases {
+		assert.Equal(t, testCases[i].expected, dangerousPermissionsOf(&testCases[i].rule), testCases[i].rule.String())
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	list := &autodiscover.RBACList{
+		Roles: []rbacv1.Role{
+			{ObjectMeta: metav1.ObjectMeta{Name: "secrets", Namespace: "tnf"},
+				Rules: []rbacv1.PolicyRule{rule([]string{""}, []string{"secrets"}, []string{"get"}), rule([]string{""}, []string{"pods"}, []string{"get"})}},
+		},
+		RoleBindings: []rbacv1.RoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "secrets", Namespace: "tnf"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "cnf"}},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "secrets"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "secrets", Namespace: "other"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "cnf"}},
+				RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "secrets"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "tnf"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:tnf"}},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			},
+		},
+		ClusterRoles: []rbacv1.ClusterRole{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+				AggregationRule: &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"aggregate-to-admin": "true"}},
+				}},
+				// The rules the aggregation controller copied are not looked at.
+				Rules: []rbacv1.PolicyRule{rule([]string{""}, []string{"pods/exec"}, []string{"create"})},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "aggregate-to-admin", Labels: map[string]string{"aggregate-to-admin": "true"}},
+				Rules:      []rbacv1.PolicyRule{rule([]string{""}, []string{"pods/exec"}, []string{"create"})},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+				Rules:      []rbacv1.PolicyRule{rule([]string{"*"}, []string{"*"}, []string{"*"})},
+			},
+		},
+		ClusterRoleBindings: []rbacv1.ClusterRoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnf-cluster-admin"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "system:serviceaccount:tnf:cnf"}},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			},
+			{
+				// The group of all the service accounts is not controlled by the CNF.
+				ObjectMeta: metav1.ObjectMeta{Name: "all"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts"}},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			},
+		},
+	}
+
+	findings := Analyze(list, "tnf", "cnf")
+	assert.Equal(t, []Finding{
+		{
+			Namespace:   "tnf",
+			Permissions: []string{SecretsRead},
+			Rule:        `verbs=[get] apiGroups=[""] resources=[secrets]`,
+			Chain:       []string{"RoleBinding tnf/secrets", "Role tnf/secrets"},
+		},
+		{
+			Namespace:   "tnf",
+			Permissions: []string{PodsExec},
+			Rule:        `verbs=[create] apiGroups=[""] resources=[pods/exec]`,
+			Chain: []string{"Group system:serviceaccounts:tnf", "RoleBinding tnf/admin", "ClusterRole admin",
+				"ClusterRole aggregate-to-admin"},
+		},
+		{
+			Permissions: []string{WildcardVerbs, WildcardResources, PodsExec, SecretsRead, Escalate, Bind, Impersonate, NodesProxy},
+			Rule:        `verbs=[*] apiGroups=["*"] resources=[*]`,
+			Chain:       []string{"ClusterRoleBinding cnf-cluster-admin", "ClusterRole cluster-admin"},
+		},
+	}, findings)
+	assert.Equal(t, `secrets read in namespace tnf by RoleBinding tnf/secrets > Role tnf/secrets (verbs=[get] apiGroups=[""] resources=[secrets])`,
+		findings[0].String())
+	// The other service accounts of the namespace only get the permissions of its group.
+	assert.Equal(t, findings[1:2], Analyze(list, "tnf", "default"))
+	assert.Empty(t, Analyze(list, "another", "cnf"))
+}
+
+func TestAnalyzeAggregationLoop(t *testing.T) {
+	selector := func(label string) *rbacv1.AggregationRule {
+		return &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{label: "true"}}}}
+	}
+	list := &autodiscover.RBACList{
+		ClusterRoles: []rbacv1.ClusterRole{
+			{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"b": "true"}}, AggregationRule: selector("a")},
+			{ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: map[string]string{"a": "true"}}, AggregationRule: selector("b")},
+		},
+		ClusterRoleBindings: []rbacv1.ClusterRoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "cnf", Namespace: "tnf"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "a"},
+		}},
+	}
+	assert.Empty(t, Analyze(list, "tnf", "cnf"))
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+// Test states, as recorded in the claim.
+const (
+	StatePassed  = "passed"
+	StateFailed  = "failed"
+	StateSkipped = "skipped"
+	StatePending = "pending"
+	// StateUnknown is reported for a test without any result.
+	StateUnknown = "unknown"
+)
+
+// Test is the outcome of one test case, with its catalog entry.
+type Test struct {
+	// ID is the key of the test in the claim results, e.g. "lifecycle-lifecycle-pod-owner-type".
+	ID    string
+	Suite string
+	State string
+	// FailureReason is the failure reason of the last failed result.
+	FailureReason string
+	// Identifier is the claim identifier of the test, nil when the claim does not hold it.
+	Identifier *claim.Identifier
+	// Description, Remediation, Type and BestPracticeReference come from identifiers.Catalog, when the test is
+	// found there.
+	Description           string
+	Remediation           string
+	Type                  string
+	BestPracticeReference string
+	// Failures lists the objects that were not compliant, or could not be checked.
+	Failures []tnf.CheckedObject
+	// Waived lists the objects that were not compliant, but whose failure is covered by a waiver.
+	Waived []tnf.CheckedObject
+}
+
+// Failed returns whether the test neither passed nor was skipped.
+func (t *Test) Failed() bool {
+	switch t.State {
+	case StatePassed, StateSkipped, StatePending:
+		return false
+	}
+	return true
+}
+
+// Suite is the tests of one test suite, with a summary of their states.
+type Suite struct {
+	Name    string
+	Passed  int
+	Failed  int
+	Skipped int
+	Tests   []*Test
+}
+
+// Report is the content of a claim that the reports are generated from.
+type Report struct {
+	// Source names the claim file, it is used as the location of the SARIF results.
+	Source    string
+	StartTime string
+	EndTime   string
+	Versions  claim.Versions
+	Suites    []*Suite
+}
+
+// result holds the fields of a claim result used in the reports.
+type result struct {
+	State          string              `json:"state"`
+	FailureReason  string              `json:"failureReason"`
+	TestID         *claim.Identifier   `json:"testID"`
+	CheckedObjects []tnf.CheckedObject `json:"checkedObjects"`
+}
+
+// New builds the report of the claim read from source.
+func New(c *claim.Claim, source string) (*Report, error) {
+	r := &Report{Source: source}
+	if c.Metadata != nil {
+		r.StartTime = c.Metadata.StartTime
+		r.EndTime = c.Metadata.EndTime
+	}
+	if c.Versions != nil {
+		r.Versions = *c.Versions
+	}
+
+	ids := make([]string, 0, len(c.Results))
+	for id := range c.Results {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	suites := map[string]*Suite{}
+	for _, id := range ids {
+		// The results are kept untyped by the claim package, so they are decoded again into the fields used here.
+		payload, err := json.Marshal(c.Results[id])
+		if err != nil {
+			return nil, err
+		}
+		var results []result
+		if err := json.Unmarshal(payload, &results); err != nil {
+			return nil, err
+		}
+		test := newTest(id, results)
+		suite, ok := suites[test.Suite]
+		if !ok {
+			suite = &Suite{Name: test.Suite}
+			suites[test.Suite] = suite
+			r.Suites = append(r.Suites, suite)
+		}
+		suite.Tests = append(suite.Tests, test)
+		switch {
+		case test.State == StatePassed:
+			suite.Passed++
+		case test.Failed():
+			suite.Failed++
+		default:
+			suite.Skipped++
+		}
+	}
+	sort.Slice(r.Suites, func(i, j int) bool { return r.Suites[i].Name < r.Suites[j].Name })
+	return r, nil
+}
+
+// newTest merges the results of a test: the last state wins, as in the claim results reconciliation.
+func newTest(id string, results []result) *Test {
+	test := &Test{ID: id, State: StateUnknown}
+	for i := range results {
+		res := &results[i]
+		if res.State != "" {
+			test.State = res.State
+		}
+		if res.FailureReason != "" {
+			test.FailureReason = res.FailureReason
+		}
+		if res.TestID != nil {
+			test.Identifier = res.TestID
+		}
+		for _, o := range res.CheckedObjects {
+			switch {
+			case o.Status == tnf.Waived:
+				test.Waived = append(test.Waived, o)
+			case o.Status.Failed():
+				test.Failures = append(test.Failures, o)
+			}
+		}
+	}
+	if test.Identifier == nil {
+		test.Identifier = catalogIdentifier(id)
+	}
+	if test.Identifier != nil {
+		if parts := identifiers.GetSuiteAndTestFromIdentifier(*test.Identifier); len(parts) > 0 {
+			test.Suite = parts[0]
+		}
+		if description, ok := identifiers.Catalog[*test.Identifier]; ok {
+			test.Description = description.Description
+			test.Remediation = description.Remediation
+			test.Type = description.Type
+			test.BestPracticeReference = description.BestPracticeReference
+		}
+	}
+	if test.Suite == "" {
+		test.Suite = strings.SplitN(id, "-", 2)[0] //nolint:gomnd // suite and test name
+	}
+	return test
+}
+
+// catalogIdentifier looks up the catalog for the test id, for claims whose results do not hold their identifier.
+// The result keys are prefixed with the ginkgo container texts, e.g. "lifecycle-lifecycle-pod-owner-type".
+func catalogIdentifier(id string) *claim.Identifier {
+	for identifier := range identifiers.Catalog {
+		if itID := identifiers.XformToGinkgoItIdentifier(identifier); id == itID || strings.HasSuffix(id, "-"+itID) {
+			found := identifier
+			return &found
+		}
+	}
+	return nil
+}
+
+// Failed returns the number of failed tests of all the suites.
+func (r *Report) Failed() int {
+	failed := 0
+	for _, s := range r.Suites {
+		failed += s.Failed
+	}
+	return failed
+}
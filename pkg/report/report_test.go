@@ -0,0 +1,97 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+const (
+	testDataPath = "testdata"
+)
+
+func loadReport(t *testing.T) *Report {
+	contents, err := os.ReadFile(path.Join(testDataPath, "claim.json"))
+	assert.Nil(t, err)
+	var root claim.Root
+	assert.Nil(t, json.Unmarshal(contents, &root))
+	r, err := New(root.Claim, "claim.json")
+	assert.Nil(t, err)
+	return r
+}
+
+func TestNew(t *testing.T) {
+	r := loadReport(t)
+
+	assert.Equal(t, "v3.3.0", r.Versions.Tnf)
+	assert.Equal(t, 2, r.Failed())
+	if assert.Len(t, r.Suites, 3) {
+		lifecycle := r.Suites[0]
+		assert.Equal(t, "lifecycle", lifecycle.Name)
+		assert.Equal(t, 1, lifecycle.Passed)
+		assert.Equal(t, 1, lifecycle.Failed)
+		assert.Equal(t, []tnf.CheckedObject{
+			{Type: tnf.ContainerObject, Name: "test", Namespace: "tnf", Pod: "test-0", Status: tnf.NonCompliant, Reason: "no preStop hook"},
+			{Type: tnf.ContainerObject, Name: "test", Namespace: "tnf", Pod: "test-2", Status: tnf.CheckError, Reason: "pod not found"},
+		}, lifecycle.Tests[0].Failures)
+		assert.NotEmpty(t, lifecycle.Tests[0].Remediation)
+		assert.Empty(t, lifecycle.Tests[1].Failures)
+		assert.Equal(t, []tnf.CheckedObject{
+			{Type: tnf.PodObject, Name: "standalone", Namespace: "tnf", Status: tnf.Waived, Reason: "not owned by a replica set, waived: singleton by design"},
+		}, lifecycle.Tests[1].Waived)
+
+		// Without an identifier in the results, the test is looked up in the catalog by its key.
+		networking := r.Suites[1]
+		assert.Equal(t, "networking", networking.Name)
+		assert.Equal(t, 1, networking.Skipped)
+		assert.Equal(t, "http://test-network-function.com/testcases/networking/icmpv4-connectivity", networking.Tests[0].Identifier.Url)
+		assert.NotEmpty(t, networking.Tests[0].Description)
+
+		assert.Equal(t, "observability", r.Suites[2].Name)
+		assert.Equal(t, 1, r.Suites[2].Failed)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var out bytes.Buffer
+	assert.Nil(t, loadReport(t).WriteSARIF(&out))
+
+	expected, err := os.ReadFile(path.Join(testDataPath, "report.sarif"))
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), out.String())
+}
+
+func TestWriteHTML(t *testing.T) {
+	var out bytes.Buffer
+	assert.Nil(t, loadReport(t).WriteHTML(&out))
+
+	html := out.String()
+	assert.Contains(t, html, `<a href="#suite-lifecycle">lifecycle</a>`)
+	assert.Contains(t, html, "<li>container tnf/test-0/test: non-compliant (no preStop hook)</li>")
+	assert.Contains(t, html, "2 pods have no preStop hook")
+	assert.Contains(t, html, "<b>Remediation:</b>")
+	assert.Contains(t, html, "<li>pod tnf/standalone: waived (not owned by a replica set, waived: singleton by design)</li>")
+	assert.NotContains(t, html, "<script")
+}
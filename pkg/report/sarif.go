@@ -0,0 +1,211 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "test-network-function"
+	toolURI      = "https://github.com/test-network-function/test-network-function"
+
+	sarifKindPass          = "pass"
+	sarifKindFail          = "fail"
+	sarifKindNotApplicable = "notApplicable"
+	sarifLevelError        = "error"
+	sarifLevelNone         = "none"
+	// sarifSuppressionExternal marks results suppressed outside of the scanned artifact, i.e. by a waiver.
+	sarifSuppressionExternal = "external"
+)
+
+// The SARIF 2.1.0 objects below only hold the properties filled from a claim.
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string          `json:"id"`
+	ShortDescription sarifMessage    `json:"shortDescription"`
+	FullDescription  *sarifMessage   `json:"fullDescription,omitempty"`
+	Help             *sarifMessage   `json:"help,omitempty"`
+	HelpURI          string          `json:"helpUri,omitempty"`
+	Properties       sarifProperties `json:"properties"`
+}
+
+type sarifProperties struct {
+	Tags []string `json:"tags"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Kind         string             `json:"kind"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes the report as a SARIF log, with a rule per test and a result per test, or per object that
+// failed the test when the test recorded them so that each of them can be tracked on its own. The failures covered
+// by a waiver are reported as suppressed results.
+func (r *Report) WriteSARIF(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			InformationURI: toolURI,
+			Version:        r.Versions.Tnf,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for _, suite := range r.Suites {
+		for _, test := range suite.Tests {
+			ruleIndex := len(run.Tool.Driver.Rules)
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSarifRule(test))
+			run.Results = append(run.Results, r.sarifResults(test, ruleIndex)...)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+func newSarifRule(test *Test) sarifRule {
+	rule := sarifRule{
+		ID:               test.ID,
+		ShortDescription: sarifMessage{Text: test.ID},
+		Properties:       sarifProperties{Tags: []string{test.Suite}},
+	}
+	if test.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: test.Description}
+	}
+	if test.Remediation != "" {
+		rule.Help = &sarifMessage{Text: test.Remediation}
+	}
+	if test.Identifier != nil {
+		rule.HelpURI = test.Identifier.Url
+	}
+	if test.Type != "" {
+		rule.Properties.Tags = append(rule.Properties.Tags, test.Type)
+	}
+	return rule
+}
+
+func (r *Report) sarifResults(test *Test, ruleIndex int) []sarifResult {
+	newResult := func(kind, level, message string) sarifResult {
+		res := sarifResult{RuleID: test.ID, RuleIndex: ruleIndex, Kind: kind, Level: level, Message: sarifMessage{Text: message}}
+		if r.Source != "" {
+			res.Locations = []sarifLocation{{
+				PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: r.Source}},
+			}}
+		}
+		return res
+	}
+
+	newObjectResult := func(o *tnf.CheckedObject) sarifResult {
+		res := newResult(sarifKindFail, sarifLevelError, fmt.Sprintf("%s failed for %s", test.ID, o))
+		location := sarifLocation{LogicalLocations: []sarifLogicalLocation{{Name: o.Name, FullyQualifiedName: o.ID(), Kind: string(o.Type)}}}
+		if len(res.Locations) > 0 {
+			res.Locations[0].LogicalLocations = location.LogicalLocations
+		} else {
+			res.Locations = []sarifLocation{location}
+		}
+		return res
+	}
+
+	var results []sarifResult
+	switch {
+	case test.State == StatePassed && len(test.Waived) == 0:
+		return []sarifResult{newResult(sarifKindPass, sarifLevelNone, fmt.Sprintf("%s passed", test.ID))}
+	case test.State == StatePassed:
+	case !test.Failed():
+		return []sarifResult{newResult(sarifKindNotApplicable, sarifLevelNone, fmt.Sprintf("%s was %s", test.ID, test.State))}
+	case len(test.Failures) == 0:
+		message := fmt.Sprintf("%s %s", test.ID, test.State)
+		if test.FailureReason != "" {
+			message += ": " + strings.TrimSpace(test.FailureReason)
+		}
+		results = append(results, newResult(sarifKindFail, sarifLevelError, message))
+	default:
+		for i := range test.Failures {
+			results = append(results, newObjectResult(&test.Failures[i]))
+		}
+	}
+	for i := range test.Waived {
+		res := newObjectResult(&test.Waived[i])
+		res.Suppressions = []sarifSuppression{{Kind: sarifSuppressionExternal, Justification: test.Waived[i].Reason}}
+		results = append(results, res)
+	}
+	return results
+}
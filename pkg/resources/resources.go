@@ -0,0 +1,156 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// exclusiveCPUAnnotations are the CRI-O annotations of the pods whose containers run on exclusive CPUs.
+var exclusiveCPUAnnotations = []string{
+	"cpu-load-balancing.crio.io",
+	"cpu-quota.crio.io",
+	"irq-load-balancing.crio.io",
+}
+
+// exclusiveCPUAnnotationValue is the value of the exclusive CPU annotations that removes the CPUs from the shared pool.
+const exclusiveCPUAnnotationValue = "disable"
+
+const millicoresPerCPU = 1000
+
+// qosResources are the resources the QoS class of a pod depends on.
+var qosResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// Finding is something noteworthy about the resources of a pod or of one of its containers.
+type Finding struct {
+	// Container is the container the finding is about, empty when it is about the pod.
+	Container string `json:"container,omitempty"`
+	// Detail tells what was found.
+	Detail string `json:"detail"`
+	// Failed tells whether the finding fails the check. Missing limits are only reported.
+	Failed bool `json:"failed"`
+}
+
+// QOSClass returns the QoS class Kubernetes gave the pod, from its status. When the status has none, the class is
+// computed from the spec: Guaranteed when all its containers have CPU and memory limits equal to their requests,
+// BestEffort when none has CPU or memory requests or limits, Burstable otherwise.
+func QOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+	guaranteed, bestEffort := true, true
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for i := range containers {
+		r := &containers[i].Resources
+		for _, name := range qosResources {
+			request, hasRequest := r.Requests[name]
+			limit, hasLimit := r.Limits[name]
+			if (hasRequest && !request.IsZero()) || (hasLimit && !limit.IsZero()) {
+				bestEffort = false
+			}
+			if !hasLimit || (hasRequest && request.Cmp(limit) != 0) {
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case bestEffort:
+		return corev1.PodQOSBestEffort
+	case guaranteed:
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+// NeedsGuaranteed returns why the pod must be in the Guaranteed QoS class, if it must: the hugepages its containers
+// request and the annotations giving them exclusive CPUs.
+func NeedsGuaranteed(pod *corev1.Pod) []string {
+	hugepages := map[string]bool{}
+	for i := range pod.Spec.Containers {
+		r := &pod.Spec.Containers[i].Resources
+		for _, list := range []corev1.ResourceList{r.Requests, r.Limits} {
+			for name := range list {
+				if strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix) {
+					hugepages[string(name)] = true
+				}
+			}
+		}
+	}
+	reasons := []string{}
+	for name := range hugepages {
+		reasons = append(reasons, "requests "+name)
+	}
+	sort.Strings(reasons)
+	for _, annotation := range exclusiveCPUAnnotations {
+		if pod.Annotations[annotation] == exclusiveCPUAnnotationValue {
+			reasons = append(reasons, fmt.Sprintf("annotation %s=%s", annotation, exclusiveCPUAnnotationValue))
+		}
+	}
+	return reasons
+}
+
+// Check returns the QoS class of the pod and the findings about its resources: the missing requests, which fail the
+// check, and limits of its containers. The pod must be Guaranteed if NeedsGuaranteed says so, or if mustBeGuaranteed
+// is true for one of its containers. The containers of such a pod, or only the ones mustBeGuaranteed is true for
+// when NeedsGuaranteed does not apply, must request integer CPUs.
+func Check(pod *corev1.Pod, mustBeGuaranteed func(container string) bool) (corev1.PodQOSClass, []Finding) {
+	var findings []Finding
+	podReasons := NeedsGuaranteed(pod)
+	reasons := podReasons
+	var pinned []*corev1.Container
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		for _, name := range qosResources {
+			if _, ok := c.Resources.Requests[name]; !ok {
+				findings = append(findings, Finding{Container: c.Name, Detail: "no requests." + string(name), Failed: true})
+			}
+			if _, ok := c.Resources.Limits[name]; !ok {
+				findings = append(findings, Finding{Container: c.Name, Detail: "no limits." + string(name)})
+			}
+		}
+		if len(podReasons) > 0 || mustBeGuaranteed(c.Name) {
+			pinned = append(pinned, c)
+		}
+		if mustBeGuaranteed(c.Name) {
+			reasons = append(reasons, "configured for container "+c.Name)
+		}
+	}
+	qos := QOSClass(pod)
+	if len(reasons) > 0 && qos != corev1.PodQOSGuaranteed {
+		findings = append(findings, Finding{Detail: fmt.Sprintf("QoS class %s, Guaranteed required: %s", qos, strings.Join(reasons, ", ")), Failed: true})
+	}
+	for _, c := range pinned {
+		if cpu, ok := c.Resources.Requests[corev1.ResourceCPU]; ok && cpu.MilliValue()%millicoresPerCPU != 0 {
+			findings = append(findings, Finding{Container: c.Name, Detail: fmt.Sprintf("requests.cpu %s is not an integer", cpu.String()), Failed: true})
+		}
+	}
+	return qos, findings
+}
+
+// Reason returns a one-line summary of the findings, for the claim, and whether any of them fails the check.
+func Reason(findings []Finding) (reason string, failed bool) {
+	details := make([]string, len(findings))
+	for i := range findings {
+		details[i] = findings[i].Detail
+		failed = failed || findings[i].Failed
+	}
+	return strings.Join(details, "; "), failed
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func resourceList(cpu, memory string) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+// guaranteedPod returns a Guaranteed pod of two containers, "app" requesting 2 CPUs and "sidecar" 100m.
+func guaranteedPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "tnf"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app", Resources: corev1.ResourceRequirements{Requests: resourceList("2", "1Gi"), Limits: resourceList("2", "1Gi")}},
+			{Name: "sidecar", Resources: corev1.ResourceRequirements{Requests: resourceList("100m", "64Mi"), Limits: resourceList("100m", "64Mi")}},
+		}},
+	}
+}
+
+func TestQOSClass(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(pod *corev1.Pod)
+		expected corev1.PodQOSClass
+	}{
+		{name: "guaranteed", modify: func(pod *corev1.Pod) {}, expected: corev1.PodQOSGuaranteed},
+		{
+			name:     "limits only",
+			modify:   func(pod *corev1.Pod) { pod.Spec.Containers[0].Resources.Requests = nil },
+			expected: corev1.PodQOSGuaranteed,
+		},
+		{
+			name:     "requests lower than limits",
+			modify:   func(pod *corev1.Pod) { pod.Spec.Containers[1].Resources.Requests = resourceList("50m", "64Mi") },
+			expected: corev1.PodQOSBurstable,
+		},
+		{
+			name:     "no memory limit",
+			modify:   func(pod *corev1.Pod) { pod.Spec.Containers[1].Resources.Limits = resourceList("100m", "") },
+			expected: corev1.PodQOSBurstable,
+		},
+		{
+			name: "burstable init container",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.InitContainers = []corev1.Container{{Name: "init", Resources: corev1.ResourceRequirements{Requests: resourceList("10m", "")}}}
+			},
+			expected: corev1.PodQOSBurstable,
+		},
+		{
+			name: "no resources",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+				pod.Spec.Containers[1].Resources = corev1.ResourceRequirements{}
+			},
+			expected: corev1.PodQOSBestEffort,
+		},
+		{
+			name: "class of the status",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.Containers[1].Resources.Requests = resourceList("50m", "64Mi")
+				pod.Status.QOSClass = corev1.PodQOSGuaranteed
+			},
+			expected: corev1.PodQOSGuaranteed,
+		},
+	}
+
+	for _, tc := range testCases {
+		pod := guaranteedPod()
+		tc.modify(pod)
+		assert.Equal(t, tc.expected, QOSClass(pod), tc.name)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	configured := func(name string) func(string) bool {
+		return func(container string) bool { return container == name }
+	}
+	none := configured("")
+	testCases := []struct {
+		name             string
+		modify           func(pod *corev1.Pod)
+		mustBeGuaranteed func(string) bool
+		expectedQOS      corev1.PodQOSClass
+		expected         []Finding
+	}{
+		{name: "guaranteed", modify: func(pod *corev1.Pod) {}, mustBeGuaranteed: none, expectedQOS: corev1.PodQOSGuaranteed},
+		{
+			name: "missing requests and limits",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.Containers[1].Resources = corev1.ResourceRequirements{Requests: resourceList("100m", "")}
+			},
+			mustBeGuaranteed: none,
+			expectedQOS:      corev1.PodQOSBurstable,
+			expected: []Finding{
+				{Container: "sidecar", Detail: "no limits.cpu"},
+				{Container: "sidecar", Detail: "no requests.memory", Failed: true},
+				{Container: "sidecar", Detail: "no limits.memory"},
+			},
+		},
+		{
+			name:             "configured container",
+			modify:           func(pod *corev1.Pod) {},
+			mustBeGuaranteed: configured("app"),
+			expectedQOS:      corev1.PodQOSGuaranteed,
+		},
+		{
+			name:             "configured container with fractional CPUs",
+			modify:           func(pod *corev1.Pod) { pod.Spec.Containers[1].Resources.Limits = resourceList("100m", "128Mi") },
+			mustBeGuaranteed: configured("sidecar"),
+			expectedQOS:      corev1.PodQOSBurstable,
+			expected: []Finding{
+				{Detail: "QoS class Burstable, Guaranteed required: configured for container sidecar", Failed: true},
+				{Container: "sidecar", Detail: "requests.cpu 100m is not an integer", Failed: true},
+			},
+		},
+		{
+			name: "hugepages and exclusive CPUs",
+			modify: func(pod *corev1.Pod) {
+				pod.Annotations = map[string]string{"cpu-quota.crio.io": "disable", "cpu-load-balancing.crio.io": "enable"}
+				pod.Spec.Containers[0].Resources.Limits["hugepages-1Gi"] = resource.MustParse("2Gi")
+			},
+			mustBeGuaranteed: none,
+			expectedQOS:      corev1.PodQOSGuaranteed,
+			expected:         []Finding{{Container: "sidecar", Detail: "requests.cpu 100m is not an integer", Failed: true}},
+		},
+		{
+			name: "hugepages in a burstable pod",
+			modify: func(pod *corev1.Pod) {
+				pod.Spec.Containers[0].Resources.Requests = resourceList("2", "512Mi")
+				pod.Spec.Containers[0].Resources.Limits["hugepages-2Mi"] = resource.MustParse("256Mi")
+				pod.Spec.Containers[1].Resources.Requests = resourceList("1", "64Mi")
+				pod.Spec.Containers[1].Resources.Limits = resourceList("1", "64Mi")
+			},
+			mustBeGuaranteed: none,
+			expectedQOS:      corev1.PodQOSBurstable,
+			expected:         []Finding{{Detail: "QoS class Burstable, Guaranteed required: requests hugepages-2Mi", Failed: true}},
+		},
+	}
+
+	for _, tc := range testCases {
+		pod := guaranteedPod()
+		tc.modify(pod)
+		qos, findings := Check(pod, tc.mustBeGuaranteed)
+		assert.Equal(t, tc.expectedQOS, qos, tc.name)
+		assert.Equal(t, tc.expected, findings, tc.name)
+	}
+}
+
+func TestReason(t *testing.T) {
+	reason, failed := Reason(nil)
+	assert.Equal(t, "", reason)
+	assert.False(t, failed)
+	reason, failed = Reason([]Finding{{Detail: "no limits.cpu"}, {Detail: "no limits.memory"}})
+	assert.Equal(t, "no limits.cpu; no limits.memory", reason)
+	assert.False(t, failed)
+	_, failed = Reason([]Finding{{Detail: "no limits.cpu"}, {Detail: "no requests.memory", Failed: true}})
+	assert.True(t, failed)
+}
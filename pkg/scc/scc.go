@@ -0,0 +1,104 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package scc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	"github.com/test-network-function/test-network-function/pkg/rbac"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AdmittedAnnotation is the pod annotation the SCC admission plugin records the SCC a pod was admitted under in.
+	AdmittedAnnotation = "openshift.io/scc"
+
+	defaultServiceAccount = "default"
+)
+
+// privilegedSCCs are the default SCCs granting access to the host or running as any user.
+var privilegedSCCs = map[string]bool{
+	"privileged":       true,
+	"anyuid":           true,
+	"hostaccess":       true,
+	"hostmount-anyuid": true,
+	"hostnetwork":      true,
+	"hostnetwork-v2":   true,
+}
+
+// IsPrivileged returns whether the SCC named name grants privileges a CNF pod should not run with.
+func IsPrivileged(name string) bool {
+	return privilegedSCCs[name]
+}
+
+// Admitted returns the SCC pod was admitted under, empty when it was not admitted by the SCC admission plugin.
+func Admitted(pod *corev1.Pod) string {
+	return pod.Annotations[AdmittedAnnotation]
+}
+
+// ServiceAccount returns the service account pod runs as.
+func ServiceAccount(pod *corev1.Pod) string {
+	if pod.Spec.ServiceAccountName == "" {
+		return defaultServiceAccount
+	}
+	return pod.Spec.ServiceAccountName
+}
+
+// CanUseFunc returns whether RBAC allows user, member of groups, to use the SCC named scc.
+type CanUseFunc func(user string, groups []string, scc string) (bool, error)
+
+// lists returns whether the users or groups of s list user or one of groups.
+func lists(s *autodiscover.SCCResource, user string, groups []string) bool {
+	for _, u := range s.Users {
+		if u == user {
+			return true
+		}
+	}
+	for _, g := range s.Groups {
+		for _, group := range groups {
+			if g == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Reachable returns the names, sorted, of the SCCs among sccs the service account serviceAccount of namespace can
+// use.
+func Reachable(sccs []autodiscover.SCCResource, namespace, serviceAccount string, canUse CanUseFunc) ([]string, error) {
+	user := rbac.ServiceAccountUser(namespace, serviceAccount)
+	groups := rbac.ServiceAccountGroups(namespace)
+	var reachable []string
+	for i := range sccs {
+		s := &sccs[i]
+		allowed := lists(s, user, groups)
+		if !allowed {
+			var err error
+			if allowed, err = canUse(user, groups, s.Metadata.Name); err != nil {
+				return nil, fmt.Errorf("could not check whether %s can use SCC %s: %v", user, s.Metadata.Name, err)
+			}
+		}
+		if allowed {
+			reachable = append(reachable, s.Metadata.Name)
+		}
+	}
+	sort.Strings(reachable)
+	return reachable, nil
+}
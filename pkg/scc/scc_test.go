@@ -0,0 +1,74 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package scc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newSCC(name string, users, groups []string) autodiscover.SCCResource {
+	s := autodiscover.SCCResource{Users: users, Groups: groups}
+	s.Metadata.Name = name
+	return s
+}
+
+func TestPod(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AdmittedAnnotation: "anyuid"}}}
+	assert.Equal(t, "anyuid", Admitted(pod))
+	assert.Equal(t, "default", ServiceAccount(pod))
+	pod.Spec.ServiceAccountName = "cnf"
+	assert.Equal(t, "cnf", ServiceAccount(pod))
+	assert.Equal(t, "", Admitted(&corev1.Pod{}))
+	for _, name := range []string{"privileged", "anyuid", "hostaccess", "hostmount-anyuid", "hostnetwork", "hostnetwork-v2"} {
+		assert.True(t, IsPrivileged(name), name)
+	}
+	assert.False(t, IsPrivileged("nonroot-v2"))
+	assert.False(t, IsPrivileged("restricted"))
+}
+
+func TestReachable(t *testing.T) {
+	sccs := []autodiscover.SCCResource{
+		newSCC("restricted", nil, []string{"system:authenticated"}),
+		newSCC("privileged", []string{"system:admin"}, []string{"system:cluster-admins"}),
+		newSCC("anyuid", []string{"system:serviceaccount:tnf:cnf"}, nil),
+		newSCC("hostnetwork", nil, nil),
+		newSCC("nonroot", nil, []string{"system:serviceaccounts:other"}),
+	}
+	var checked []string
+	canUse := func(user string, groups []string, scc string) (bool, error) {
+		assert.Equal(t, "system:serviceaccount:tnf:cnf", user)
+		assert.Equal(t, []string{"system:serviceaccounts", "system:serviceaccounts:tnf", "system:authenticated"}, groups)
+		checked = append(checked, scc)
+		return scc == "hostnetwork", nil
+	}
+	reachable, err := Reachable(sccs, "tnf", "cnf", canUse)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"anyuid", "hostnetwork", "restricted"}, reachable)
+	// RBAC is only checked for the SCCs not listing the service account.
+	assert.Equal(t, []string{"privileged", "hostnetwork", "nonroot"}, checked)
+
+	_, err = Reachable(sccs, "tnf", "cnf", func(string, []string, string) (bool, error) {
+		return false, errors.New("forbidden")
+	})
+	assert.NotNil(t, err)
+}
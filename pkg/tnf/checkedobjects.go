@@ -0,0 +1,223 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// ComplianceStatus is the outcome of a test for a single checked object.
+type ComplianceStatus string
+
+const (
+	// Compliant means the object passed the check.
+	Compliant ComplianceStatus = "compliant"
+	// NonCompliant means the object failed the check.
+	NonCompliant ComplianceStatus = "non-compliant"
+	// CheckError means the object could not be checked.
+	CheckError ComplianceStatus = "error"
+	// Waived means the object failed the check, but the failure is covered by a waiver.
+	Waived ComplianceStatus = "waived"
+)
+
+// ObjectType is the kind of object checked by a test.
+type ObjectType string
+
+// Types of objects that can be checked.
+const (
+	PodObject            ObjectType = "pod"
+	ContainerObject      ObjectType = "container"
+	NodeObject           ObjectType = "node"
+	OperatorObject       ObjectType = "operator"
+	NamespaceObject      ObjectType = "namespace"
+	IPObject             ObjectType = "ip"
+	PortObject           ObjectType = "port"
+	ServiceAccountObject ObjectType = "serviceaccount"
+	DeploymentObject     ObjectType = "deployment"
+	StatefulSetObject    ObjectType = "statefulset"
+	ImageObject          ObjectType = "image"
+)
+
+// CheckedObject is an object checked by a test, with the outcome of the check. The fields that identify the
+// object depend on its type:
+//   - pod: Namespace and Name
+//   - container: Namespace, Pod and Name
+//   - node: Name
+//   - operator: Namespace and Name (the CSV name)
+//   - namespace: Name
+//   - ip: Network and Name (the address)
+//   - port: Namespace, Pod and Name (the port and protocol, e.g. "8080/TCP")
+//   - serviceaccount: Namespace and Name
+//   - deployment, statefulset: Namespace and Name
+//   - image: Name (the repository and name of the image, e.g. "rhel8/nginx-116")
+type CheckedObject struct {
+	Type      ObjectType       `json:"type"`
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace,omitempty"`
+	Pod       string           `json:"pod,omitempty"`
+	Network   string           `json:"network,omitempty"`
+	Status    ComplianceStatus `json:"status"`
+	Reason    string           `json:"reason,omitempty"`
+}
+
+// ID identifies the object among the objects of its type, e.g. "namespace/pod/container" for a container.
+func (o *CheckedObject) ID() string {
+	switch o.Type {
+	case ContainerObject:
+		return fmt.Sprintf("%s/%s/%s", o.Namespace, o.Pod, o.Name)
+	case PortObject:
+		return fmt.Sprintf("%s/%s:%s", o.Namespace, o.Pod, o.Name)
+	case IPObject:
+		return fmt.Sprintf("%s(%s)", o.Name, o.Network)
+	}
+	if o.Namespace != "" {
+		return fmt.Sprintf("%s/%s", o.Namespace, o.Name)
+	}
+	return o.Name
+}
+
+func (o *CheckedObject) String() string {
+	s := fmt.Sprintf("%s %s: %s", o.Type, o.ID(), o.Status)
+	if o.Reason != "" {
+		s += " (" + o.Reason + ")"
+	}
+	return s
+}
+
+var (
+	// checkedObjects holds the objects recorded by the running test until they are taken by the claim
+	// result recorder. Checks may run in parallel, hence the mutex.
+	checkedObjects      []CheckedObject
+	checkedObjectsMutex sync.Mutex
+)
+
+// WaiverFunc returns the justification of the waiver covering the failure of object o in test testID, if any.
+type WaiverFunc func(testID string, o *CheckedObject) (justification string, waived bool)
+
+var (
+	waiverFunc WaiverFunc
+
+	// currentTestID returns the ID of the running test. It is a variable so that it can be spoofed in unit tests.
+	currentTestID = func() string {
+		return ginkgo.CurrentSpecReport().LeafNodeText
+	}
+)
+
+// SetWaiverFunc sets the function that tells which failures are waived, nil to waive none.
+func SetWaiverFunc(f WaiverFunc) {
+	checkedObjectsMutex.Lock()
+	defer checkedObjectsMutex.Unlock()
+	waiverFunc = f
+}
+
+// WaiverOf returns the justification of the waiver covering the failures of object o in the running test, if any, so
+// that a test can spare the objects it would only disrupt to record a waived failure.
+func WaiverOf(o *CheckedObject) (justification string, waived bool) {
+	checkedObjectsMutex.Lock()
+	defer checkedObjectsMutex.Unlock()
+	if waiverFunc == nil {
+		return "", false
+	}
+	return waiverFunc(currentTestID(), o)
+}
+
+// RecordCheckedObject records the outcome of the running test for a single object, and returns its status. A
+// non-compliant object whose failure is covered by a waiver is recorded, and returned, as Waived so that the test
+// does not fail because of it.
+func RecordCheckedObject(o CheckedObject) ComplianceStatus { //nolint:gocritic // passed by value so callers can use composite literals
+	checkedObjectsMutex.Lock()
+	defer checkedObjectsMutex.Unlock()
+	if o.Status == NonCompliant && waiverFunc != nil {
+		if justification, waived := waiverFunc(currentTestID(), &o); waived {
+			o.Status = Waived
+			if o.Reason != "" {
+				o.Reason += ", "
+			}
+			o.Reason += "waived: " + justification
+		}
+	}
+	checkedObjects = append(checkedObjects, o)
+	return o.Status
+}
+
+// Failed returns whether status fails a test.
+func (s ComplianceStatus) Failed() bool {
+	return s == NonCompliant || s == CheckError
+}
+
+// TakeCheckedObjects returns the objects recorded since the previous call, in the order they were recorded.
+func TakeCheckedObjects() []CheckedObject {
+	checkedObjectsMutex.Lock()
+	defer checkedObjectsMutex.Unlock()
+	objects := checkedObjects
+	checkedObjects = nil
+	return objects
+}
+
+// RecordPod records the outcome of the running test for a pod.
+func RecordPod(namespace, name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: PodObject, Namespace: namespace, Name: name, Status: status, Reason: reason})
+}
+
+// RecordContainer records the outcome of the running test for a container.
+func RecordContainer(namespace, pod, name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: ContainerObject, Namespace: namespace, Pod: pod, Name: name, Status: status, Reason: reason})
+}
+
+// RecordNode records the outcome of the running test for a node.
+func RecordNode(name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: NodeObject, Name: name, Status: status, Reason: reason})
+}
+
+// RecordOperator records the outcome of the running test for an operator, identified by its CSV.
+func RecordOperator(namespace, name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: OperatorObject, Namespace: namespace, Name: name, Status: status, Reason: reason})
+}
+
+// RecordNamespace records the outcome of the running test for a namespace.
+func RecordNamespace(name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: NamespaceObject, Name: name, Status: status, Reason: reason})
+}
+
+// RecordIP records the outcome of the running test for an IP address on the given network.
+func RecordIP(network, ip string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: IPObject, Network: network, Name: ip, Status: status, Reason: reason})
+}
+
+// RecordPort records the outcome of the running test for a port of a pod.
+func RecordPort(namespace, pod string, port int, protocol string, status ComplianceStatus, reason string) ComplianceStatus {
+	name := fmt.Sprintf("%d/%s", port, protocol)
+	return RecordCheckedObject(CheckedObject{Type: PortObject, Namespace: namespace, Pod: pod, Name: name, Status: status, Reason: reason})
+}
+
+// RecordServiceAccount records the outcome of the running test for a service account.
+func RecordServiceAccount(namespace, name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: ServiceAccountObject, Namespace: namespace, Name: name, Status: status, Reason: reason})
+}
+
+// RecordPodSet records the outcome of the running test for a podset, a DeploymentObject or a StatefulSetObject.
+func RecordPodSet(objectType ObjectType, namespace, name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: objectType, Namespace: namespace, Name: name, Status: status, Reason: reason})
+}
+
+// RecordImage records the outcome of the running test for a container image, identified by its repository and name.
+func RecordImage(repository, name string, status ComplianceStatus, reason string) ComplianceStatus {
+	return RecordCheckedObject(CheckedObject{Type: ImageObject, Name: repository + "/" + name, Status: status, Reason: reason})
+}
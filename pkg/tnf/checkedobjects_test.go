@@ -0,0 +1,148 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tnf_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+func TestRecordCheckedObjects(t *testing.T) {
+	assert.Empty(t, tnf.TakeCheckedObjects())
+
+	tnf.RecordPod("ns1", "pod1", tnf.Compliant, "")
+	tnf.RecordContainer("ns1", "pod1", "c1", tnf.NonCompliant, "runs as root")
+	tnf.RecordNode("node1", tnf.CheckError, "debug pod not found")
+	tnf.RecordOperator("ns2", "op.v1", tnf.Compliant, "")
+	tnf.RecordNamespace("ns3", tnf.NonCompliant, "bad prefix")
+	tnf.RecordIP("default", "10.0.0.1", tnf.Compliant, "")
+	tnf.RecordPodSet(tnf.DeploymentObject, "ns1", "dep1", tnf.NonCompliant, "no PodDisruptionBudget")
+	tnf.RecordImage("rhel8", "nginx-116", tnf.CheckError, "catalog unreachable")
+
+	assert.Equal(t, []tnf.CheckedObject{
+		{Type: tnf.PodObject, Namespace: "ns1", Name: "pod1", Status: tnf.Compliant},
+		{Type: tnf.ContainerObject, Namespace: "ns1", Pod: "pod1", Name: "c1", Status: tnf.NonCompliant, Reason: "runs as root"},
+		{Type: tnf.NodeObject, Name: "node1", Status: tnf.CheckError, Reason: "debug pod not found"},
+		{Type: tnf.OperatorObject, Namespace: "ns2", Name: "op.v1", Status: tnf.Compliant},
+		{Type: tnf.NamespaceObject, Name: "ns3", Status: tnf.NonCompliant, Reason: "bad prefix"},
+		{Type: tnf.IPObject, Network: "default", Name: "10.0.0.1", Status: tnf.Compliant},
+		{Type: tnf.DeploymentObject, Namespace: "ns1", Name: "dep1", Status: tnf.NonCompliant, Reason: "no PodDisruptionBudget"},
+		{Type: tnf.ImageObject, Name: "rhel8/nginx-116", Status: tnf.CheckError, Reason: "catalog unreachable"},
+	}, tnf.TakeCheckedObjects())
+	// Taking the objects drains them.
+	assert.Empty(t, tnf.TakeCheckedObjects())
+}
+
+func TestRecordCheckedObjectWaived(t *testing.T) {
+	tnf.SetWaiverFunc(func(testID string, o *tnf.CheckedObject) (string, bool) {
+		return "accepted deviation", o.Name == "pod1"
+	})
+	defer tnf.SetWaiverFunc(nil)
+
+	assert.Equal(t, tnf.Waived, tnf.RecordPod("ns", "pod1", tnf.NonCompliant, "uses hostNetwork"))
+	assert.Equal(t, tnf.NonCompliant, tnf.RecordPod("ns", "pod2", tnf.NonCompliant, "uses hostNetwork"))
+	// Only failures are waived, not errors.
+	assert.Equal(t, tnf.CheckError, tnf.RecordPod("ns", "pod1", tnf.CheckError, "timeout"))
+	assert.Equal(t, tnf.Compliant, tnf.RecordPod("ns", "pod1", tnf.Compliant, ""))
+
+	assert.Equal(t, []tnf.CheckedObject{
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod1", Status: tnf.Waived, Reason: "uses hostNetwork, waived: accepted deviation"},
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod2", Status: tnf.NonCompliant, Reason: "uses hostNetwork"},
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod1", Status: tnf.CheckError, Reason: "timeout"},
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod1", Status: tnf.Compliant},
+	}, tnf.TakeCheckedObjects())
+}
+
+func TestWaiverOf(t *testing.T) {
+	deployment := &tnf.CheckedObject{Type: tnf.DeploymentObject, Namespace: "ns", Name: "test"}
+	_, waived := tnf.WaiverOf(deployment)
+	assert.False(t, waived)
+
+	tnf.SetWaiverFunc(func(testID string, o *tnf.CheckedObject) (string, bool) {
+		return "accepted deviation", o.Name == "test"
+	})
+	defer tnf.SetWaiverFunc(nil)
+	justification, waived := tnf.WaiverOf(deployment)
+	assert.True(t, waived)
+	assert.Equal(t, "accepted deviation", justification)
+	_, waived = tnf.WaiverOf(&tnf.CheckedObject{Type: tnf.DeploymentObject, Namespace: "ns", Name: "test-2"})
+	assert.False(t, waived)
+	assert.Empty(t, tnf.TakeCheckedObjects())
+}
+
+func TestComplianceStatusFailed(t *testing.T) {
+	assert.False(t, tnf.Compliant.Failed())
+	assert.True(t, tnf.NonCompliant.Failed())
+	assert.True(t, tnf.CheckError.Failed())
+	assert.False(t, tnf.Waived.Failed())
+}
+
+func TestRecordCheckedObjectsConcurrently(t *testing.T) {
+	const count = 50
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tnf.RecordNode("node", tnf.Compliant, "")
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, tnf.TakeCheckedObjects(), count)
+}
+
+func TestCheckedObjectString(t *testing.T) {
+	testCases := []struct {
+		object   tnf.CheckedObject
+		expected string
+	}{
+		{
+			object:   tnf.CheckedObject{Type: tnf.PodObject, Namespace: "ns", Name: "p", Status: tnf.NonCompliant, Reason: "no pre-stop"},
+			expected: "pod ns/p: non-compliant (no pre-stop)",
+		},
+		{
+			object:   tnf.CheckedObject{Type: tnf.ContainerObject, Namespace: "ns", Pod: "p", Name: "c", Status: tnf.Compliant},
+			expected: "container ns/p/c: compliant",
+		},
+		{
+			object:   tnf.CheckedObject{Type: tnf.NodeObject, Name: "n", Status: tnf.CheckError, Reason: "timeout"},
+			expected: "node n: error (timeout)",
+		},
+		{
+			object:   tnf.CheckedObject{Type: tnf.IPObject, Network: "net1", Name: "10.0.0.1", Status: tnf.Compliant},
+			expected: "ip 10.0.0.1(net1): compliant",
+		},
+		{
+			object:   tnf.CheckedObject{Type: tnf.PortObject, Namespace: "ns", Pod: "p", Name: "8080/TCP", Status: tnf.NonCompliant, Reason: "connection refused"},
+			expected: "port ns/p:8080/TCP: non-compliant (connection refused)",
+		},
+		{
+			object:   tnf.CheckedObject{Type: tnf.ServiceAccountObject, Namespace: "ns", Name: "sa", Status: tnf.Compliant},
+			expected: "serviceaccount ns/sa: compliant",
+		},
+		{
+			object:   tnf.CheckedObject{Type: tnf.StatefulSetObject, Namespace: "ns", Name: "sts", Status: tnf.NonCompliant, Reason: "no PodDisruptionBudget selects the pods"},
+			expected: "statefulset ns/sts: non-compliant (no PodDisruptionBudget selects the pods)",
+		},
+	}
+	for i := range testCases {
+		assert.Equal(t, testCases[i].expected, testCases[i].object.String())
+	}
+}
@@ -59,6 +59,9 @@ const (
 	// PingBinaryName is the name of the Unix `ping` command.
 	PingBinaryName = "ping"
 
+	// NcBinaryName is the name of the `nc` (ncat) command.
+	NcBinaryName = "nc"
+
 	// XargsBinaryName is the name of the Unix `xargs` command.
 	XargsBinaryName = "xargs"
 
@@ -70,4 +73,7 @@ const (
 
 	// WcBinaryName is the name of the Unix `wc` command
 	WcBinaryName = "wc"
+
+	// KillBinaryName is the name of the Unix `kill` command
+	KillBinaryName = "kill"
 )
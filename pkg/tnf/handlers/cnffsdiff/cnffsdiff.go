@@ -19,6 +19,7 @@ package cnffsdiff
 import (
 	"time"
 
+	"github.com/test-network-function/test-network-function/pkg/containerruntime"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
@@ -40,7 +41,7 @@ const (
 	usrbin                = `(?m)[\t|\s]\/usr\/bin[.]*`
 	usrsbin               = `(?m)[\t|\s]\/usr\/sbin[.]*`
 	usrlib                = `(?m)[\t|\s]\/usr\/lib[.]*`
-	successfulOutputRegex = `(?m){}`
+	successfulOutputRegex = `(?m)` + containerruntime.NoChanges
 	acceptAllRegex        = `(?m)(.|\n)+`
 )
 
@@ -94,17 +95,13 @@ func (p *CnfFsDiff) ReelTimeout() *reel.Step {
 func (p *CnfFsDiff) ReelEOF() {
 }
 
-// Command returns command line args for checking the fs difference between a container and it's image
-func Command(containerID string) []string {
-	return []string{"chroot", "/host", "podman", "diff", "--format", "json", containerID}
-}
-
-// NewFsDiff creates a new `FsDiff` test which checks the fs difference between a container and it's image
-func NewFsDiff(timeout time.Duration, containerID, nodeName string) *CnfFsDiff {
+// NewFsDiff creates a new `FsDiff` test which checks the fs difference between a container and it's image, with the
+// diff command of the container runtime.
+func NewFsDiff(timeout time.Duration, containerID string, runtime containerruntime.Runtime) *CnfFsDiff {
 	return &CnfFsDiff{
 		result:  tnf.SUCCESS,
 		timeout: timeout,
-		args:    Command(containerID),
+		args:    runtime.FsDiffCommand(containerID),
 	}
 }
 
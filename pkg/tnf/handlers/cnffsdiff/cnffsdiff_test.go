@@ -0,0 +1,71 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package cnffsdiff_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/containerruntime"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/cnffsdiff"
+)
+
+const testTimeoutDuration = time.Second * 2
+
+func TestNewFsDiff(t *testing.T) {
+	for _, name := range containerruntime.Names() {
+		rt, err := containerruntime.Get(name)
+		assert.Nil(t, err)
+		fsDiff := cnffsdiff.NewFsDiff(testTimeoutDuration, "abc", rt)
+		assert.Equal(t, rt.FsDiffCommand("abc"), fsDiff.Args())
+		assert.Equal(t, testTimeoutDuration, fsDiff.Timeout())
+	}
+}
+
+// TestReelMatch feeds the output of the diff commands to the handler the way reel does: the first regular expression
+// matching the output is passed to ReelMatch.
+func TestReelMatch(t *testing.T) {
+	testCases := []struct {
+		output         string
+		expectedResult int
+	}{
+		{output: "{}", expectedResult: tnf.SUCCESS},
+		{output: " /etc\n /etc/hosts\n{}", expectedResult: tnf.SUCCESS},
+		{output: " /var\n /var/lib\n /var/lib/rpm\n{}", expectedResult: tnf.FAILURE},
+		{output: " /usr/bin/curl\n{}", expectedResult: tnf.FAILURE},
+		{output: `{"changed":["/etc"]}`, expectedResult: tnf.SUCCESS},
+	}
+	rt, err := containerruntime.Get(containerruntime.Containerd)
+	assert.Nil(t, err)
+	for _, tc := range testCases {
+		fsDiff := cnffsdiff.NewFsDiff(testTimeoutDuration, "abc", rt)
+		matched := false
+		for _, pattern := range fsDiff.ReelFirst().Expect {
+			if match := regexp.MustCompile(pattern).FindString(tc.output); match != "" {
+				assert.Nil(t, fsDiff.ReelMatch(pattern, "", match))
+				matched = true
+				break
+			}
+		}
+		assert.True(t, matched, strings.TrimSpace(tc.output))
+		assert.Equal(t, tc.expectedResult, fsDiff.Result())
+	}
+}
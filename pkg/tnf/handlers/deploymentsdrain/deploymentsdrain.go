@@ -17,6 +17,8 @@
 package deploymentsdrain
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -26,19 +28,30 @@ import (
 )
 
 const (
-	ddRegex                = "SUCCESS"
+	ddRegex = "SUCCESS"
+	// pdbBlockedRegex matches the error `oc adm drain` retries on when evicting a pod would violate its
+	// PodDisruptionBudget.
+	pdbBlockedRegex        = "Cannot evict pod as it would violate the pod's disruption budget"
 	drainTimeoutPercentage = 90 // drain timeout is a percentage of test timeout
 )
 
+// evictionErrorRe captures the name and namespace of the pod of an eviction error.
+var evictionErrorRe = regexp.MustCompile(`error when evicting pods/"([^"]+)" -n "([^"]+)"`)
+
 // DeploymentsDrain holds information derived from running "oc adm drain" on the command line.
 type DeploymentsDrain struct {
 	result  int
 	timeout time.Duration
 	args    []string
 	node    string
+	// pdbBlockedPods are the pods, as namespace/name, whose eviction was refused because of their
+	// PodDisruptionBudget.
+	pdbBlockedPods []string
 }
 
-// NewDeploymentsDrain creates a new DeploymentsDrain tnf.Test.
+// NewDeploymentsDrain creates a new DeploymentsDrain tnf.Test.  The pods are evicted, so that the drain honors their
+// PodDisruptionBudgets as a cluster upgrade does.  The command always exits successfully, for its output to tell
+// whether the drain succeeded or was blocked by a PodDisruptionBudget.
 func NewDeploymentsDrain(timeout time.Duration, nodeName string) *DeploymentsDrain {
 	drainTimeout := timeout * drainTimeoutPercentage / 100
 	drainTimeoutString := drainTimeout.String()
@@ -46,9 +59,9 @@ func NewDeploymentsDrain(timeout time.Duration, nodeName string) *DeploymentsDra
 		timeout: timeout,
 		result:  tnf.ERROR,
 		args: []string{
-			"oc", "adm", "drain", nodeName, "--pod-selector=pod-template-hash", "--disable-eviction=true",
+			"oc", "adm", "drain", nodeName, "--pod-selector=pod-template-hash",
 			"--delete-emptydir-data=true", "--ignore-daemonsets=true", "--timeout=" + drainTimeoutString,
-			"&&", "echo", "SUCCESS",
+			"&&", "echo", "SUCCESS", "||", "true",
 		},
 		node: nodeName,
 	}
@@ -74,16 +87,35 @@ func (dd *DeploymentsDrain) Result() int {
 	return dd.result
 }
 
+// GetPDBBlockedPods returns the pods, as namespace/name, whose eviction was refused because of their
+// PodDisruptionBudget.  The drain failed if it was not successful in the end.
+func (dd *DeploymentsDrain) GetPDBBlockedPods() []string {
+	return dd.pdbBlockedPods
+}
+
 // ReelFirst returns a step which expects the output within the test timeout.
 func (dd *DeploymentsDrain) ReelFirst() *reel.Step {
 	return &reel.Step{
-		Expect:  []string{ddRegex},
+		Expect:  []string{ddRegex, pdbBlockedRegex},
 		Timeout: dd.timeout,
 	}
 }
 
-// ReelMatch sets result
-func (dd *DeploymentsDrain) ReelMatch(_, _, _ string) *reel.Step {
+// ReelMatch sets result.  A drain that was blocked by a PodDisruptionBudget fails, and the pods it could not evict
+// are recorded.
+func (dd *DeploymentsDrain) ReelMatch(pattern, before, match string) *reel.Step {
+	if pattern == pdbBlockedRegex {
+		dd.result = tnf.FAILURE
+		seen := map[string]bool{}
+		for _, m := range evictionErrorRe.FindAllStringSubmatch(before+match, -1) {
+			pod := fmt.Sprintf("%s/%s", m[2], m[1])
+			if !seen[pod] {
+				seen[pod] = true
+				dd.pdbBlockedPods = append(dd.pdbBlockedPods, pod)
+			}
+		}
+		return nil
+	}
 	dd.result = tnf.SUCCESS
 	return nil
 }
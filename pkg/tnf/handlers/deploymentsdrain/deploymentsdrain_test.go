@@ -59,6 +59,26 @@ func Test_ReelMatchSuccess(t *testing.T) {
 	assert.Equal(t, tnf.SUCCESS, newDd.Result())
 }
 
+func Test_ReelFirstPDBBlocked(t *testing.T) {
+	newDd := dd.NewDeploymentsDrain(testTimeoutDuration, testNode)
+	assert.NotNil(t, newDd)
+	firstStep := newDd.ReelFirst()
+	assert.Len(t, regexp.MustCompile(firstStep.Expect[0]).FindStringSubmatch(testInputPDBBlocked), 0)
+	assert.Len(t, regexp.MustCompile(firstStep.Expect[1]).FindStringSubmatch(testInputPDBBlocked), 1)
+	assert.Len(t, regexp.MustCompile(firstStep.Expect[1]).FindStringSubmatch(testInputError), 0)
+}
+
+func Test_ReelMatchPDBBlocked(t *testing.T) {
+	newDd := dd.NewDeploymentsDrain(testTimeoutDuration, testNode)
+	assert.NotNil(t, newDd)
+	pattern := newDd.ReelFirst().Expect[1]
+	loc := regexp.MustCompile(pattern).FindStringIndex(testInputPDBBlocked)
+	step := newDd.ReelMatch(pattern, testInputPDBBlocked[:loc[0]], testInputPDBBlocked[loc[0]:])
+	assert.Nil(t, step)
+	assert.Equal(t, tnf.FAILURE, newDd.Result())
+	assert.Equal(t, []string{"tnf/test-7959744869-jtt7j", "tnf/test-7959744869-ths9l"}, newDd.GetPDBBlockedPods())
+}
+
 // Just ensure there are no panics.
 func Test_ReelEof(t *testing.T) {
 	newDd := dd.NewDeploymentsDrain(testTimeoutDuration, testNode)
@@ -168,4 +188,15 @@ const (
 	error: timed out waiting for the condition
 	SUCCESS
 	`
+	testInputPDBBlocked = `node/worker-0-0 cordoned
+	evicting pod tnf/test-7959744869-jtt7j
+	evicting pod tnf/test-7959744869-ths9l
+	error when evicting pods/"test-7959744869-jtt7j" -n "tnf" (will retry after 5s): Cannot evict pod as it would violate the pod's disruption budget.
+	error when evicting pods/"test-7959744869-ths9l" -n "tnf" (will retry after 5s): Cannot evict pod as it would violate the pod's disruption budget.
+	error when evicting pods/"test-7959744869-jtt7j" -n "tnf" (will retry after 5s): Cannot evict pod as it would violate the pod's disruption budget.
+	There are pending pods in node "worker-0-0" when an error occurred: [error when evicting pods/"test-7959744869-jtt7j" -n "tnf": global timeout reached: 4m30s]
+	error: unable to drain node "worker-0-0" due to error:[error when evicting pods/"test-7959744869-jtt7j" -n "tnf": global timeout reached: 4m30s], continuing command...
+	There are pending nodes to be drained:
+	 worker-0-0
+	`
 )
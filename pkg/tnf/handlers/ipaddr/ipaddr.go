@@ -36,6 +36,8 @@ type IPAddr struct {
 	args    []string
 	// The ipv4 address for a given device if the Handler matches.
 	ipv4Address string
+	// The global ipv6 address for a given device if the Handler matches.
+	ipv6Address string
 }
 
 const (
@@ -43,6 +45,9 @@ const (
 	DeviceDoesNotExistRegex = `(?m)Device \"(\w+)\" does not exist.$`
 	// SuccessfulOutputRegex matches `ip addr` output for a given device, and provides grouping to extract the associated Ipv4 address.
 	SuccessfulOutputRegex = `(?m)^\s+inet ((25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?))`
+	// SuccessfulIPv6OutputRegex matches `ip addr` output for a given device with a global Ipv6 address, and provides
+	// grouping to extract it. Link local addresses are ignored, they cannot be used to reach other pods.
+	SuccessfulIPv6OutputRegex = `(?m)^\s+inet6 ([0-9a-fA-F:]+)/\d+ scope global`
 )
 
 var (
@@ -73,12 +78,13 @@ func (i *IPAddr) Result() int {
 // ReelFirst returns a step which expects an ip summary for the given device.
 func (i *IPAddr) ReelFirst() *reel.Step {
 	return &reel.Step{
-		Expect:  []string{SuccessfulOutputRegex, DeviceDoesNotExistRegex},
+		Expect:  []string{SuccessfulOutputRegex, SuccessfulIPv6OutputRegex, DeviceDoesNotExistRegex},
 		Timeout: i.timeout,
 	}
 }
 
-// ReelMatch parses the ip addr output and set the test result on match.
+// ReelMatch parses the ip addr output and set the test result on match. The ipv4 address is listed before the ipv6
+// ones, so both are in the match of a dual-stack device.
 // Returns no step; the test is complete.
 func (i *IPAddr) ReelMatch(pattern, _, match string) *reel.Step {
 	if pattern == DeviceDoesNotExistRegex {
@@ -91,6 +97,12 @@ func (i *IPAddr) ReelMatch(pattern, _, match string) *reel.Step {
 		i.ipv4Address = matched[1]
 		i.result = tnf.SUCCESS
 	}
+	re = regexp.MustCompile(SuccessfulIPv6OutputRegex)
+	matched = re.FindStringSubmatch(match)
+	if matched != nil {
+		i.ipv6Address = matched[1]
+		i.result = tnf.SUCCESS
+	}
 	return nil
 }
 
@@ -108,6 +120,11 @@ func (i *IPAddr) GetIPv4Address() string {
 	return i.ipv4Address
 }
 
+// GetIPv6Address returns the extracted global IPv6 address for the given device (interface).
+func (i *IPAddr) GetIPv6Address() string {
+	return i.ipv6Address
+}
+
 func ipAddrCmd(dev string) []string {
 	return strings.Split(fmt.Sprintf("%s %s", ipAddrCommand, dev), " ")
 }
@@ -40,6 +40,7 @@ type TestCase struct {
 	pattern             string
 	expectedResult      int
 	expectedIpv4Address string
+	expectedIpv6Address string
 }
 
 var testCases = map[string]TestCase{
@@ -49,6 +50,19 @@ var testCases = map[string]TestCase{
 		expectedResult:      tnf.SUCCESS,
 		expectedIpv4Address: "172.17.0.7",
 	},
+	"device_exists_dual_stack": {
+		device:              "eth0",
+		pattern:             ipaddr.SuccessfulOutputRegex,
+		expectedResult:      tnf.SUCCESS,
+		expectedIpv4Address: "10.128.2.28",
+		expectedIpv6Address: "fd01:0:0:5::1c",
+	},
+	"device_exists_ipv6": {
+		device:              "eth0",
+		pattern:             ipaddr.SuccessfulIPv6OutputRegex,
+		expectedResult:      tnf.SUCCESS,
+		expectedIpv6Address: "fd01:0:0:5::1c",
+	},
 	"device_does_not_exist": {
 		device:              "dne",
 		pattern:             ipaddr.DeviceDoesNotExistRegex,
@@ -127,6 +141,15 @@ func TestIpAddr_GetIpv4Address(t *testing.T) {
 	}
 }
 
+func TestIpAddr_GetIpv6Address(t *testing.T) {
+	for testName, testCase := range testCases {
+		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
+		step := ipAddr.ReelMatch(testCase.pattern, "", getMockOutput(t, testName))
+		assert.Nil(t, step)
+		assert.Equal(t, testCase.expectedIpv6Address, ipAddr.GetIPv6Address())
+	}
+}
+
 func TestIpAddr_ReelTimeout(t *testing.T) {
 	for _, testCase := range testCases {
 		ipAddr := ipaddr.NewIPAddr(testTimeoutDuration, testCase.device)
@@ -0,0 +1,108 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package kill
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	podDeletedRegex = `(?m)^pod "%s" deleted`
+	killCommand     = "kill -KILL %s && echo killed %s"
+	killedRegex     = `(?m)^killed %s$`
+)
+
+// Kill holds the parameters of the PodDelete and ContainerKill handlers.
+type Kill struct {
+	result     int
+	timeout    time.Duration
+	args       []string
+	regex      string
+	identifier identifier.Identifier
+}
+
+// NewPodDelete creates a new Kill handler deleting a pod, without waiting for its containers to terminate.
+func NewPodDelete(timeout time.Duration, namespace, podName string) *Kill {
+	return &Kill{
+		timeout:    timeout,
+		result:     tnf.ERROR,
+		args:       []string{"oc", "delete", "pod", podName, "-n", namespace, "--wait=false"},
+		regex:      fmt.Sprintf(podDeletedRegex, podName),
+		identifier: identifier.PodDeleteIdentifier,
+	}
+}
+
+// NewContainerKill creates a new Kill handler sending SIGKILL to the process of a container.  It runs in the debug
+// pod of the node of the container, containerPID being the PID of the process on the node, as returned by
+// utils.GetContainerPID.
+func NewContainerKill(timeout time.Duration, containerPID string) *Kill {
+	return &Kill{
+		timeout:    timeout,
+		result:     tnf.ERROR,
+		args:       []string{fmt.Sprintf(killCommand, containerPID, containerPID)},
+		regex:      fmt.Sprintf(killedRegex, containerPID),
+		identifier: identifier.ContainerKillIdentifier,
+	}
+}
+
+// Args returns the command line args for the test.
+func (k *Kill) Args() []string {
+	return k.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (k *Kill) GetIdentifier() identifier.Identifier {
+	return k.identifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (k *Kill) Timeout() time.Duration {
+	return k.timeout
+}
+
+// Result returns the test result.
+func (k *Kill) Result() int {
+	return k.result
+}
+
+// ReelFirst returns a step which expects the command output within the test timeout.
+func (k *Kill) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{k.regex},
+		Timeout: k.timeout,
+	}
+}
+
+// ReelMatch does nothing, just set the test result as success.
+func (k *Kill) ReelMatch(_, _, _ string) *reel.Step {
+	k.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (k *Kill) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary upon EOF.
+func (k *Kill) ReelEOF() {
+}
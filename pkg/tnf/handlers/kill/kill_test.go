@@ -0,0 +1,81 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package kill_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/kill"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 1
+	testPodNamespace    = "tnf"
+	testPodName         = "test-0"
+	testContainerPID    = "4242"
+)
+
+func Test_NewPodDelete(t *testing.T) {
+	handler := kill.NewPodDelete(testTimeoutDuration, testPodNamespace, testPodName)
+	assert.NotNil(t, handler)
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, identifier.PodDeleteIdentifier, handler.GetIdentifier())
+	assert.Equal(t, []string{"oc", "delete", "pod", "test-0", "-n", "tnf", "--wait=false"}, handler.Args())
+}
+
+func Test_NewContainerKill(t *testing.T) {
+	handler := kill.NewContainerKill(testTimeoutDuration, testContainerPID)
+	assert.NotNil(t, handler)
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, identifier.ContainerKillIdentifier, handler.GetIdentifier())
+	assert.Equal(t, []string{"kill -KILL 4242 && echo killed 4242"}, handler.Args())
+}
+
+func Test_KillReelFirst(t *testing.T) {
+	testCases := []struct {
+		handler *kill.Kill
+		output  string
+		matches bool
+	}{
+		{handler: kill.NewPodDelete(testTimeoutDuration, testPodNamespace, testPodName), output: `pod "test-0" deleted`, matches: true},
+		{handler: kill.NewPodDelete(testTimeoutDuration, testPodNamespace, testPodName), output: `pod "test-01" deleted`, matches: false},
+		{handler: kill.NewPodDelete(testTimeoutDuration, testPodNamespace, testPodName),
+			output: `Error from server (NotFound): pods "test-0" not found`, matches: false},
+		{handler: kill.NewContainerKill(testTimeoutDuration, testContainerPID), output: "killed 4242", matches: true},
+		{handler: kill.NewContainerKill(testTimeoutDuration, testContainerPID), output: "killed 42421", matches: false},
+		{handler: kill.NewContainerKill(testTimeoutDuration, testContainerPID), output: "kill: (4242): No such process", matches: false},
+	}
+	for _, tc := range testCases {
+		re := regexp.MustCompile(tc.handler.ReelFirst().Expect[0])
+		assert.Equal(t, tc.matches, re.MatchString(tc.output), tc.output)
+	}
+}
+
+func Test_KillReelMatch(t *testing.T) {
+	handler := kill.NewContainerKill(testTimeoutDuration, testContainerPID)
+	assert.Nil(t, handler.ReelMatch("", "", "killed 4242"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
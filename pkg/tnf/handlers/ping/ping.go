@@ -17,6 +17,7 @@
 package ping
 
 import (
+	"net"
 	"regexp"
 	"strconv"
 	"time"
@@ -123,20 +124,25 @@ func (p *Ping) GetStats() (transmitted, received, errors int) {
 }
 
 // Command returns command line args for pinging `host` with `count` requests, or indefinitely if `count` is not
-// positive.
+// positive. IPv6 hosts are pinged with `ping -6`.
 func Command(host string, count int) []string {
-	if count > 0 {
-		return []string{dependencies.PingBinaryName, "-c", strconv.Itoa(count), host}
-	}
-	return []string{dependencies.PingBinaryName, host}
+	return append([]string{dependencies.PingBinaryName}, pingArgs(host, count)...)
 }
 
 // Command same as command but uses nsenter to in the command
 func CommandNsenter(containerPID, host string, count int) []string {
+	return append([]string{utils.AddNsenterPrefix(containerPID), dependencies.PingBinaryName}, pingArgs(host, count)...)
+}
+
+func pingArgs(host string, count int) []string {
+	var args []string
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		args = append(args, "-6")
+	}
 	if count > 0 {
-		return []string{utils.AddNsenterPrefix(containerPID), dependencies.PingBinaryName, "-c", strconv.Itoa(count), host}
+		args = append(args, "-c", strconv.Itoa(count))
 	}
-	return []string{utils.AddNsenterPrefix(containerPID), dependencies.PingBinaryName, host}
+	return append(args, host)
 }
 
 // NewPing creates a new `Ping` test which pings `hosts` with `count` requests, or indefinitely if `count` is not
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -86,6 +87,14 @@ var testCases = map[string]TestCase{
 		expectedErrors:   0,
 		expectedResult:   tnf.SUCCESS,
 	},
+	"ipv6_address_no_packet_loss": {
+		host:             "fd01:0:0:5::1c",
+		count:            4,
+		expectedSent:     4,
+		expectedReceived: 4,
+		expectedErrors:   0,
+		expectedResult:   tnf.SUCCESS,
+	},
 	"incorrect_ip_address": {
 		host:             "0.0.1.2",
 		count:            1,
@@ -122,6 +131,9 @@ func TestPing_Args(t *testing.T) {
 		request := ping.NewPing(testTimeoutDuration, testCase.host, testCase.count)
 		assert.NotNil(t, request)
 		args := []string{"ping", "-c", strconv.Itoa(testCase.count), testCase.host}
+		if strings.Contains(testCase.host, ":") {
+			args = []string{"ping", "-6", "-c", strconv.Itoa(testCase.count), testCase.host}
+		}
 		assert.Equal(t, args, request.Args())
 	}
 }
@@ -198,3 +210,12 @@ func TestPingCmd(t *testing.T) {
 	cmd = ping.Command("192.168.1.1", 1)
 	assert.Equal(t, []string{"ping", "-c", "1", "192.168.1.1"}, cmd)
 }
+
+func TestPingCmdIPv6(t *testing.T) {
+	cmd := ping.Command("fd01::1c", 0)
+	assert.Equal(t, []string{"ping", "-6", "fd01::1c"}, cmd)
+	cmd = ping.Command("fd01::1c", 1)
+	assert.Equal(t, []string{"ping", "-6", "-c", "1", "fd01::1c"}, cmd)
+	cmd = ping.Command("::ffff:192.168.1.1", 1)
+	assert.Equal(t, []string{"ping", "-c", "1", "::ffff:192.168.1.1"}, cmd)
+}
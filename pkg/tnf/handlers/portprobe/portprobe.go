@@ -0,0 +1,156 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package portprobe
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/dependencies"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+	"github.com/test-network-function/test-network-function/pkg/utils"
+)
+
+// Protocols of the probed ports, as declared in container ports.
+const (
+	TCP  = "TCP"
+	UDP  = "UDP"
+	SCTP = "SCTP"
+)
+
+const (
+	// UnreachableRegex matches nc output when the port could not be connected to.
+	UnreachableRegex = `(?m)^Ncat: (Connection refused|TIMEOUT|No route to host|Network is unreachable|Connection timed out)\.`
+	// ConnectedRegex matches nc output when the port was connected to.
+	ConnectedRegex = `(?m)^Ncat: Connected to \S+\.`
+	// ErrorRegex matches any other nc message, e.g. when the protocol is not supported by the node.
+	ErrorRegex = `(?m)^Ncat: (.+)\.\s*$`
+	// UDPInconclusiveReason is the reason of the error reported for a UDP port that was not refused: the datagram may
+	// as well have been dropped silently, e.g. by a NetworkPolicy.
+	UDPInconclusiveReason = "UDP port not refused, but no answer confirms it is reachable"
+)
+
+// PortProbe provides a port reachability test implemented using `nc -z`. A TCP or SCTP port is reachable when
+// a connection is established. UDP is connectionless: a UDP port is reported unreachable when the target answers
+// with an ICMP port unreachable message, and the probe errors out otherwise, as nothing tells a port that was
+// reached from a datagram that was dropped.
+type PortProbe struct {
+	result   int
+	timeout  time.Duration
+	args     []string
+	protocol string
+	reason   string
+}
+
+// Args returns the command line args for the test.
+func (p *PortProbe) Args() []string {
+	return p.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (p *PortProbe) GetIdentifier() identifier.Identifier {
+	return identifier.PortProbeIdentifier
+}
+
+// Timeout returns the timeout for the test.
+func (p *PortProbe) Timeout() time.Duration {
+	return p.timeout
+}
+
+// Result returns the test result.
+func (p *PortProbe) Result() int {
+	return p.result
+}
+
+// Reason returns the nc message explaining a result other than tnf.SUCCESS.
+func (p *PortProbe) Reason() string {
+	return p.reason
+}
+
+// ReelFirst returns a step which expects the outcome of the connection within the test timeout. The failures are
+// listed first, as nc reports a UDP "connection" before it gets a port unreachable message.
+func (p *PortProbe) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{UnreachableRegex, ConnectedRegex, ErrorRegex},
+		Timeout: p.timeout,
+	}
+}
+
+// ReelMatch sets the test result from the matched nc message.
+// Returns no step; the test is complete.
+func (p *PortProbe) ReelMatch(pattern, _, match string) *reel.Step {
+	switch {
+	case pattern == ConnectedRegex && p.protocol == UDP:
+		p.result = tnf.ERROR
+		p.reason = UDPInconclusiveReason
+		return nil
+	case pattern == ConnectedRegex:
+		p.result = tnf.SUCCESS
+		return nil
+	case pattern == UnreachableRegex:
+		p.result = tnf.FAILURE
+	default:
+		p.result = tnf.ERROR
+	}
+	if matched := regexp.MustCompile(pattern).FindStringSubmatch(match); matched != nil {
+		p.reason = strings.TrimSpace(matched[1])
+	}
+	return nil
+}
+
+// ReelTimeout does nothing; nc gives up on its own after its connection timeout.
+func (p *PortProbe) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing; nc requires no intervention on eof.
+func (p *PortProbe) ReelEOF() {
+}
+
+// Command returns command line args for probing `port` of `host` over `protocol`, giving up connecting after
+// `connectTimeout`. nc exits with an error status when the port is unreachable, which is a test failure and not an
+// error running the command, hence the trailing `|| true`.
+func Command(host string, port int, protocol string, connectTimeout time.Duration) []string {
+	args := []string{dependencies.NcBinaryName, "-v", "-z", "-w", strconv.Itoa(int(connectTimeout.Seconds()))}
+	switch protocol {
+	case UDP:
+		args = append(args, "--udp")
+	case SCTP:
+		args = append(args, "--sctp")
+	}
+	return append(args, host, strconv.Itoa(port), "||", "true")
+}
+
+// CommandNsenter is the same as Command but runs nc in the network namespace of the process containerPID.
+func CommandNsenter(containerPID, host string, port int, protocol string, connectTimeout time.Duration) []string {
+	return append([]string{utils.AddNsenterPrefix(containerPID)}, Command(host, port, protocol, connectTimeout)...)
+}
+
+// NewPortProbe creates a new `PortProbe` test of `port` of `host` over `protocol`, which executes within `timeout`.
+func NewPortProbe(timeout time.Duration, host string, port int, protocol string, connectTimeout time.Duration) *PortProbe {
+	return &PortProbe{result: tnf.ERROR, timeout: timeout, args: Command(host, port, protocol, connectTimeout), protocol: protocol}
+}
+
+// NewPortProbeNsenter is the same as NewPortProbe but runs nc in the network namespace of the process containerPID,
+// from a node debug pod.
+func NewPortProbeNsenter(timeout time.Duration, containerPID, host string, port int, protocol string, connectTimeout time.Duration) *PortProbe {
+	return &PortProbe{result: tnf.ERROR, timeout: timeout, args: CommandNsenter(containerPID, host, port, protocol, connectTimeout), protocol: protocol}
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package portprobe_test
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/portprobe"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 10
+	connectTimeout      = time.Second * 5
+)
+
+var testCases = map[string]struct {
+	protocol       string
+	expectedResult int
+	expectedReason string
+}{
+	"tcp_connected":      {protocol: portprobe.TCP, expectedResult: tnf.SUCCESS},
+	"tcp_refused":        {protocol: portprobe.TCP, expectedResult: tnf.FAILURE, expectedReason: "Connection refused"},
+	"tcp_timeout":        {protocol: portprobe.TCP, expectedResult: tnf.FAILURE, expectedReason: "TIMEOUT"},
+	"udp_connected":      {protocol: portprobe.UDP, expectedResult: tnf.ERROR, expectedReason: portprobe.UDPInconclusiveReason},
+	"udp_refused":        {protocol: portprobe.UDP, expectedResult: tnf.FAILURE, expectedReason: "Connection refused"},
+	"sctp_not_supported": {protocol: portprobe.SCTP, expectedResult: tnf.ERROR, expectedReason: "Protocol not supported"},
+}
+
+// firstMatch returns the first expectation of the step matching output, as reel does.
+func firstMatch(t *testing.T, expectations []string, output string) string {
+	for _, expectation := range expectations {
+		if regexp.MustCompile(expectation).MatchString(output) {
+			return expectation
+		}
+	}
+	assert.Fail(t, "no expectation matched", output)
+	return ""
+}
+
+func TestPortProbe_ReelMatch(t *testing.T) {
+	for testName, testCase := range testCases {
+		probe := portprobe.NewPortProbe(testTimeoutDuration, "10.128.2.28", 8080, testCase.protocol, connectTimeout)
+		assert.Equal(t, tnf.ERROR, probe.Result())
+		output, err := os.ReadFile(path.Join("testdata", testName+".txt"))
+		assert.Nil(t, err)
+		pattern := firstMatch(t, probe.ReelFirst().Expect, string(output))
+		assert.Nil(t, probe.ReelMatch(pattern, "", string(output)))
+		assert.Equal(t, testCase.expectedResult, probe.Result(), testName)
+		assert.Equal(t, testCase.expectedReason, probe.Reason(), testName)
+	}
+}
+
+func TestPortProbe_Args(t *testing.T) {
+	probe := portprobe.NewPortProbe(testTimeoutDuration, "10.128.2.28", 8080, portprobe.TCP, connectTimeout)
+	assert.Equal(t, []string{"nc", "-v", "-z", "-w", "5", "10.128.2.28", "8080", "||", "true"}, probe.Args())
+	probe = portprobe.NewPortProbe(testTimeoutDuration, "fd01::1c", 5353, portprobe.UDP, connectTimeout)
+	assert.Equal(t, []string{"nc", "-v", "-z", "-w", "5", "--udp", "fd01::1c", "5353", "||", "true"}, probe.Args())
+	probe = portprobe.NewPortProbeNsenter(testTimeoutDuration, "1234", "10.128.2.28", 38412, portprobe.SCTP, connectTimeout)
+	assert.Equal(t, []string{"nsenter -t 1234 -n ", "nc", "-v", "-z", "-w", "5", "--sctp", "10.128.2.28", "38412", "||", "true"}, probe.Args())
+}
+
+func TestPortProbe_GetIdentifier(t *testing.T) {
+	probe := portprobe.NewPortProbe(testTimeoutDuration, "10.128.2.28", 8080, portprobe.TCP, connectTimeout)
+	assert.Equal(t, identifier.PortProbeIdentifier, probe.GetIdentifier())
+	assert.Equal(t, testTimeoutDuration, probe.Timeout())
+	assert.Nil(t, probe.ReelTimeout())
+	probe.ReelEOF()
+}
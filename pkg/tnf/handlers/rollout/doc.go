@@ -1,4 +1,4 @@
-// Copyright (C) 2021 Red Hat, Inc.
+// Copyright (C) 2022 Red Hat, Inc.
 //
 // This program is free software; you can redistribute it and/or modify
 // it under the terms of the GNU General Public License as published by
@@ -14,6 +14,6 @@
 // with this program; if not, write to the Free Software Foundation, Inc.,
 // 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
 
-// Package checksubscription provides a Test that checks the subscription for a given operator.  This verifies that the
-// operator was installed using OLM.
-package checksubscription
+// Package rollout provides tests to roll out deployments and statefulsets by patching their pod template, and to
+// read the status of their rollout.
+package rollout
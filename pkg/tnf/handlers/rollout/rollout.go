@@ -0,0 +1,101 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rollout
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	// AnnotationName is the pod template annotation set to trigger a rollout.  Changing it only replaces the pods.
+	AnnotationName = "test-network-function.com/rollout"
+
+	patchCommand = `oc patch %s %s -n %s --type=merge -p '{"spec":{"template":{"metadata":{"annotations":{"%s":%s}}}}}'`
+	patchRegex   = "^%s.*/%s patched"
+)
+
+// Rollout holds the Rollout handler parameters.
+type Rollout struct {
+	result  int
+	timeout time.Duration
+	args    []string
+	regex   string
+}
+
+// NewRollout creates a new Rollout handler, setting the AnnotationName annotation of the pod template of the podset
+// to value, which rolls it out when the value changes.  An empty value removes the annotation.
+func NewRollout(timeout time.Duration, namespace, podsetName, typesource, value string) *Rollout {
+	jsonValue := "null"
+	if value != "" {
+		jsonValue = fmt.Sprintf("%q", value)
+	}
+	command := fmt.Sprintf(patchCommand, typesource, podsetName, namespace, AnnotationName, jsonValue)
+	return &Rollout{
+		timeout: timeout,
+		result:  tnf.ERROR,
+		args:    []string{command},
+		regex:   fmt.Sprintf(patchRegex, typesource, podsetName),
+	}
+}
+
+// Args returns the command line args for the test.
+func (r *Rollout) Args() []string {
+	return r.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (r *Rollout) GetIdentifier() identifier.Identifier {
+	return identifier.RolloutIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (r *Rollout) Timeout() time.Duration {
+	return r.timeout
+}
+
+// Result returns the test result.
+func (r *Rollout) Result() int {
+	return r.result
+}
+
+// ReelFirst returns a step which expects the patch command output within the test timeout.
+func (r *Rollout) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{r.regex},
+		Timeout: r.timeout,
+	}
+}
+
+// ReelMatch does nothing, just set the test result as success.
+func (r *Rollout) ReelMatch(_, _, _ string) *reel.Step {
+	r.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (r *Rollout) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary upon EOF.
+func (r *Rollout) ReelEOF() {
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rollout_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rollout"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+)
+
+const (
+	testTimeoutDuration = time.Second * 1
+	testPodNamespace    = "tnf"
+	testDeploymentName  = "test"
+	sourcetype          = "deployment"
+)
+
+func Test_NewRollout(t *testing.T) {
+	handler := rollout.NewRollout(testTimeoutDuration, testPodNamespace, testDeploymentName, sourcetype, "2022-03-01T10:00:00Z")
+	assert.NotNil(t, handler)
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, identifier.RolloutIdentifier, handler.GetIdentifier())
+	assert.Equal(t, []string{`oc patch deployment test -n tnf --type=merge -p ` +
+		`'{"spec":{"template":{"metadata":{"annotations":{"test-network-function.com/rollout":"2022-03-01T10:00:00Z"}}}}}'`}, handler.Args())
+
+	restore := rollout.NewRollout(testTimeoutDuration, testPodNamespace, testDeploymentName, sourcetype, "")
+	assert.Equal(t, []string{`oc patch deployment test -n tnf --type=merge -p ` +
+		`'{"spec":{"template":{"metadata":{"annotations":{"test-network-function.com/rollout":null}}}}}'`}, restore.Args())
+}
+
+func Test_RolloutReelFirst(t *testing.T) {
+	handler := rollout.NewRollout(testTimeoutDuration, testPodNamespace, testDeploymentName, sourcetype, "now")
+	re := regexp.MustCompile(handler.ReelFirst().Expect[0])
+	assert.True(t, re.MatchString("deployment.apps/test patched"))
+	assert.True(t, re.MatchString("deployment.apps/test patched (no change)"))
+	assert.False(t, re.MatchString(`Error from server (NotFound): deployments.apps "test" not found`))
+}
+
+func Test_RolloutReelMatch(t *testing.T) {
+	handler := rollout.NewRollout(testTimeoutDuration, testPodNamespace, testDeploymentName, sourcetype, "now")
+	assert.Nil(t, handler.ReelMatch("", "", "deployment.apps/test patched"))
+	assert.Equal(t, tnf.SUCCESS, handler.Result())
+	assert.Nil(t, handler.ReelTimeout())
+	handler.ReelEOF()
+}
+
+func Test_NewStatus(t *testing.T) {
+	handler := rollout.NewStatus(testTimeoutDuration, testPodNamespace, testDeploymentName, "statefulset")
+	assert.NotNil(t, handler)
+	assert.Equal(t, testTimeoutDuration, handler.Timeout())
+	assert.Equal(t, tnf.ERROR, handler.Result())
+	assert.Equal(t, identifier.RolloutStatusIdentifier, handler.GetIdentifier())
+	assert.Equal(t, []string{"oc", "rollout", "status", "statefulset", "test", "-n", "tnf", "--watch=false"}, handler.Args())
+}
+
+func Test_StatusReelMatch(t *testing.T) {
+	testCases := []struct {
+		output   string
+		complete bool
+	}{
+		{output: `deployment "test" successfully rolled out`, complete: true},
+		{output: "statefulset rolling update complete 2 pods at revision test-5f57f59fbc...", complete: true},
+		{output: "partitioned roll out complete: 2 new pods have been updated...", complete: true},
+		{output: `Waiting for deployment "test" rollout to finish: 1 out of 2 new replicas have been updated...`},
+		{output: "Waiting for 1 pods to be ready..."},
+	}
+	for _, tc := range testCases {
+		handler := rollout.NewStatus(testTimeoutDuration, testPodNamespace, testDeploymentName, sourcetype)
+		var pattern, match string
+		for _, expect := range handler.ReelFirst().Expect {
+			if match = regexp.MustCompile(expect).FindString(tc.output); match != "" {
+				pattern = expect
+				break
+			}
+		}
+		assert.NotEmpty(t, pattern, tc.output)
+		assert.Nil(t, handler.ReelMatch(pattern, "", match))
+		assert.Equal(t, tnf.SUCCESS, handler.Result())
+		assert.Equal(t, tc.complete, handler.IsComplete(), tc.output)
+		assert.Equal(t, tc.output, handler.GetStatus())
+	}
+}
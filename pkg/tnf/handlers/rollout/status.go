@@ -0,0 +1,109 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package rollout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
+	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
+)
+
+const (
+	statusCommand = "oc rollout status %s %s -n %s --watch=false"
+	// completeRegex matches the status of a deployment, or of a statefulset updated all at once or by partition, whose
+	// rollout completed.
+	completeRegex = `(?m)^.*(successfully rolled out|rolling update complete|partitioned roll out complete).*$`
+	// inProgressRegex matches the status of a rollout in progress.
+	inProgressRegex = `(?m)^Waiting for .*$`
+)
+
+// Status holds the Status handler parameters.
+type Status struct {
+	result   int
+	timeout  time.Duration
+	args     []string
+	complete bool
+	status   string
+}
+
+// NewStatus creates a new Status handler, reading the status of the rollout of a podset without waiting for it.
+func NewStatus(timeout time.Duration, namespace, podsetName, typesource string) *Status {
+	return &Status{
+		timeout: timeout,
+		result:  tnf.ERROR,
+		args:    strings.Fields(fmt.Sprintf(statusCommand, typesource, podsetName, namespace)),
+	}
+}
+
+// Args returns the command line args for the test.
+func (s *Status) Args() []string {
+	return s.args
+}
+
+// GetIdentifier returns the tnf.Test specific identifier.
+func (s *Status) GetIdentifier() identifier.Identifier {
+	return identifier.RolloutStatusIdentifier
+}
+
+// Timeout returns the timeout in seconds for the test.
+func (s *Status) Timeout() time.Duration {
+	return s.timeout
+}
+
+// Result returns the test result.
+func (s *Status) Result() int {
+	return s.result
+}
+
+// IsComplete returns whether the rollout completed.
+func (s *Status) IsComplete() bool {
+	return s.complete
+}
+
+// GetStatus returns the status line of the rollout.
+func (s *Status) GetStatus() string {
+	return s.status
+}
+
+// ReelFirst returns a step which expects the rollout status within the test timeout.
+func (s *Status) ReelFirst() *reel.Step {
+	return &reel.Step{
+		Expect:  []string{completeRegex, inProgressRegex},
+		Timeout: s.timeout,
+	}
+}
+
+// ReelMatch records whether the rollout completed, and sets the test result as success.
+func (s *Status) ReelMatch(pattern, _, match string) *reel.Step {
+	s.complete = pattern == completeRegex
+	s.status = strings.TrimSpace(strings.SplitN(match, "\n", 2)[0])
+	s.result = tnf.SUCCESS
+	return nil
+}
+
+// ReelTimeout does nothing;  no action is necessary upon timeout.
+func (s *Status) ReelTimeout() *reel.Step {
+	return nil
+}
+
+// ReelEOF does nothing;  no action is necessary upon EOF.
+func (s *Status) ReelEOF() {
+}
@@ -25,7 +25,6 @@ const (
 	nodeselectorIdentifierURL             = urlTests + "/nodeselector"
 	ipAddrIdentifierURL                   = urlTests + "/ipaddr"
 	nodesIdentifierURL                    = urlTests + "/nodes"
-	operatorIdentifierURL                 = urlTests + "/operator"
 	pingIdentifierURL                     = urlTests + "/ping"
 	podIdentifierURL                      = urlTests + "/container/pod"
 	versionIdentifierURL                  = urlTests + "/generic/version"
@@ -49,7 +48,6 @@ const (
 	sysctlConfigFilesListIdentifierURL    = urlTests + "/sysctlConfigFilesList"
 	sysctlAllConfigsArgsURL               = urlTests + "/sysctlAllConfigsArgs"
 	uncordonNodeIdentifierURL             = urlTests + "/node/uncordon"
-	checkSubscriptionIdentifierURL        = urlTests + "/operator/check-subscription"
 	nodeDebugIdentifierURL                = urlTests + "/nodedebug"
 	loggingIdentifierURL                  = urlTests + "/logging"
 	podantiaffinityIdentifierURL          = urlTests + "/testPodHighAvailability"
@@ -60,6 +58,11 @@ const (
 	crdStatusExistenceIdentifierURL       = urlTests + "/crdStatusExistence"
 	daemonSetIdentifierURL                = urlTests + "/daemonset"
 	automountserviceIdentifierURL         = urlTests + "/automountservice"
+	portProbeIdentifierURL                = urlTests + "/portprobe"
+	rolloutIdentifierURL                  = urlTests + "/rollout"
+	rolloutStatusIdentifierURL            = urlTests + "/rolloutStatus"
+	podDeleteIdentifierURL                = urlTests + "/podDelete"
+	containerKillIdentifierURL            = urlTests + "/containerKill"
 	versionOne                            = "v1.0.0"
 )
 
@@ -135,22 +138,6 @@ var Catalog = map[string]TestCatalogEntry{
 			dependencies.OcBinaryName,
 		},
 	},
-	operatorIdentifierURL: {
-		Identifier: OperatorIdentifier,
-		Description: "An operator-specific test used to exercise the behavior of a given operator.  In the current " +
-			"offering, we check if the operator ClusterServiceVersion (CSV) is installed properly.  A CSV is a YAML " +
-			"manifest created from Operator metadata that assists the Operator Lifecycle Manager (OLM) in running " +
-			"the Operator.",
-		Type: Normative,
-		IntrusionSettings: IntrusionSettings{
-			ModifiesSystem:           false,
-			ModificationIsPersistent: false,
-		},
-		BinaryDependencies: []string{
-			dependencies.JqBinaryName,
-			dependencies.OcBinaryName,
-		},
-	},
 	pingIdentifierURL: {
 		Identifier:  PingIdentifier,
 		Description: "A generic test used to test ICMP connectivity from a source machine/container to a target destination.",
@@ -355,7 +342,8 @@ var Catalog = map[string]TestCatalogEntry{
 	},
 	deploymentsdrainIdentifierURL: {
 		Identifier:  DeploymentsNodesIdentifier,
-		Description: "A generic test used to drain node from its deployment pods",
+		Description: "A generic test used to drain node from its deployment pods. The pods are evicted, so the drain " +
+			"honors their PodDisruptionBudgets.",
 		Type:        Normative,
 		IntrusionSettings: IntrusionSettings{
 			ModifiesSystem:           true,
@@ -444,18 +432,6 @@ var Catalog = map[string]TestCatalogEntry{
 			dependencies.OcBinaryName,
 		},
 	},
-	checkSubscriptionIdentifierURL: {
-		Identifier:  CheckSubscriptionURLIdentifier,
-		Description: "A test used to check the subscription of a given operator",
-		Type:        Normative,
-		IntrusionSettings: IntrusionSettings{
-			ModifiesSystem:           false,
-			ModificationIsPersistent: false,
-		},
-		BinaryDependencies: []string{
-			dependencies.OcBinaryName,
-		},
-	},
 	nodeDebugIdentifierURL: {
 		Identifier:  NodeDebugIdentifier,
 		Description: "A generic test used to execute a command in a node",
@@ -593,6 +569,69 @@ var Catalog = map[string]TestCatalogEntry{
 			dependencies.OcBinaryName,
 		},
 	},
+	portProbeIdentifierURL: {
+		Identifier:  PortProbeIdentifier,
+		Description: "A generic test used to test TCP, UDP or SCTP connectivity from a source container to a port of a target destination.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.NcBinaryName,
+		},
+	},
+	rolloutIdentifierURL: {
+		Identifier: RolloutIdentifier,
+		Description: "A test to roll out a deployment or a statefulset. The test patches an annotation of the pod " +
+			"template, which replaces the pods without changing them, and checks whether the command output is valid.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           true,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	rolloutStatusIdentifierURL: {
+		Identifier:  RolloutStatusIdentifier,
+		Description: "A test to read whether the rollout of a deployment or a statefulset completed, without waiting for it.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           false,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	podDeleteIdentifierURL: {
+		Identifier:  PodDeleteIdentifier,
+		Description: "A test to delete a pod without waiting for its termination, and check whether the command output is valid.",
+		Type:        Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           true,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.OcBinaryName,
+		},
+	},
+	containerKillIdentifierURL: {
+		Identifier: ContainerKillIdentifier,
+		Description: "A test to kill the process of a container with SIGKILL from the debug pod of its node, given its PID " +
+			"on the node.",
+		Type: Normative,
+		IntrusionSettings: IntrusionSettings{
+			ModifiesSystem:           true,
+			ModificationIsPersistent: false,
+		},
+		BinaryDependencies: []string{
+			dependencies.KillBinaryName,
+			dependencies.EchoBinaryName,
+		},
+	},
 }
 
 // TestIDBaseDomain is the BaseDomain for the IDs of test cases building blocks
@@ -616,12 +655,6 @@ var NodesIdentifier = Identifier{
 	SemanticVersion: versionOne,
 }
 
-// OperatorIdentifier is the Identifier used to represent the operator-specific test suite.
-var OperatorIdentifier = Identifier{
-	URL:             operatorIdentifierURL,
-	SemanticVersion: versionOne,
-}
-
 // PingIdentifier is the Identifier used to represent the generic Ping test.
 var PingIdentifier = Identifier{
 	URL:             pingIdentifierURL,
@@ -759,12 +792,6 @@ var UncordonNodeURLIdentifier = Identifier{
 	SemanticVersion: versionOne,
 }
 
-// CheckSubscriptionURLIdentifier is the Identifier used to represent a test that checks the subscription of an operator.
-var CheckSubscriptionURLIdentifier = Identifier{
-	URL:             checkSubscriptionIdentifierURL,
-	SemanticVersion: versionOne,
-}
-
 // NodeDebugIdentifier is the Identifier used to represent the generic NodeDebug test.
 var NodeDebugIdentifier = Identifier{
 	URL:             nodeDebugIdentifierURL,
@@ -828,3 +855,33 @@ var AutomountServiceIdentifier = Identifier{
 	URL:             automountserviceIdentifierURL,
 	SemanticVersion: versionOne,
 }
+
+// PortProbeIdentifier is the Identifier used to represent the generic port probe test.
+var PortProbeIdentifier = Identifier{
+	URL:             portProbeIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// RolloutIdentifier is the Identifier used to represent a test that rolls out deployments and statefulsets.
+var RolloutIdentifier = Identifier{
+	URL:             rolloutIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// RolloutStatusIdentifier is the Identifier used to represent a test that reads the rollout status of a podset.
+var RolloutStatusIdentifier = Identifier{
+	URL:             rolloutStatusIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// PodDeleteIdentifier is the Identifier used to represent a test that deletes a pod.
+var PodDeleteIdentifier = Identifier{
+	URL:             podDeleteIdentifierURL,
+	SemanticVersion: versionOne,
+}
+
+// ContainerKillIdentifier is the Identifier used to represent a test that kills the process of a container.
+var ContainerKillIdentifier = Identifier{
+	URL:             containerKillIdentifierURL,
+	SemanticVersion: versionOne,
+}
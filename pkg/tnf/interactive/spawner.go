@@ -433,9 +433,15 @@ func (g *GoExpectSpawner) extractStderrPipe(spawnFunc *SpawnFunc) (io.Reader, er
 }
 
 // CreateGoExpectSpawner creates a GoExpectSpawner implementation and returns it as a *Spawner for type compatibility
-// reasons.
+// reasons. The sessions are recorded to the file named by TNF_RECORD_TRANSCRIPT, or replayed from the file named by
+// TNF_REPLAY_TRANSCRIPT instead of spawning the command.
 func CreateGoExpectSpawner() *Spawner {
 	goExpectSpawner := NewGoExpectSpawner()
 	var spawner Spawner = goExpectSpawner
+	if transcript := getReplayTranscript(); transcript != nil {
+		spawner = NewReplaySpawner(transcript)
+	} else if recorder := getTranscriptRecorder(); recorder != nil {
+		spawner = NewRecordingSpawner(spawner, recorder)
+	}
 	return &spawner
 }
@@ -0,0 +1,392 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	expect "github.com/google/goexpect"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// recordTranscriptEnvironmentVariableKey is the OS environment variable naming the file every session is recorded to.
+	recordTranscriptEnvironmentVariableKey = "TNF_RECORD_TRANSCRIPT"
+	// replayTranscriptEnvironmentVariableKey is the OS environment variable naming the file the sessions are replayed from.
+	replayTranscriptEnvironmentVariableKey = "TNF_REPLAY_TRANSCRIPT"
+
+	// shellSession is the session name of the local shells, which does not depend on $SHELL.
+	shellSession = "shell"
+)
+
+// TranscriptEntry is one exchange with a session: the text sent to it since the previous expectation, and the
+// output that satisfied the expectation, or how the expectation failed.
+type TranscriptEntry struct {
+	// Session identifies the spawned command, e.g. "oc rsh -n tnf -c test test-0".
+	Session string `json:"session"`
+	Execute string `json:"execute,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Timeout bool   `json:"timeout,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TranscriptRecorder writes transcript entries as JSON lines. It is safe for concurrent use by several sessions.
+type TranscriptRecorder struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewTranscriptRecorder creates a TranscriptRecorder writing to w.
+func NewTranscriptRecorder(w io.Writer) *TranscriptRecorder {
+	return &TranscriptRecorder{encoder: json.NewEncoder(w)}
+}
+
+func (r *TranscriptRecorder) record(entry *TranscriptEntry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err := r.encoder.Encode(entry); err != nil {
+		log.Errorf("Failed to record the transcript of session %s: %v", entry.Session, err)
+	}
+}
+
+// Transcript holds recorded entries for replay. Within a session, each entry is replayed once, in the recorded
+// order, to the first expectation following the same sent text. Matching on the sent text rather than on the
+// position lets sessions of the same command, e.g. the parallel sessions to a node, be replayed in any order.
+type Transcript struct {
+	mutex    sync.Mutex
+	sessions map[string][]*TranscriptEntry
+}
+
+// LoadTranscript reads the JSON lines written by a TranscriptRecorder.
+func LoadTranscript(r io.Reader) (*Transcript, error) {
+	t := &Transcript{sessions: map[string][]*TranscriptEntry{}}
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for {
+		entry := &TranscriptEntry{}
+		if err := decoder.Decode(entry); err == io.EOF {
+			return t, nil
+		} else if err != nil {
+			return nil, err
+		}
+		t.sessions[entry.Session] = append(t.sessions[entry.Session], entry)
+	}
+}
+
+// take removes and returns the first entry of session sent execute.
+func (t *Transcript) take(session, execute string) (*TranscriptEntry, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	entries := t.sessions[session]
+	for i, entry := range entries {
+		if entry.Execute == execute {
+			t.sessions[session] = append(entries[:i:i], entries[i+1:]...)
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// sessionName identifies a spawned command in a transcript.
+func sessionName(command string, args []string) string {
+	if command == os.Getenv(shellEnvironmentVariableKey) {
+		command = shellSession
+	}
+	return strings.Join(append([]string{command}, args...), " ")
+}
+
+// RecordingSpawner is a Spawner recording the sessions created by another Spawner.
+type RecordingSpawner struct {
+	spawner  Spawner
+	recorder *TranscriptRecorder
+}
+
+// NewRecordingSpawner creates a RecordingSpawner recording the sessions of spawner to recorder.
+func NewRecordingSpawner(spawner Spawner, recorder *TranscriptRecorder) *RecordingSpawner {
+	return &RecordingSpawner{spawner: spawner, recorder: recorder}
+}
+
+// Spawn spawns the session with the underlying Spawner and records everything exchanged with it.
+func (s *RecordingSpawner) Spawn(command string, args []string, timeout time.Duration, opts ...Option) (*Context, error) {
+	context, err := s.spawner.Spawn(command, args, timeout, opts...)
+	if err != nil || context == nil {
+		return context, err
+	}
+	var expecter expect.Expecter = &recordingExpecter{
+		expecter: *context.GetExpecter(),
+		recorder: s.recorder,
+		session:  sessionName(command, args),
+	}
+	return NewContext(&expecter, context.GetErrorChannel()), nil
+}
+
+// recordingExpecter forwards to an Expecter, recording what is sent and what is matched.
+type recordingExpecter struct {
+	expecter expect.Expecter
+	recorder *TranscriptRecorder
+	session  string
+	// sent is the text sent since the last expectation.
+	sent strings.Builder
+}
+
+func (e *recordingExpecter) record(output string, err error) {
+	entry := &TranscriptEntry{Session: e.session, Execute: e.sent.String(), Output: output}
+	e.sent.Reset()
+	if err != nil {
+		entry.Timeout = isTimeout(err)
+		entry.Error = err.Error()
+	}
+	e.recorder.record(entry)
+}
+
+func (e *recordingExpecter) Expect(re *regexp.Regexp, timeout time.Duration) (string, []string, error) {
+	output, match, err := e.expecter.Expect(re, timeout)
+	e.record(output, err)
+	return output, match, err
+}
+
+func (e *recordingExpecter) ExpectBatch(batchers []expect.Batcher, timeout time.Duration) ([]expect.BatchRes, error) {
+	results, err := e.expecter.ExpectBatch(batchers, timeout)
+	for idx, b := range batchers {
+		switch b.Cmd() {
+		case expect.BatchSend:
+			e.sent.WriteString(b.Arg())
+		case expect.BatchExpect, expect.BatchSwitchCase:
+			if result, ok := batchResult(results, idx); ok {
+				e.record(result.Output, nil)
+				continue
+			}
+			// The batch stops at the first failed expectation.
+			e.record("", err)
+			return results, err
+		}
+	}
+	return results, err
+}
+
+func (e *recordingExpecter) ExpectSwitchCase(cases []expect.Caser, timeout time.Duration) (string, []string, int, error) {
+	output, match, idx, err := e.expecter.ExpectSwitchCase(cases, timeout)
+	e.record(output, err)
+	return output, match, idx, err
+}
+
+func (e *recordingExpecter) Send(s string) error {
+	err := e.expecter.Send(s)
+	if err == nil {
+		e.sent.WriteString(s)
+	}
+	return err
+}
+
+func (e *recordingExpecter) Close() error {
+	return e.expecter.Close()
+}
+
+func batchResult(results []expect.BatchRes, idx int) (expect.BatchRes, bool) {
+	for _, r := range results {
+		if r.Idx == idx {
+			return r, true
+		}
+	}
+	return expect.BatchRes{}, false
+}
+
+func isTimeout(err error) bool {
+	_, ok := err.(expect.TimeoutError)
+	return ok
+}
+
+// ReplaySpawner is a Spawner whose sessions replay a Transcript instead of running commands.
+type ReplaySpawner struct {
+	transcript *Transcript
+}
+
+// NewReplaySpawner creates a ReplaySpawner replaying transcript.
+func NewReplaySpawner(transcript *Transcript) *ReplaySpawner {
+	return &ReplaySpawner{transcript: transcript}
+}
+
+// Spawn creates a session replaying the entries recorded for the same command.
+func (s *ReplaySpawner) Spawn(command string, args []string, timeout time.Duration, opts ...Option) (*Context, error) {
+	var expecter expect.Expecter = &replayExpecter{transcript: s.transcript, session: sessionName(command, args)}
+	// Nothing is ever reported on the error channel since there is no process that could exit.
+	return NewContext(&expecter, make(chan error)), nil
+}
+
+// replayExpecter matches the expectations against the recorded outputs.
+type replayExpecter struct {
+	transcript *Transcript
+	session    string
+	sent       strings.Builder
+}
+
+// next returns the recorded output for the text sent since the last expectation, or the recorded error.
+func (e *replayExpecter) next(timeout time.Duration) (string, error) {
+	execute := e.sent.String()
+	e.sent.Reset()
+	entry, ok := e.transcript.take(e.session, execute)
+	switch {
+	case !ok:
+		return "", fmt.Errorf("no recorded output left in session %q for %q", e.session, execute)
+	case entry.Timeout:
+		return "", expect.TimeoutError(timeout)
+	case entry.Error != "":
+		return "", errors.New(entry.Error)
+	}
+	return entry.Output, nil
+}
+
+// match returns the output up to the end of the first case matching output, as a live session would, or a
+// timeout when no case matches.
+func (e *replayExpecter) match(output string, cases []expect.Caser, timeout time.Duration) (string, []string, int, error) {
+	for i, c := range cases {
+		re, err := c.RE()
+		if err != nil {
+			return "", nil, -1, err
+		}
+		loc := re.FindStringSubmatchIndex(output)
+		if loc == nil {
+			continue
+		}
+		var match []string
+		for j := 0; j < len(loc); j += 2 {
+			if loc[j] >= 0 {
+				match = append(match, output[loc[j]:loc[j+1]])
+			} else {
+				match = append(match, "")
+			}
+		}
+		if tag, status := c.Tag(); tag == expect.FailTag && status != nil {
+			return output[:loc[1]], match, i, status
+		}
+		return output[:loc[1]], match, i, nil
+	}
+	return "", nil, -1, expect.TimeoutError(timeout)
+}
+
+func (e *replayExpecter) Expect(re *regexp.Regexp, timeout time.Duration) (string, []string, error) {
+	output, err := e.next(timeout)
+	if err != nil {
+		return "", nil, err
+	}
+	output, match, _, err := e.match(output, []expect.Caser{&expect.Case{R: re}}, timeout)
+	return output, match, err
+}
+
+func (e *replayExpecter) ExpectBatch(batchers []expect.Batcher, timeout time.Duration) ([]expect.BatchRes, error) {
+	var results []expect.BatchRes
+	for idx, b := range batchers {
+		var cases []expect.Caser
+		switch b.Cmd() {
+		case expect.BatchSend:
+			e.sent.WriteString(b.Arg())
+			continue
+		case expect.BatchExpect:
+			re, err := regexp.Compile(b.Arg())
+			if err != nil {
+				return results, err
+			}
+			cases = []expect.Caser{&expect.Case{R: re}}
+		case expect.BatchSwitchCase:
+			cases = b.Cases()
+		default:
+			continue
+		}
+		output, err := e.next(timeout)
+		if err != nil {
+			return results, err
+		}
+		output, match, caseIdx, err := e.match(output, cases, timeout)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, expect.BatchRes{Idx: idx, CaseIdx: caseIdx, Output: output, Match: match})
+	}
+	return results, nil
+}
+
+func (e *replayExpecter) ExpectSwitchCase(cases []expect.Caser, timeout time.Duration) (string, []string, int, error) {
+	output, err := e.next(timeout)
+	if err != nil {
+		return "", nil, -1, err
+	}
+	return e.match(output, cases, timeout)
+}
+
+func (e *replayExpecter) Send(s string) error {
+	e.sent.WriteString(s)
+	return nil
+}
+
+func (e *replayExpecter) Close() error {
+	return nil
+}
+
+var (
+	transcriptRecorder     *TranscriptRecorder
+	transcriptRecorderOnce sync.Once
+	replayTranscript       *Transcript
+	replayTranscriptOnce   sync.Once
+)
+
+// getTranscriptRecorder returns the recorder writing to the file named by TNF_RECORD_TRANSCRIPT, or nil when
+// recording is disabled.
+func getTranscriptRecorder() *TranscriptRecorder {
+	path := os.Getenv(recordTranscriptEnvironmentVariableKey)
+	if path == "" {
+		return nil
+	}
+	transcriptRecorderOnce.Do(func() {
+		f, err := os.Create(path)
+		if err != nil {
+			log.Fatalf("Failed to create the transcript file %s: %v", path, err)
+		}
+		log.Infof("Recording the sessions to %s", path)
+		transcriptRecorder = NewTranscriptRecorder(f)
+	})
+	return transcriptRecorder
+}
+
+// getReplayTranscript returns the transcript loaded from the file named by TNF_REPLAY_TRANSCRIPT, or nil when
+// replay is disabled.
+func getReplayTranscript() *Transcript {
+	path := os.Getenv(replayTranscriptEnvironmentVariableKey)
+	if path == "" {
+		return nil
+	}
+	replayTranscriptOnce.Do(func() {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open the transcript file %s: %v", path, err)
+		}
+		defer f.Close()
+		replayTranscript, err = LoadTranscript(f)
+		if err != nil {
+			log.Fatalf("Failed to load the transcript file %s: %v", path, err)
+		}
+		log.Infof("Replaying the sessions from %s", path)
+	})
+	return replayTranscript
+}
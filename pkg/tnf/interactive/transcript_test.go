@@ -0,0 +1,118 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package interactive_test
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+)
+
+const testTranscript = `{"session":"oc rsh -n tnf -c test test-0","execute":"hostname\n","output":"test-0\n$ "}
+{"session":"oc rsh -n tnf -c test test-0","execute":"ip addr\n","timeout":true,"error":"expect: timer expired after 2 seconds"}
+{"session":"oc rsh -n tnf -c test test-0","execute":"false\n","error":"exit status 1"}
+`
+
+func TestRecordingSpawner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+	var expecter expect.Expecter = mockExpecter
+	mockSpawner := mock_interactive.NewMockSpawner(ctrl)
+	mockSpawner.EXPECT().Spawn("oc", []string{"rsh", "-n", "tnf", "-c", "test", "test-0"}, testTimeoutDuration).
+		Return(interactive.NewContext(&expecter, make(chan error)), nil)
+
+	batchers := []expect.Batcher{&expect.BSnd{S: "hostname\n"}, &expect.BExp{R: "\\$ "}}
+	mockExpecter.EXPECT().ExpectBatch(batchers, testTimeoutDuration).
+		Return([]expect.BatchRes{{Idx: 1, Output: "test-0\n$ "}}, nil)
+	mockExpecter.EXPECT().Send("ip addr\n").Return(nil)
+	mockExpecter.EXPECT().Expect(gomock.Any(), testTimeoutDuration).Return("", nil, expect.TimeoutError(testTimeoutDuration))
+	mockExpecter.EXPECT().Send("false\n").Return(nil)
+	mockExpecter.EXPECT().Expect(gomock.Any(), testTimeoutDuration).Return("", nil, errors.New("exit status 1"))
+
+	out := &bytes.Buffer{}
+	spawner := interactive.NewRecordingSpawner(mockSpawner, interactive.NewTranscriptRecorder(out))
+	context, err := spawner.Spawn("oc", []string{"rsh", "-n", "tnf", "-c", "test", "test-0"}, testTimeoutDuration)
+	assert.Nil(t, err)
+	recording := *context.GetExpecter()
+
+	_, err = recording.ExpectBatch(batchers, testTimeoutDuration)
+	assert.Nil(t, err)
+	assert.Nil(t, recording.Send("ip addr\n"))
+	_, _, err = recording.Expect(regexp.MustCompile(`\$ `), testTimeoutDuration)
+	assert.NotNil(t, err)
+	assert.Nil(t, recording.Send("false\n"))
+	_, _, err = recording.Expect(regexp.MustCompile(`\$ `), testTimeoutDuration)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, testTranscript, out.String())
+}
+
+func TestReplaySpawner(t *testing.T) {
+	transcript, err := interactive.LoadTranscript(strings.NewReader(testTranscript))
+	assert.Nil(t, err)
+	spawner := interactive.NewReplaySpawner(transcript)
+	context, err := spawner.Spawn("oc", []string{"rsh", "-n", "tnf", "-c", "test", "test-0"}, testTimeoutDuration)
+	assert.Nil(t, err)
+	replay := *context.GetExpecter()
+
+	// Sessions of an unrecorded command have nothing to replay.
+	other, err := spawner.Spawn("oc", []string{"rsh", "-n", "tnf", "-c", "test", "test-1"}, testTimeoutDuration)
+	assert.Nil(t, err)
+	assert.Nil(t, (*other.GetExpecter()).Send("hostname\n"))
+	_, _, err = (*other.GetExpecter()).Expect(regexp.MustCompile(`\$ `), testTimeoutDuration)
+	assert.NotNil(t, err)
+
+	// Entries are matched on the sent text, so they can be replayed out of the recorded order.
+	assert.Nil(t, replay.Send("false\n"))
+	_, _, err = replay.Expect(regexp.MustCompile(`\$ `), testTimeoutDuration)
+	assert.Equal(t, "exit status 1", err.Error())
+
+	matched := ""
+	results, err := replay.ExpectBatch([]expect.Batcher{
+		&expect.BSnd{S: "hostname\n"},
+		&expect.BCas{C: []expect.Caser{
+			&expect.Case{R: regexp.MustCompile(`unknown`), T: expect.OK()},
+			&expect.Case{R: regexp.MustCompile(`(test-\d+)\n`), T: func() (expect.Tag, *expect.Status) {
+				matched = "test-0"
+				return expect.OKTag, expect.NewStatus(0, "")
+			}},
+		}},
+	}, testTimeoutDuration)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-0", matched)
+	assert.Equal(t, []expect.BatchRes{{Idx: 1, CaseIdx: 1, Output: "test-0\n", Match: []string{"test-0\n", "test-0"}}}, results)
+
+	assert.Nil(t, replay.Send("ip addr\n"))
+	_, _, err = replay.Expect(regexp.MustCompile(`\$ `), testTimeoutDuration)
+	assert.IsType(t, expect.TimeoutError(0), err)
+
+	// Every entry is replayed once.
+	assert.Nil(t, replay.Send("hostname\n"))
+	_, _, err = replay.Expect(regexp.MustCompile(`\$ `), testTimeoutDuration)
+	assert.NotNil(t, err)
+	assert.Nil(t, replay.Close())
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+/*
+Package parallel runs the per-target checks of a test case (one per pod, container or node) concurrently.
+A Runner bounds the number of checks in flight and hands back their results in submission order, so the
+claim output does not depend on scheduling. A SessionPool bounds the number of interactive sessions opened
+to a single pod, typically a node debug pod shared by every check targeting that node.
+*/
+package parallel
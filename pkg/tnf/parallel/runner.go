@@ -0,0 +1,185 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package parallel
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+)
+
+// Result holds what a single check reported. Checks must not write to the claim directly since they run
+// concurrently; they record messages here and the caller prints them once all the checks are done.
+type Result struct {
+	// Key identifies the target of the check, e.g. "namespace/pod/container".
+	Key string
+	// Messages are the lines to be printed to the claim for this target, in the order they were recorded.
+	Messages []string
+	// Failed is set when the target is not compliant.
+	Failed bool
+	// Err is set when the check could not be completed.
+	Err error
+	// reason is the message explaining Failed or Err.
+	reason string
+}
+
+// Printf records an informational message.
+func (r *Result) Printf(format string, args ...interface{}) {
+	r.Messages = append(r.Messages, fmt.Sprintf(format, args...))
+}
+
+// Failf records a message and marks the target as non compliant.
+func (r *Result) Failf(format string, args ...interface{}) {
+	r.Printf(format, args...)
+	if !r.Failed && r.Err == nil {
+		r.reason = r.Messages[len(r.Messages)-1]
+	}
+	r.Failed = true
+}
+
+// Errorf records a message and marks the check as errored. The message is used as the error when err is nil.
+func (r *Result) Errorf(err error, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	r.Messages = append(r.Messages, message)
+	if err == nil {
+		err = errors.New(message)
+	}
+	if r.Err == nil {
+		r.reason = message
+	}
+	r.Err = err
+}
+
+// Compliance returns the status of the target for the claim, with the first failure or error message as the
+// reason. An error takes precedence over a failure.
+func (r *Result) Compliance() (status tnf.ComplianceStatus, reason string) {
+	switch {
+	case r.Err != nil:
+		if r.reason == "" {
+			return tnf.CheckError, r.Err.Error()
+		}
+		return tnf.CheckError, r.reason
+	case r.Failed:
+		return tnf.NonCompliant, r.reason
+	default:
+		return tnf.Compliant, ""
+	}
+}
+
+// Results is the ordered list of results returned by Runner.Run.
+type Results []*Result
+
+// FailedKeys returns the keys of the non compliant targets.
+func (rs Results) FailedKeys() []string {
+	var keys []string
+	for _, r := range rs {
+		if r.Failed {
+			keys = append(keys, r.Key)
+		}
+	}
+	return keys
+}
+
+// ErroredKeys returns the keys of the targets that could not be checked.
+func (rs Results) ErroredKeys() []string {
+	var keys []string
+	for _, r := range rs {
+		if r.Err != nil {
+			keys = append(keys, r.Key)
+		}
+	}
+	return keys
+}
+
+// Print passes every recorded message to printf, target after target in submission order.
+func (rs Results) Print(printf func(format string, args ...interface{})) {
+	for _, r := range rs {
+		for _, m := range r.Messages {
+			printf("%s", m)
+		}
+	}
+}
+
+// Check is a single per-target check. It reports through the Result it is given.
+type Check func(result *Result)
+
+type task struct {
+	key   string
+	check Check
+}
+
+// Runner runs checks on at most maxWorkers goroutines.
+type Runner struct {
+	maxWorkers int
+	tasks      []task
+}
+
+// NewRunner creates a Runner running at most maxWorkers checks at a time. A value lower than 1 runs the
+// checks one after the other.
+func NewRunner(maxWorkers int) *Runner {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	return &Runner{maxWorkers: maxWorkers}
+}
+
+// Add queues a check for the target identified by key.
+func (r *Runner) Add(key string, check Check) {
+	r.tasks = append(r.tasks, task{key: key, check: check})
+}
+
+// Run runs all the queued checks and waits for them. The results are in the order the checks were added,
+// whatever order they completed in. A check that panics (e.g. a failed gomega assertion) is reported as
+// an errored result instead of taking the whole test run down.
+func (r *Runner) Run() Results {
+	results := make(Results, len(r.tasks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	workers := r.maxWorkers
+	if workers > len(r.tasks) {
+		workers = len(r.tasks)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runCheck(r.tasks[i])
+			}
+		}()
+	}
+	for i := range r.tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	r.tasks = nil
+	return results
+}
+
+func runCheck(t task) (result *Result) {
+	result = &Result{Key: t.key}
+	defer func() {
+		if rec := recover(); rec != nil {
+			result.Errorf(fmt.Errorf("%v", rec), "ERROR: check on %s aborted: %v", t.key, rec)
+		}
+	}()
+	t.check(result)
+	return result
+}
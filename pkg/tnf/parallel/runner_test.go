@@ -0,0 +1,161 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package parallel_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
+)
+
+func TestRunnerOrder(t *testing.T) {
+	testCases := []struct {
+		maxWorkers int
+		checks     int
+	}{
+		{maxWorkers: 0, checks: 5},
+		{maxWorkers: 1, checks: 5},
+		{maxWorkers: 4, checks: 20},
+		{maxWorkers: 50, checks: 3},
+		{maxWorkers: 4, checks: 0},
+	}
+
+	for _, tc := range testCases {
+		runner := parallel.NewRunner(tc.maxWorkers)
+		for i := 0; i < tc.checks; i++ {
+			i := i
+			runner.Add(fmt.Sprintf("target-%d", i), func(result *parallel.Result) {
+				// Make the first checks the slowest ones so they complete last.
+				time.Sleep(time.Duration(tc.checks-i) * time.Millisecond)
+				result.Printf("checked target %d", i)
+			})
+		}
+		results := runner.Run()
+		assert.Len(t, results, tc.checks)
+		var printed []string
+		results.Print(func(format string, args ...interface{}) {
+			printed = append(printed, fmt.Sprintf(format, args...))
+		})
+		for i := 0; i < tc.checks; i++ {
+			assert.Equal(t, fmt.Sprintf("target-%d", i), results[i].Key)
+			assert.Equal(t, fmt.Sprintf("checked target %d", i), printed[i])
+		}
+	}
+}
+
+func TestRunnerMaxWorkers(t *testing.T) {
+	const maxWorkers = 3
+	var running, maxRunning int32
+	runner := parallel.NewRunner(maxWorkers)
+	for i := 0; i < 12; i++ {
+		runner.Add(fmt.Sprint(i), func(result *parallel.Result) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	runner.Run()
+	assert.LessOrEqual(t, maxRunning, int32(maxWorkers))
+	assert.Greater(t, maxRunning, int32(1))
+}
+
+func TestRunnerResults(t *testing.T) {
+	errCheck := errors.New("session timed out")
+	runner := parallel.NewRunner(2)
+	runner.Add("ok", func(result *parallel.Result) {
+		result.Printf("ok is fine")
+	})
+	runner.Add("bad", func(result *parallel.Result) {
+		result.Failf("FAILURE: bad is not compliant")
+	})
+	runner.Add("err", func(result *parallel.Result) {
+		result.Errorf(errCheck, "ERROR: err could not be checked")
+	})
+	runner.Add("panic", func(result *parallel.Result) {
+		panic("assertion failed")
+	})
+	runner.Add("unknown", func(result *parallel.Result) {
+		result.Errorf(nil, "ERROR: unknown could not be checked")
+	})
+	results := runner.Run()
+
+	assert.Equal(t, []string{"bad"}, results.FailedKeys())
+	assert.Equal(t, []string{"err", "panic", "unknown"}, results.ErroredKeys())
+	assert.EqualError(t, results[4].Err, "ERROR: unknown could not be checked")
+	assert.Equal(t, errCheck, results[2].Err)
+	assert.Equal(t, []string{"ERROR: check on panic aborted: assertion failed"}, results[3].Messages)
+}
+
+func TestResultCompliance(t *testing.T) {
+	testCases := map[string]struct {
+		check          parallel.Check
+		expectedStatus tnf.ComplianceStatus
+		expectedReason string
+	}{
+		"compliant": {
+			check:          func(result *parallel.Result) { result.Printf("all good") },
+			expectedStatus: tnf.Compliant,
+		},
+		"first_failure_is_the_reason": {
+			check: func(result *parallel.Result) {
+				result.Printf("checking")
+				result.Failf("first failure")
+				result.Failf("second failure")
+			},
+			expectedStatus: tnf.NonCompliant,
+			expectedReason: "first failure",
+		},
+		"error_wins_over_failure": {
+			check: func(result *parallel.Result) {
+				result.Failf("failure")
+				result.Errorf(nil, "error")
+				result.Failf("another failure")
+			},
+			expectedStatus: tnf.CheckError,
+			expectedReason: "error",
+		},
+		"panic": {
+			check:          func(result *parallel.Result) { panic("boom") },
+			expectedStatus: tnf.CheckError,
+			expectedReason: "ERROR: check on panic aborted: boom",
+		},
+	}
+	for name, tc := range testCases {
+		runner := parallel.NewRunner(1)
+		runner.Add("panic", tc.check)
+		status, reason := runner.Run()[0].Compliance()
+		assert.Equal(t, tc.expectedStatus, status, name)
+		assert.Equal(t, tc.expectedReason, reason, name)
+	}
+
+	// Err set without Errorf.
+	status, reason := (&parallel.Result{Err: errors.New("direct")}).Compliance()
+	assert.Equal(t, tnf.CheckError, status)
+	assert.Equal(t, "direct", reason)
+}
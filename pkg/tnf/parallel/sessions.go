@@ -0,0 +1,133 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package parallel
+
+import (
+	"sync"
+
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+)
+
+// SessionFactory opens a new interactive session.
+type SessionFactory func() (*interactive.Oc, error)
+
+// SessionPool lends at most size sessions opened with the same factory. Sessions are opened on demand
+// and kept open for reuse until Close is called.
+type SessionPool struct {
+	factory SessionFactory
+	// slots holds one token per session that may be lent; Acquire blocks when it is empty.
+	slots chan struct{}
+	mutex sync.Mutex
+	idle  []*interactive.Oc
+	all   map[*interactive.Oc]bool
+}
+
+// NewSessionPool creates a SessionPool lending at most size sessions at a time. A value lower than 1
+// allows a single session.
+func NewSessionPool(size int, factory SessionFactory) *SessionPool {
+	if size < 1 {
+		size = 1
+	}
+	p := &SessionPool{
+		factory: factory,
+		slots:   make(chan struct{}, size),
+		all:     map[*interactive.Oc]bool{},
+	}
+	for i := 0; i < size; i++ {
+		p.slots <- struct{}{}
+	}
+	return p
+}
+
+// Acquire lends a session, blocking until one is available. The session must be given back with Release,
+// or with Discard if it can't be used anymore.
+func (p *SessionPool) Acquire() (*interactive.Oc, error) {
+	<-p.slots
+	p.mutex.Lock()
+	if n := len(p.idle); n > 0 {
+		oc := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mutex.Unlock()
+		return oc, nil
+	}
+	p.mutex.Unlock()
+
+	opened := false
+	defer func() {
+		// Give the slot back if the session could not be opened, including when the factory panics.
+		if !opened {
+			p.slots <- struct{}{}
+		}
+	}()
+	oc, err := p.factory()
+	if err != nil {
+		return nil, err
+	}
+	opened = true
+	p.mutex.Lock()
+	p.all[oc] = true
+	p.mutex.Unlock()
+	return oc, nil
+}
+
+// Release gives back a session lent by Acquire.
+func (p *SessionPool) Release(oc *interactive.Oc) {
+	p.mutex.Lock()
+	p.idle = append(p.idle, oc)
+	p.mutex.Unlock()
+	p.slots <- struct{}{}
+}
+
+// Discard closes a session lent by Acquire, e.g. after a timeout left it in an unknown state. A new
+// session will be opened in its place when needed.
+func (p *SessionPool) Discard(oc *interactive.Oc) {
+	p.mutex.Lock()
+	delete(p.all, oc)
+	p.mutex.Unlock()
+	oc.Close()
+	p.slots <- struct{}{}
+}
+
+// Close closes all the sessions opened by the pool. It must not be called while sessions are lent.
+func (p *SessionPool) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for oc := range p.all {
+		oc.Close()
+	}
+	p.all = map[*interactive.Oc]bool{}
+	p.idle = nil
+}
+
+// Use runs fn with a lent session. The session is given back to the pool once fn returns, unless fn
+// returns false or panics in which case it is discarded.
+func (p *SessionPool) Use(fn func(oc *interactive.Oc) (keep bool)) error {
+	oc, err := p.Acquire()
+	if err != nil {
+		return err
+	}
+	keep := false
+	defer func() {
+		if keep {
+			p.Release(oc)
+		} else {
+			p.Discard(oc)
+		}
+	}()
+	keep = fn(oc)
+	return nil
+}
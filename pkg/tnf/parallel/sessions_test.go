@@ -0,0 +1,145 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package parallel_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	expect "github.com/google/goexpect"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	mock_interactive "github.com/test-network-function/test-network-function/pkg/tnf/interactive/mocks"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
+)
+
+// newTestSessionFactory returns a factory of Oc sessions backed by mocks, and a counter of the sessions it
+// opened. closed receives the sessions once they are closed.
+func newTestSessionFactory(t *testing.T, ctrl *gomock.Controller, closed chan<- *interactive.Oc) (parallel.SessionFactory, *int) {
+	opened := 0
+	var mutex sync.Mutex
+	return func() (*interactive.Oc, error) {
+		mockExpecter := mock_interactive.NewMockExpecter(ctrl)
+		mockExpecter.EXPECT().Close().AnyTimes().Return(nil)
+		var expecter expect.Expecter = mockExpecter
+		mockSpawner := mock_interactive.NewMockSpawner(ctrl)
+		mockSpawner.EXPECT().Spawn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(interactive.NewContext(&expecter, nil), nil)
+		var spawner interactive.Spawner = mockSpawner
+		oc, _, err := interactive.SpawnOc(&spawner, "debug", "container-00", "default", time.Second)
+		assert.Nil(t, err)
+		go func() {
+			<-oc.GetDoneChannel()
+			closed <- oc
+		}()
+		mutex.Lock()
+		opened++
+		mutex.Unlock()
+		return oc, nil
+	}, &opened
+}
+
+func TestSessionPoolReuse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	closed := make(chan *interactive.Oc, 10)
+	factory, opened := newTestSessionFactory(t, ctrl, closed)
+
+	pool := parallel.NewSessionPool(2, factory)
+	first, err := pool.Acquire()
+	assert.Nil(t, err)
+	second, err := pool.Acquire()
+	assert.Nil(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, *opened)
+
+	// The pool is exhausted, the next Acquire waits for a Release.
+	acquired := make(chan *interactive.Oc)
+	go func() {
+		oc, _ := pool.Acquire()
+		acquired <- oc
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "Acquire did not block on an exhausted pool")
+	case <-time.After(20 * time.Millisecond):
+	}
+	pool.Release(first)
+	assert.Same(t, first, <-acquired)
+	assert.Equal(t, 2, *opened)
+
+	// A discarded session is closed and replaced by a new one.
+	pool.Discard(second)
+	assert.Same(t, second, <-closed)
+	third, err := pool.Acquire()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, *opened)
+
+	pool.Release(first)
+	pool.Release(third)
+	pool.Close()
+	closedSessions := []*interactive.Oc{<-closed, <-closed}
+	assert.ElementsMatch(t, []*interactive.Oc{first, third}, closedSessions)
+}
+
+func TestSessionPoolFactoryError(t *testing.T) {
+	errSpawn := errors.New("unable to spawn oc")
+	pool := parallel.NewSessionPool(1, func() (*interactive.Oc, error) {
+		return nil, errSpawn
+	})
+	for i := 0; i < 2; i++ {
+		// A failed Acquire must not consume the only slot of the pool.
+		oc, err := pool.Acquire()
+		assert.Nil(t, oc)
+		assert.Equal(t, errSpawn, err)
+	}
+}
+
+func TestSessionPoolUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	closed := make(chan *interactive.Oc, 10)
+	factory, opened := newTestSessionFactory(t, ctrl, closed)
+	pool := parallel.NewSessionPool(1, factory)
+
+	var used []*interactive.Oc
+	for _, keep := range []bool{true, false, true} {
+		keep := keep
+		err := pool.Use(func(oc *interactive.Oc) bool {
+			used = append(used, oc)
+			return keep
+		})
+		assert.Nil(t, err)
+	}
+	// The first session is reused once, then discarded and replaced.
+	assert.Same(t, used[0], used[1])
+	assert.Same(t, used[1], <-closed)
+	assert.NotSame(t, used[1], used[2])
+	assert.Equal(t, 2, *opened)
+
+	// A panicking fn must not leak the session slot.
+	assert.Panics(t, func() {
+		_ = pool.Use(func(oc *interactive.Oc) bool {
+			panic("assertion failed")
+		})
+	})
+	assert.Same(t, used[2], <-closed)
+	assert.Nil(t, pool.Use(func(oc *interactive.Oc) bool { return true }))
+	assert.Equal(t, 3, *opened)
+}
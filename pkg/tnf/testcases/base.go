@@ -19,12 +19,9 @@ package testcases
 import (
 	"encoding/json"
 	"os"
-	"regexp"
-	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases/data/cnf"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases/data/operator"
 	"gopkg.in/yaml.v2"
 )
 
@@ -88,8 +85,6 @@ const (
 	PrivilegedPod = "PRIVILEGED_POD"
 	// PrivilegedRoles is name of the test case template for running cluster roles and permission tests
 	PrivilegedRoles = "PRIVILEGED_ROLE"
-	// OperatorStatus checks if csv for a given operator is installed
-	OperatorStatus = "OPERATOR_STATUS"
 )
 
 // PodFactType type to hold container fact types
@@ -130,9 +125,7 @@ var PodTestTemplateDataMap = map[string]string{
 }
 
 // OperatorTestTemplateDataMap  is map of available json data test case templates
-var OperatorTestTemplateDataMap = map[string]string{
-	OperatorStatus: operator.OperatorJSON,
-}
+var OperatorTestTemplateDataMap = map[string]string{}
 
 // CnfTestTemplateFileMap is map of configured test case filenames
 var CnfTestTemplateFileMap = map[string]string{
@@ -143,8 +136,7 @@ var CnfTestTemplateFileMap = map[string]string{
 
 // OperatorTestTemplateFileMap is map of configured test case filenames
 var OperatorTestTemplateFileMap = map[string]string{
-	OperatorStatus: "operatorstatus.yml",
-	"ReadMeTxt":    "readme.txt",
+	"ReadMeTxt": "readme.txt",
 }
 
 // RegExType holds regex constant name.
@@ -333,19 +325,6 @@ func (b *BaseTestCase) ReplaceSAasExpectedStatus(index int, val string) {
 	b.ExpectedStatus[index] = val
 }
 
-// IsInFocus matches ginkgo focus strings to description key
-func IsInFocus(focus []string, desc string) bool {
-	matchesFocus := true
-	var focusFilter *regexp.Regexp
-	if len(focus) > 0 {
-		focusFilter = regexp.MustCompile(strings.Join(focus, "|"))
-	}
-	if focusFilter != nil {
-		matchesFocus = focusFilter.MatchString(desc)
-	}
-	return matchesFocus
-}
-
 // GetConfiguredPodTests loads the `configuredTestFile` and extracts
 // the names of test groups from it.
 func GetConfiguredPodTests() (cnfTests []string) {
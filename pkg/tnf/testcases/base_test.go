@@ -34,16 +34,15 @@ var (
 )
 
 const (
-	filePerm         = 0644
-	testTempFile     = "testconfigure.yml"
-	InValidData      = "INVALID_DATA"
-	InValidKey       = "INVALID_KEY"
-	cnfFilePath      = "./files/cnf"
-	operatorFilePath = "./files/operator"
-	name             = "testpod"
-	invalidFilePath  = "./invalid"
-	inValidFile      = "dummy.yaml"
-	allowAll         = `.+`
+	filePerm        = 0644
+	testTempFile    = "testconfigure.yml"
+	InValidData     = "INVALID_DATA"
+	InValidKey      = "INVALID_KEY"
+	cnfFilePath     = "./files/cnf"
+	name            = "testpod"
+	invalidFilePath = "./invalid"
+	inValidFile     = "dummy.yaml"
+	allowAll        = `.+`
 )
 
 func setup() {
@@ -93,24 +92,12 @@ func TestLoadCNFPodGatherFactsTestCaseSpecs(t *testing.T) {
 	assert.NotNil(t, testCase)
 }
 
-func TestLoadOperatorOperatorStatusTestCaseSpecs(t *testing.T) {
-	testCase, err := testcases.LoadOperatorTestCaseSpecs(testcases.OperatorStatus)
-	assert.Nil(t, err)
-	assert.NotNil(t, testCase)
-}
-
 func TestLoadCNFTestCaseSpecsFromFile(t *testing.T) {
 	testCase, err := testcases.LoadTestCaseSpecsFromFile(testcases.PrivilegedRoles, cnfFilePath, testcases.Cnf)
 	assert.Nil(t, err)
 	assert.NotNil(t, testCase)
 }
 
-func TestLoadOperatorTestCaseSpecsFromFile(t *testing.T) {
-	testCase, err := testcases.LoadTestCaseSpecsFromFile(testcases.OperatorStatus, operatorFilePath, testcases.Operator)
-	assert.Nil(t, err)
-	assert.NotNil(t, testCase)
-}
-
 func TestLoadInvalidPathCNFTestCaseSpecsFromFile(t *testing.T) {
 	testCase, err := testcases.LoadTestCaseSpecsFromFile(testcases.PrivilegedRoles, invalidFilePath, testcases.Cnf)
 	assert.NotNil(t, err)
@@ -135,11 +122,10 @@ func TestConfiguredTest_Operator_RenderTestCaseSpec(t *testing.T) {
 	var c = testcases.ConfiguredTest{}
 	c.Name = "OPERATOR_STATUS"
 	c.Tests = []string{"CSV_INSTALLED", "CSV_SCC"}
-	b, err := c.RenderTestCaseSpec(testcases.Operator, testcases.OperatorStatus)
-	assert.Nil(t, err)
-	assert.NotNil(t, b)
-	assert.Equal(t, "CSV_INSTALLED", b.TestCase[0].Name)
-	assert.Equal(t, "CSV_SCC", b.TestCase[1].Name)
+	// The operator checks are built in pkg/olm, there are no operator test templates left.
+	b, err := c.RenderTestCaseSpec(testcases.Operator, c.Name)
+	assert.NotNil(t, err)
+	assert.Nil(t, b)
 
 	c.Name = "PRIVILEGED_POD"
 	c.Tests = []string{"HOST_NETWORK_CHECK"}
@@ -29,6 +29,7 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/containerruntime"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodedebug"
@@ -166,24 +167,25 @@ func NewGenericTesterAndValidate(templateFile, schemaPath string, values map[str
 // returns the raw output of the command
 func RunCommandInContainerNameSpace(nodeName string, nodeOc *interactive.Oc, containerID, command string, timeout time.Duration, runtime string) string {
 	containrPID := GetContainerPID(nodeName, nodeOc, containerID, runtime)
-	nodeCommand := "nsenter -t " + containrPID + " -n " + command
+	nodeCommand := getRuntime(runtime).NamespaceCommand(containrPID, command)
 	return RunCommandInNode(nodeName, nodeOc, nodeCommand, timeout)
 }
 
 // GetContainerPID gets the container PID from a kubernetes node, Oc and container PID
 func GetContainerPID(nodeName string, nodeOc *interactive.Oc, containerID, runtime string) string {
-	command := ""
-	switch runtime {
-	case "docker": //nolint:goconst // used only once
-		command = "chroot /host docker inspect -f '{{.State.Pid}}' " + containerID + " 2>/dev/null"
-	case "cri-o": //nolint:goconst // used only once
-		command = "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' " + containerID + " 2>/dev/null"
-	default:
-		ginkgo.Skip(fmt.Sprintf("Container runtime %s not supported yet for this test, skipping", runtime))
-	}
+	command := getRuntime(runtime).PIDCommand(containerID)
 	return RunCommandInNode(nodeName, nodeOc, command, timeoutPid)
 }
 
+// getRuntime returns the container runtime called name, skipping the test when it is not supported.
+func getRuntime(name string) containerruntime.Runtime {
+	rt, err := containerruntime.Get(name)
+	if err != nil {
+		ginkgo.Skip(fmt.Sprintf("%v, skipping", err))
+	}
+	return rt
+}
+
 func GetModulesFromNode(nodeName string, nodeOc *interactive.Oc) []string {
 	// Get the 1st column list of the modules running on the node.
 	// Split on the return/newline and get the list of the modules back.
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function/pkg/containerruntime"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
 )
 
@@ -186,3 +187,31 @@ func TestGetModulesFromNode(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, GetModulesFromNode("testNode", nil))
 	}
 }
+
+func TestGetContainerPID(t *testing.T) {
+	restore := containerruntime.Register(&containerruntime.Mock{PID: "42"})
+	defer restore()
+	testCases := []struct {
+		runtime         string
+		expectedCommand string
+	}{
+		{runtime: "mock", expectedCommand: "echo 42"},
+		{runtime: containerruntime.CRIO, expectedCommand: "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' abc 2>/dev/null"},
+		{runtime: containerruntime.Containerd, expectedCommand: "chroot /host crictl inspect --output go-template --template '{{.info.pid}}' abc 2>/dev/null"},
+		{runtime: containerruntime.Docker, expectedCommand: "chroot /host docker inspect -f '{{.State.Pid}}' abc 2>/dev/null"},
+	}
+
+	origFunc := RunCommandInNode
+	defer func() {
+		RunCommandInNode = origFunc
+	}()
+	for _, tc := range testCases {
+		var command string
+		RunCommandInNode = func(nodeName string, nodeOc *interactive.Oc, cmd string, timeout time.Duration) string {
+			command = cmd
+			return "42"
+		}
+		assert.Equal(t, "42", GetContainerPID("testNode", nil, "abc", tc.runtime))
+		assert.Equal(t, tc.expectedCommand, command)
+	}
+}
@@ -4,11 +4,14 @@ set -x
 export OUTPUT_LOC="$PWD/test-network-function"
 
 usage() {
-	echo "$0 [-o OUTPUT_LOC] [-f SUITE...] -s [SUITE...] [-l LABEL...]"
+	echo "$0 [-o OUTPUT_LOC] [-f SUITE...] -s [SUITE...] [-l LABEL...] [-d] [-r]"
 	echo "Call the script and list the test suites to run"
 	echo "  e.g."
 	echo "    $0 [ARGS] -f access-control lifecycle"
 	echo "  will run the access-control and lifecycle suites"
+	echo "  -f and -s take suite keys and test IDs, -l takes label filter expressions,"
+	echo "  -d lists the selected tests without running them"
+	echo "  -r resumes an interrupted run from the partial claim in OUTPUT_LOC"
 	echo ""
 	echo "Allowed suites are listed in the README."
 }
@@ -21,6 +24,8 @@ usage_error() {
 FOCUS=""
 SKIP=""
 LABEL=""
+DRY_RUN=""
+RESUME=""
 BASEDIR=$(dirname $(realpath $0))
 # Parge args beginning with "-"
 while [[ $1 == -* ]]; do
@@ -34,19 +39,21 @@ while [[ $1 == -* ]]; do
 			fi ;;
 		-s|--skip)
         	while (( "$#" >= 2 )) && ! [[ $2 = --* ]] && ! [[ $2 = -* ]] ; do
-    			SKIP="$2|$SKIP"
+    			SKIP="$2,$SKIP"
           		shift
         	done;;
 		-f|--focus)
     	    while (( "$#" >= 2 )) && ! [[ $2 = --* ]]  && ! [[ $2 = -* ]] ; do
-        		FOCUS="$2|$FOCUS"
+        		FOCUS="$2,$FOCUS"
         		shift
         	done;;
         -l|--label)
             while (( "$#" >= 2 )) && ! [[ $2 = --* ]]  && ! [[ $2 = -* ]] ; do
-                LABEL="$2|$LABEL"
+                LABEL="$2,$LABEL"
                 shift
             done;;
+		-d|--dry-run) DRY_RUN="-dry-run";;
+		-r|--resume) RESUME="-resume";;
     	-*) echo "invalid option: $1" 1>&2; usage_error;;
 	esac
 	shift
@@ -69,9 +76,14 @@ trap html_output EXIT
 # If no focus is set then display usage and quit with a non-zero exit code.
 [ -z "$FOCUS" ] && echo "no focus found" && usage_error
 
-FOCUS=${FOCUS%?}  # strip the trailing "|" from the concatenation
-SKIP=${SKIP%?} # strip the trailing "|" from the concatenation
-LABEL=${LABEL%?} # strip the trailing "|" from the concatenation
+FOCUS=${FOCUS%?}  # strip the trailing "," from the concatenation
+SKIP=${SKIP%?} # strip the trailing "," from the concatenation
+LABEL=${LABEL%?} # strip the trailing "," from the concatenation
+
+if [ -n "$DRY_RUN" ]; then
+	cd ./test-network-function && ./test-network-function.test -focus="$FOCUS" -skip="$SKIP" -label-filter="$LABEL" $DRY_RUN
+	exit $?
+fi
 
 res=`oc version | grep  Server`
 if [ -z "$res" ]
@@ -98,13 +110,5 @@ echo "Running with skip  '$SKIP'"
 echo "Running with label filter '$LABEL'"
 echo "Report will be output to '$OUTPUT_LOC'"
 echo "ginkgo arguments '${GINKGO_ARGS}'"
-SKIP_STRING=""
-LABEL_STRING=""
-if [ -n "$SKIP" ]; then
-	SKIP_STRING=-ginkgo.skip="$SKIP"
-fi
-if [ -n "$LABEL" ]; then
-    LABEL_STRING=-ginkgo.label-filter="$LABEL"
-fi
 
-cd ./test-network-function && ./test-network-function.test -ginkgo.focus="$FOCUS" $SKIP_STRING $LABEL_STRING ${GINKGO_ARGS}
+cd ./test-network-function && ./test-network-function.test -focus="$FOCUS" -skip="$SKIP" -label-filter="$LABEL" $RESUME ${GINKGO_ARGS}
@@ -23,20 +23,28 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/podsecurity"
+	"github.com/test-network-function/test-network-function/pkg/rbac"
+	"github.com/test-network-function/test-network-function/pkg/scc"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/automountservice"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/clusterrolebinding"
 	containerpkg "github.com/test-network-function/test-network-function/pkg/tnf/handlers/container"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rolebinding"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/pkg/utils"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -45,6 +53,9 @@ const (
 
 	// ocGetCrNamespaceFormat is the "oc get" format string to get the namespaced-only resources created for a given CRD.
 	ocGetCrNamespaceFormat = "oc get %s -A -o go-template='{{range .items}}{{if .metadata.namespace}}{{.metadata.name}},{{.metadata.namespace}}{{\"\n\"}}{{end}}{{end}}'"
+
+	// defaultServiceAccount is the service account of the pods that do not set one.
+	defaultServiceAccount = "default"
 )
 
 var (
@@ -57,8 +68,7 @@ var (
 )
 
 var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, common.AccessControlTestKey) {
+	if selection.IsSuiteSelected(common.AccessControlTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -73,6 +83,12 @@ var _ = ginkgo.Describe(common.AccessControlTestKey, func() {
 
 		testRoles(env)
 
+		testPodSecurity(env)
+
+		testSCC(env)
+
+		testServiceAccountRBAC(env)
+
 		defer ginkgo.GinkgoRecover()
 
 		// Run the tests that interact with the pods
@@ -113,74 +129,51 @@ func addFailedTcInfo(failedTcs map[string][]failedTcInfo, tc, pod, ns string, co
 
 //nolint:gocritic,funlen // ignore hugeParam error. Pointers to loop iterator vars are bad and `testCmd` is likely to be such.
 func runTestOnPods(env *config.TestEnvironment, testCmd testcases.BaseTestCase, testType string) {
-	const noContainerIdx = -1
 	testID := identifiers.XformToGinkgoItIdentifierExtended(identifiers.TestHostResourceIdentifier, testCmd.Name)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
-		context := env.GetLocalShellContext()
-		failedTcs := map[string][]failedTcInfo{} // maps a pod name to a slice of failed TCs
-		for _, podUnderTest := range env.PodsUnderTest {
-			podName := podUnderTest.Name
-			podNamespace := podUnderTest.Namespace
+		runner := parallel.NewRunner(common.ParallelWorkers())
+		podFailedTcs := make([][]failedTcInfo, len(env.PodsUnderTest))
+		for i, podUnderTest := range env.PodsUnderTest {
+			podTestCmd := testCmd
 			if testCmd.ExpectedType == testcases.Function {
+				// Each pod gets its own copy of the expectations since they are rendered with the pod name.
+				podTestCmd.ExpectedStatus = append([]string{}, testCmd.ExpectedStatus...)
 				for _, val := range testCmd.ExpectedStatus {
-					testCmd.ExpectedStatusFn(podName, testcases.StatusFunctionType(val))
+					podTestCmd.ExpectedStatusFn(podUnderTest.Name, testcases.StatusFunctionType(val))
 				}
 			}
-			testType := testType
-			testCmd := testCmd
-			var args []interface{}
-			if testType == testcases.PrivilegedRoles {
-				args = []interface{}{podUnderTest.Namespace, podUnderTest.Namespace, podUnderTest.ServiceAccount}
-			} else {
-				args = []interface{}{podUnderTest.Name, podUnderTest.Namespace}
+			podUnderTest := podUnderTest
+			failedTcs := &podFailedTcs[i]
+			ginkgo.By(fmt.Sprintf("Executing TC %s on pod %s (ns %s)", testCmd.Name, podUnderTest.Name, podUnderTest.Namespace))
+			runner.Add(podUnderTest.Name, func(result *parallel.Result) {
+				// Each check needs its own local shell, the environment one can't be shared between goroutines.
+				context := interactive.GetContext(common.LogLevelTraceEnabled)
+				defer func() {
+					if err := (*context.GetExpecter()).Close(); err != nil {
+						log.Warnf("Failed to close local shell context due to %v", err)
+					}
+				}()
+				*failedTcs = runTestOnPod(context, podUnderTest, podTestCmd, testType, result)
+			})
+		}
+		results := runner.Run()
+		results.Print(tnf.ClaimFilePrintf)
+
+		failedTcs := map[string][]failedTcInfo{} // maps a pod name to a slice of failed TCs
+		for i, podUnderTest := range env.PodsUnderTest {
+			status, reason := results[i].Compliance()
+			if !tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, status, reason).Failed() {
+				continue
 			}
-			var count int
-			if testCmd.Loop > 0 {
-				count = podUnderTest.ContainerCount
-			} else {
-				count = testCmd.Loop
+			for _, tc := range podFailedTcs[i] {
+				addFailedTcInfo(failedTcs, tc.tc, podUnderTest.Name, tc.ns, tc.containerIdx)
 			}
-
-			if count > 0 {
-				count := 0
-				for count < podUnderTest.ContainerCount {
-					ginkgo.By(fmt.Sprintf("Executing TC %s on pod %s (ns %s), container index %d", testCmd.Name, podNamespace, podName, count))
-					argsCount := append(args, count)
-					cmd := fmt.Sprintf(testCmd.Command, argsCount...)
-					cmdArgs := strings.Split(cmd, " ")
-					cnfInTest := containerpkg.NewPod(cmdArgs, podUnderTest.Name, podUnderTest.Namespace, testCmd.ExpectedStatus, testCmd.ResultType, testCmd.Action, common.DefaultTimeout)
-					gomega.Expect(cnfInTest).ToNot(gomega.BeNil())
-					test, err := tnf.NewTest(context.GetExpecter(), cnfInTest, []reel.Handler{cnfInTest}, context.GetErrorChannel())
-					gomega.Expect(err).To(gomega.BeNil())
-					gomega.Expect(test).ToNot(gomega.BeNil())
-					test.RunWithCallbacks(nil, func() {
-						tnf.ClaimFilePrintf("FAILURE: Command sent: %s, Expectations: %v", cmd, testCmd.ExpectedStatus)
-						addFailedTcInfo(failedTcs, testCmd.Name, podName, podNamespace, count)
-					}, func(e error) {
-						tnf.ClaimFilePrintf("ERROR: Command sent: %s, Expectations: %v, Error: %v", cmd, testCmd.ExpectedStatus, e)
-						addFailedTcInfo(failedTcs, testCmd.Name, podName, podNamespace, count)
-					})
-					count++
-				}
-			} else {
-				ginkgo.By(fmt.Sprintf("Executing TC %s on pod %s (ns %s)", testCmd.Name, podNamespace, podName))
-				cmd := fmt.Sprintf(testCmd.Command, args...)
-				cmdArgs := strings.Split(cmd, " ")
-				podTest := containerpkg.NewPod(cmdArgs, podUnderTest.Name, podUnderTest.Namespace, testCmd.ExpectedStatus, testCmd.ResultType, testCmd.Action, common.DefaultTimeout)
-				gomega.Expect(podTest).ToNot(gomega.BeNil())
-				test, err := tnf.NewTest(context.GetExpecter(), podTest, []reel.Handler{podTest}, context.GetErrorChannel())
-				gomega.Expect(err).To(gomega.BeNil())
-				gomega.Expect(test).ToNot(gomega.BeNil())
-				test.RunWithCallbacks(nil, func() {
-					tnf.ClaimFilePrintf("FAILURE: Command sent: %s, Expectations: %v", cmd, testCmd.ExpectedStatus)
-					addFailedTcInfo(failedTcs, testCmd.Name, podName, podNamespace, noContainerIdx)
-				}, func(e error) {
-					tnf.ClaimFilePrintf("ERROR: Command sent: %s, Expectations: %v, Error: %v", cmd, testCmd.ExpectedStatus, e)
-					addFailedTcInfo(failedTcs, testCmd.Name, podName, podNamespace, noContainerIdx)
-				})
+		}
+		for _, podName := range results.ErroredKeys() {
+			if _, exists := failedTcs[podName]; !exists {
+				failedTcs[podName] = nil
 			}
 		}
-
 		if n := len(failedTcs); n > 0 {
 			log.Debugf("Failed TCs: %+v", failedTcs)
 			ginkgo.Fail(fmt.Sprintf("%d pods failed the test.", n))
@@ -188,6 +181,203 @@ func runTestOnPods(env *config.TestEnvironment, testCmd testcases.BaseTestCase,
 	})
 }
 
+// runTestOnPod runs testCmd on a single pod, or on each of its containers, and returns the failed TCs.
+//nolint:gocritic // ignore hugeParam error, testCmd is a per pod copy.
+func runTestOnPod(context *interactive.Context, podUnderTest *configsections.Pod, testCmd testcases.BaseTestCase, testType string, result *parallel.Result) []failedTcInfo {
+	const noContainerIdx = -1
+	var failedTcs []failedTcInfo
+	podName := podUnderTest.Name
+	podNamespace := podUnderTest.Namespace
+	var args []interface{}
+	if testType == testcases.PrivilegedRoles {
+		args = []interface{}{podUnderTest.Namespace, podUnderTest.Namespace, podUnderTest.ServiceAccount}
+	} else {
+		args = []interface{}{podUnderTest.Name, podUnderTest.Namespace}
+	}
+	var count int
+	if testCmd.Loop > 0 {
+		count = podUnderTest.ContainerCount
+	} else {
+		count = testCmd.Loop
+	}
+
+	runCmd := func(cmd string, containerIdx int) {
+		cmdArgs := strings.Split(cmd, " ")
+		cnfInTest := containerpkg.NewPod(cmdArgs, podUnderTest.Name, podUnderTest.Namespace, testCmd.ExpectedStatus, testCmd.ResultType, testCmd.Action, common.DefaultTimeout)
+		gomega.Expect(cnfInTest).ToNot(gomega.BeNil())
+		test, err := tnf.NewTest(context.GetExpecter(), cnfInTest, []reel.Handler{cnfInTest}, context.GetErrorChannel())
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(test).ToNot(gomega.BeNil())
+		test.RunWithCallbacks(nil, func() {
+			result.Failf("FAILURE: Command sent: %s, Expectations: %v", cmd, testCmd.ExpectedStatus)
+			failedTcs = append(failedTcs, failedTcInfo{tc: testCmd.Name, containerIdx: containerIdx, ns: podNamespace})
+		}, func(e error) {
+			result.Errorf(e, "ERROR: Command sent: %s, Expectations: %v, Error: %v", cmd, testCmd.ExpectedStatus, e)
+			failedTcs = append(failedTcs, failedTcInfo{tc: testCmd.Name, containerIdx: containerIdx, ns: podNamespace})
+		})
+	}
+
+	if count > 0 {
+		for containerIdx := 0; containerIdx < podUnderTest.ContainerCount; containerIdx++ {
+			log.Debugf("Executing TC %s on pod %s (ns %s), container index %d", testCmd.Name, podName, podNamespace, containerIdx)
+			argsCount := append(args, containerIdx)
+			runCmd(fmt.Sprintf(testCmd.Command, argsCount...), containerIdx)
+		}
+	} else {
+		log.Debugf("Executing TC %s on pod %s (ns %s)", testCmd.Name, podName, podNamespace)
+		runCmd(fmt.Sprintf(testCmd.Command, args...), noContainerIdx)
+	}
+	return failedTcs
+}
+
+func testPodSecurity(env *config.TestEnvironment) {
+	testPodSecurityProfile(env, identifiers.TestPodSecurityBaselineIdentifier, podsecurity.Baseline)
+	testPodSecurityProfile(env, identifiers.TestPodSecurityRestrictedIdentifier, podsecurity.Restricted)
+}
+
+func testPodSecurityProfile(env *config.TestEnvironment, identifier claim.Identifier, level podsecurity.Level) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		badPods := 0
+		for _, podUnderTest := range env.PodsUnderTest {
+			ginkgo.By(fmt.Sprintf("Evaluating pod %s (ns %s) against the %s profile", podUnderTest.Name, podUnderTest.Namespace, level))
+			pod, err := autodiscover.GetPod(podUnderTest.Namespace, podUnderTest.Name)
+			if err != nil {
+				tnf.ClaimFilePrintf("Failed to get pod %s/%s: %v", podUnderTest.Namespace, podUnderTest.Name, err)
+				tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, tnf.CheckError, err.Error())
+				badPods++
+				continue
+			}
+			if recordPodSecurity(pod, level) {
+				badPods++
+			}
+		}
+		if badPods > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d pods do not meet the %s Pod Security Standard or could not be checked.", badPods, level))
+		}
+	})
+}
+
+// recordPodSecurity records pod and each of its containers with the controls of the profile they break, and returns
+// whether any of them failed the test.
+func recordPodSecurity(pod *corev1.Pod, level podsecurity.Level) (failed bool) {
+	violations := map[string][]podsecurity.Violation{}
+	for _, v := range podsecurity.Evaluate(pod, level) {
+		violations[v.Container] = append(violations[v.Container], v)
+	}
+	compliance := func(violations []podsecurity.Violation) (tnf.ComplianceStatus, string) {
+		if len(violations) == 0 {
+			return tnf.Compliant, ""
+		}
+		return tnf.NonCompliant, podsecurity.Reason(violations)
+	}
+
+	status, reason := compliance(violations[""])
+	if reason != "" {
+		tnf.ClaimFilePrintf("Pod %s/%s: %s", pod.Namespace, pod.Name, reason)
+	}
+	failed = tnf.RecordPod(pod.Namespace, pod.Name, status, reason).Failed()
+	for _, name := range podsecurity.ContainerNames(pod) {
+		status, reason = compliance(violations[name])
+		if reason != "" {
+			tnf.ClaimFilePrintf("Container %s (Pod %s ns %s): %s", name, pod.Name, pod.Namespace, reason)
+		}
+		if tnf.RecordContainer(pod.Namespace, pod.Name, name, status, reason).Failed() {
+			failed = true
+		}
+	}
+	return failed
+}
+
+func testSCC(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodSCCIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestPodSCCIdentifier)...)
+		sccList, err := autodiscover.GetSCCs()
+		gomega.Expect(err).To(gomega.BeNil())
+		// The pods of a CNF often share their service account, the SCCs it can use are looked up once.
+		reachableSCCs := map[string][]string{}
+		badPods := 0
+		for _, podUnderTest := range env.PodsUnderTest {
+			status, reason := checkPodSCC(podUnderTest, sccList.Items, reachableSCCs)
+			if reason != "" {
+				tnf.ClaimFilePrintf("Pod %s/%s: %s", podUnderTest.Namespace, podUnderTest.Name, reason)
+			}
+			if tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, status, reason).Failed() {
+				badPods++
+			}
+		}
+		if badPods > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d pods run under a privileged SCC or could not be checked.", badPods))
+		}
+	})
+}
+
+// checkPodSCC returns whether podUnderTest runs under a privileged SCC, and reports the SCCs it can use in the claim.
+// reachableSCCs holds the SCCs each service account can use.
+func checkPodSCC(podUnderTest *configsections.Pod, sccs []autodiscover.SCCResource, reachableSCCs map[string][]string) (tnf.ComplianceStatus, string) {
+	pod, err := autodiscover.GetPod(podUnderTest.Namespace, podUnderTest.Name)
+	if err != nil {
+		return tnf.CheckError, err.Error()
+	}
+	serviceAccount := scc.ServiceAccount(pod)
+	user := rbac.ServiceAccountUser(pod.Namespace, serviceAccount)
+	reachable, ok := reachableSCCs[user]
+	if !ok {
+		if reachable, err = scc.Reachable(sccs, pod.Namespace, serviceAccount, autodiscover.CanUseSCC); err != nil {
+			return tnf.CheckError, err.Error()
+		}
+		reachableSCCs[user] = reachable
+	}
+	admitted := scc.Admitted(pod)
+	tnf.ClaimFilePrintf("Pod %s/%s: admitted under SCC %q, service account %s can use SCCs %v", pod.Namespace, pod.Name,
+		admitted, serviceAccount, reachable)
+	switch {
+	case admitted == "":
+		return tnf.CheckError, "no " + scc.AdmittedAnnotation + " annotation"
+	case scc.IsPrivileged(admitted):
+		return tnf.NonCompliant, fmt.Sprintf("runs under SCC %s, service account %s can use SCCs %s", admitted, serviceAccount,
+			strings.Join(reachable, ", "))
+	}
+	return tnf.Compliant, ""
+}
+
+func testServiceAccountRBAC(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestServiceAccountRBACIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		rbacList, err := autodiscover.GetRBAC()
+		gomega.Expect(err).To(gomega.BeNil())
+		checked := map[string]bool{}
+		badServiceAccounts := 0
+		for _, podUnderTest := range env.PodsUnderTest {
+			serviceAccount := podUnderTest.ServiceAccount
+			if serviceAccount == "" {
+				serviceAccount = defaultServiceAccount
+			}
+			user := rbac.ServiceAccountUser(podUnderTest.Namespace, serviceAccount)
+			if checked[user] {
+				continue
+			}
+			checked[user] = true
+			ginkgo.By(fmt.Sprintf("Resolving the roles of service account %s", user))
+			status, reason := tnf.Compliant, ""
+			findings := rbac.Analyze(rbacList, podUnderTest.Namespace, serviceAccount)
+			for i := range findings {
+				tnf.ClaimFilePrintf("Service account %s/%s: %s", podUnderTest.Namespace, serviceAccount, findings[i].String())
+			}
+			if len(findings) > 0 {
+				status, reason = tnf.NonCompliant, rbac.Reason(findings)
+			}
+			if tnf.RecordServiceAccount(podUnderTest.Namespace, serviceAccount, status, reason).Failed() {
+				badServiceAccounts++
+			}
+		}
+		if badServiceAccounts > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d service accounts are granted dangerous permissions.", badServiceAccounts))
+		}
+	})
+}
+
 func getCrsNamespaces(crdName, crdKind string, context *interactive.Context) (map[string]string, error) {
 	const expectedNumFields = 2
 	const crNameFieldIdx = 0
@@ -263,12 +453,16 @@ func testNamespace(env *config.TestEnvironment) {
 			var failedNamespaces []string
 			for _, namespace := range env.NameSpacesUnderTest {
 				ginkgo.By(fmt.Sprintf("Checking namespace %s", namespace))
+				status, reason := tnf.Compliant, ""
 				for _, invalidPrefix := range invalidNamespacePrefixes {
 					if strings.HasPrefix(namespace, invalidPrefix) {
 						common.TcClaimLogPrintf("Namespace %s has invalid prefix %s", namespace, invalidPrefix)
-						failedNamespaces = append(failedNamespaces, namespace)
+						status, reason = tnf.NonCompliant, "invalid prefix "+invalidPrefix
 					}
 				}
+				if tnf.RecordNamespace(namespace, status, reason).Failed() {
+					failedNamespaces = append(failedNamespaces, namespace)
+				}
 			}
 
 			if failedNamespacesNum := len(failedNamespaces); failedNamespacesNum > 0 {
@@ -277,11 +471,14 @@ func testNamespace(env *config.TestEnvironment) {
 
 			ginkgo.By(fmt.Sprintf("CNF pods' should belong to any of the configured namespaces: %v", env.NameSpacesUnderTest))
 
-			if nonValidPodsNum := len(env.Config.NonValidPods); nonValidPodsNum > 0 {
-				for _, invalidPod := range env.Config.NonValidPods {
-					common.TcClaimLogPrintf("Pod %s has invalid namespace %s", invalidPod.Name, invalidPod.Namespace)
+			nonValidPodsNum := 0
+			for _, invalidPod := range env.Config.NonValidPods {
+				common.TcClaimLogPrintf("Pod %s has invalid namespace %s", invalidPod.Name, invalidPod.Namespace)
+				if tnf.RecordPod(invalidPod.Namespace, invalidPod.Name, tnf.NonCompliant, "namespace not under test").Failed() {
+					nonValidPodsNum++
 				}
-
+			}
+			if nonValidPodsNum > 0 {
 				ginkgo.Fail(fmt.Sprintf("Found %d pods under test belonging to invalid namespaces.", nonValidPodsNum))
 			}
 
@@ -314,8 +511,12 @@ func testServiceAccount(env *config.TestEnvironment) {
 		failedPods := []*configsections.Pod{}
 		for _, podUnderTest := range env.PodsUnderTest {
 			ginkgo.By(fmt.Sprintf("Testing service account for pod %s (ns: %s)", podUnderTest.Name, podUnderTest.Namespace))
+			status, reason := tnf.Compliant, ""
 			if podUnderTest.ServiceAccount == "" {
 				tnf.ClaimFilePrintf("Pod %s (ns: %s) doesn't have a service account name.", podUnderTest.Name, podUnderTest.Namespace)
+				status, reason = tnf.NonCompliant, "no service account name"
+			}
+			if tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, status, reason).Failed() {
 				failedPods = append(failedPods, podUnderTest)
 			}
 		}
@@ -356,26 +557,28 @@ func testAutomountService(env *config.TestEnvironment) {
 			// the token defined in the pod has takes precedence
 			// the test would pass iif token is explicitly set to false
 			// if the token is set to true in the pod, the test would fail right away
-			if podToken == automountservice.TokenIsTrue {
-				msg = append(msg, fmt.Sprintf("Pod %s:%s is configured with automountServiceAccountToken set to true ", podNamespace, podName))
-				continue
-			}
+			var reason string
+			switch {
+			case podToken == automountservice.TokenIsTrue:
+				reason = fmt.Sprintf("Pod %s:%s is configured with automountServiceAccountToken set to true ", podNamespace, podName)
 			// The pod token is false means the pod is configured properly
 			// The pod is not configured and the service account is configured with false means
 			// the pod will inherit the behavior `false` and the test would pass
-			if podToken == automountservice.TokenIsFalse || serviceAccountToken == automountservice.TokenIsFalse {
-				continue
-			}
+			case podToken == automountservice.TokenIsFalse || serviceAccountToken == automountservice.TokenIsFalse:
 			// the service account is configured with true means all the pods
 			// using this service account are not configured properly, register the error
 			// message and fail
-			if serviceAccountToken == automountservice.TokenIsTrue {
-				msg = append(msg, fmt.Sprintf("serviceaccount %s:%s is configured with automountServiceAccountToken set to true, impacting pod %s ", podNamespace, serviceAccountName, podName))
-			}
+			case serviceAccountToken == automountservice.TokenIsTrue:
+				reason = fmt.Sprintf("serviceaccount %s:%s is configured with automountServiceAccountToken set to true, impacting pod %s ", podNamespace, serviceAccountName, podName)
 			// the token should be set explicitly to false, otherwise, it's a failure
 			// register the error message and check the next pod
-			if serviceAccountToken == automountservice.TokenNotSet {
-				msg = append(msg, fmt.Sprintf("serviceaccount %s:%s is not configured with automountServiceAccountToken set to false, impacting pod %s ", podNamespace, serviceAccountName, podName))
+			case serviceAccountToken == automountservice.TokenNotSet:
+				reason = fmt.Sprintf("serviceaccount %s:%s is not configured with automountServiceAccountToken set to false, impacting pod %s ", podNamespace, serviceAccountName, podName)
+			}
+			if reason == "" {
+				tnf.RecordPod(podNamespace, podName, tnf.Compliant, "")
+			} else if tnf.RecordPod(podNamespace, podName, tnf.NonCompliant, strings.TrimSpace(reason)).Failed() {
+				msg = append(msg, reason)
 			}
 		}
 		if len(msg) > 0 {
@@ -402,11 +605,16 @@ func testRoleBindings(env *config.TestEnvironment) {
 			rbTester := rolebinding.NewRoleBinding(common.DefaultTimeout, serviceAccountName, podNamespace)
 			test, err := tnf.NewTest(context.GetExpecter(), rbTester, []reel.Handler{rbTester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
-			test.RunWithCallbacks(nil, func() {
+			test.RunWithCallbacks(func() {
+				tnf.RecordPod(podNamespace, podName, tnf.Compliant, "")
+			}, func() {
 				tnf.ClaimFilePrintf("FAILURE: Pod %s (ns: %s) roleBindings: %v", podName, podNamespace, rbTester.GetRoleBindings())
-				failedPods = append(failedPods, podUnderTest)
+				if tnf.RecordPod(podNamespace, podName, tnf.NonCompliant, "role bindings in other namespaces").Failed() {
+					failedPods = append(failedPods, podUnderTest)
+				}
 			}, func(err error) {
 				tnf.ClaimFilePrintf("ERROR: Pod %s (ns: %s) roleBindings: %v, error: %v", podName, podNamespace, rbTester.GetRoleBindings(), err)
+				tnf.RecordPod(podNamespace, podName, tnf.CheckError, err.Error())
 				failedPods = append(failedPods, podUnderTest)
 			})
 		}
@@ -434,11 +642,16 @@ func testClusterRoleBindings(env *config.TestEnvironment) {
 			crbTester := clusterrolebinding.NewClusterRoleBinding(common.DefaultTimeout, serviceAccountName, podNamespace)
 			test, err := tnf.NewTest(context.GetExpecter(), crbTester, []reel.Handler{crbTester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
-			test.RunWithCallbacks(nil, func() {
+			test.RunWithCallbacks(func() {
+				tnf.RecordPod(podNamespace, podName, tnf.Compliant, "")
+			}, func() {
 				tnf.ClaimFilePrintf("FAILURE: Pod: %s (ns: %s) SA: %s clusterRoleBindings: %v", podName, podNamespace, serviceAccountName, crbTester.GetClusterRoleBindings())
-				failedPods = append(failedPods, podUnderTest)
+				if tnf.RecordPod(podNamespace, podName, tnf.NonCompliant, "cluster role bindings").Failed() {
+					failedPods = append(failedPods, podUnderTest)
+				}
 			}, func(err error) {
 				tnf.ClaimFilePrintf("ERROR: Pod: %s (ns: %s) SA: %s clusterRoleBindings: %v, error: %v", podName, podNamespace, serviceAccountName, crbTester.GetClusterRoleBindings(), err)
+				tnf.RecordPod(podNamespace, podName, tnf.CheckError, err.Error())
 				failedPods = append(failedPods, podUnderTest)
 			})
 		}
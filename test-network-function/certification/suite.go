@@ -18,6 +18,7 @@ package certification
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2"
@@ -26,22 +27,26 @@ import (
 	configpkg "github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 const (
 	// timeout for eventually call
 	apiRequestTimeout = 30 * time.Second
+	// lowestFreshnessGrade is the lowest health index a container image passes with.
+	lowestFreshnessGrade = "C"
 )
 
-var certAPIClient api.CertAPIClient
+var (
+	certAPIClient    api.CertAPIClient
+	containerCatalog api.ContainerCatalog
+)
 
 var _ = ginkgo.Describe(common.AffiliatedCertTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, common.AffiliatedCertTestKey) {
+	if selection.IsSuiteSelected(common.AffiliatedCertTestKey) {
 		env := configpkg.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -51,6 +56,7 @@ var _ = ginkgo.Describe(common.AffiliatedCertTestKey, func() {
 		ginkgo.AfterEach(env.CloseLocalShellContext)
 
 		testContainerCertificationStatus()
+		testContainerVulnerabilities()
 		testOperatorCertificationStatus()
 	}
 })
@@ -58,7 +64,7 @@ var _ = ginkgo.Describe(common.AffiliatedCertTestKey, func() {
 // getContainerCertificationRequestFunction returns function that will try to get the certification status (CCP) for a container.
 func getContainerCertificationRequestFunction(id configsections.ContainerImageIdentifier) func() (interface{}, error) {
 	return func() (interface{}, error) {
-		return certAPIClient.GetContainerCatalogEntry(id)
+		return containerCatalog.GetContainerCatalogEntry(id)
 	}
 }
 
@@ -69,8 +75,9 @@ func getOperatorCertificationRequestFunction(organization, operatorName string)
 	}
 }
 
-// waitForCertificationRequestToSuccess calls to certificationRequestFunc until it returns true.
-func waitForCertificationRequestToSuccess(certificationRequestFunc func() (interface{}, error), timeout time.Duration) interface{} {
+// waitForCertificationRequestToSuccess calls to certificationRequestFunc until it succeeds or the timeout elapses, and
+// returns the result and the error of the last call.
+func waitForCertificationRequestToSuccess(certificationRequestFunc func() (interface{}, error), timeout time.Duration) (interface{}, error) {
 	const pollingPeriod = 1 * time.Second
 	var elapsed time.Duration
 	var err error
@@ -85,7 +92,35 @@ func waitForCertificationRequestToSuccess(certificationRequestFunc func() (inter
 		time.Sleep(pollingPeriod)
 		elapsed += pollingPeriod
 	}
-	return result
+	return result, err
+}
+
+// getContainersToQuery returns the container images listed in the configuration and, if enabled, the ones used by the
+// containers under test.
+func getContainersToQuery(env *configpkg.TestEnvironment) map[configsections.ContainerImageIdentifier]bool {
+	containersToQuery := make(map[configsections.ContainerImageIdentifier]bool)
+	for _, c := range env.Config.CertifiedContainerInfo {
+		containersToQuery[c] = true
+	}
+	if env.Config.CheckDiscoveredContainerCertificationStatus {
+		for _, cut := range env.ContainersUnderTest {
+			containersToQuery[cut.ImageSource.ContainerImageIdentifier] = true
+		}
+	}
+	return containersToQuery
+}
+
+// setContainerCatalog sets the container catalog to the offline snapshot if one is configured, else to the catalog API.
+func setContainerCatalog(config *configsections.TestConfiguration) {
+	if config.CertifiedContainerCatalogSnapshot == "" {
+		containerCatalog = api.NewHTTPClient()
+		return
+	}
+	snapshot, err := api.LoadCatalogSnapshot(config.CertifiedContainerCatalogSnapshot)
+	if err != nil {
+		ginkgo.Fail(fmt.Sprintf("Failed to load the container catalog snapshot: %v", err))
+	}
+	containerCatalog = snapshot
 }
 
 func testContainerCertificationStatus() {
@@ -93,21 +128,13 @@ func testContainerCertificationStatus() {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestContainerIsCertifiedIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
 		env := configpkg.GetTestEnvironment()
-		containersToQuery := make(map[configsections.ContainerImageIdentifier]bool)
-		for _, c := range env.Config.CertifiedContainerInfo {
-			containersToQuery[c] = true
-		}
-		if env.Config.CheckDiscoveredContainerCertificationStatus {
-			for _, cut := range env.ContainersUnderTest {
-				containersToQuery[cut.ImageSource.ContainerImageIdentifier] = true
-			}
-		}
+		containersToQuery := getContainersToQuery(env)
 		if len(containersToQuery) == 0 {
 			ginkgo.Skip("No containers to check configured in tnf_config.yml")
 		}
 		ginkgo.By(fmt.Sprintf("Getting certification status. Number of containers to check: %d", len(containersToQuery)))
 		if len(containersToQuery) > 0 {
-			certAPIClient = api.NewHTTPClient()
+			setContainerCatalog(&env.Config)
 			failedContainers := []configsections.ContainerImageIdentifier{}
 			allContainersToQueryEmpty := true
 			for c := range containersToQuery {
@@ -117,12 +144,16 @@ func testContainerCertificationStatus() {
 				}
 				allContainersToQueryEmpty = false
 				ginkgo.By(fmt.Sprintf("Container %s/%s should eventually be verified as certified", c.Repository, c.Name))
-				entry := waitForCertificationRequestToSuccess(getContainerCertificationRequestFunction(c), apiRequestTimeout).(*api.ContainerCatalogEntry)
-				if entry == nil {
+				result, err := waitForCertificationRequestToSuccess(getContainerCertificationRequestFunction(c), apiRequestTimeout)
+				entry, _ := result.(*api.ContainerCatalogEntry)
+				if err != nil {
+					tnf.ClaimFilePrintf("ERROR: failed to look container %s (repository %s) up in the certified container catalog: %v", c.Name, c.Repository, err)
+					failedContainers = append(failedContainers, c)
+				} else if entry == nil {
 					tnf.ClaimFilePrintf("Container %s (repository %s) is not found in the certified container catalog.", c.Name, c.Repository)
 					failedContainers = append(failedContainers, c)
 				} else {
-					if entry.GetBestFreshnessGrade() > "C" {
+					if entry.GetBestFreshnessGrade() > lowestFreshnessGrade {
 						tnf.ClaimFilePrintf("Container %s (repository %s) is found in the certified container catalog but with low health index '%s'.", c.Name, c.Repository, entry.GetBestFreshnessGrade())
 						failedContainers = append(failedContainers, c)
 					}
@@ -141,6 +172,113 @@ func testContainerCertificationStatus() {
 	})
 }
 
+func testContainerVulnerabilities() {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestContainerVulnerabilitiesIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		env := configpkg.GetTestEnvironment()
+		containersToQuery := getContainersToQuery(env)
+		if len(containersToQuery) == 0 {
+			ginkgo.Skip("No containers to check configured in tnf_config.yml")
+		}
+		setContainerCatalog(&env.Config)
+		failedContainers := []configsections.ContainerImageIdentifier{}
+		foundContainers := 0
+		for c := range containersToQuery {
+			if c.Name == "" || c.Repository == "" {
+				continue
+			}
+			ginkgo.By(fmt.Sprintf("Container %s/%s should be fresh and free of severe vulnerabilities", c.Repository, c.Name))
+			result, err := waitForCertificationRequestToSuccess(getContainerCertificationRequestFunction(c), apiRequestTimeout)
+			if err != nil {
+				tnf.ClaimFilePrintf("ERROR: failed to look container %s (repository %s) up in the container catalog: %v", c.Name, c.Repository, err)
+				tnf.RecordImage(c.Repository, c.Name, tnf.CheckError, fmt.Sprintf("container catalog lookup failed: %v", err))
+				failedContainers = append(failedContainers, c)
+				continue
+			}
+			entry, _ := result.(*api.ContainerCatalogEntry)
+			if entry == nil {
+				tnf.ClaimFilePrintf("Container %s (repository %s) is not found in the container catalog, skipping it.", c.Name, c.Repository)
+				continue
+			}
+			foundContainers++
+			if checkContainerImage(c, entry).Failed() {
+				failedContainers = append(failedContainers, c)
+			}
+		}
+		if foundContainers == 0 && len(failedContainers) == 0 {
+			ginkgo.Skip("None of the containers to check is found in the container catalog")
+		}
+		if n := len(failedContainers); n > 0 {
+			log.Warnf("Containers that are not fresh, have severe vulnerabilities or could not be checked: %+v", failedContainers)
+			ginkgo.Fail(fmt.Sprintf("%d container images are not fresh, have severe vulnerabilities or could not be checked.", n))
+		}
+	})
+}
+
+// checkContainerImage reports the provenance of the image of container c, checks that it is published in its
+// repository, that its current health index is high enough and that no Critical or Important vulnerability affects it,
+// and records the outcome.
+func checkContainerImage(c configsections.ContainerImageIdentifier, entry *api.ContainerCatalogEntry) tnf.ComplianceStatus {
+	repository := c.Repository + "/" + c.Name
+	published := []string{}
+	for i := range entry.Repositories {
+		if entry.Repositories[i].Published {
+			published = append(published, entry.Repositories[i].Registry+"/"+entry.Repositories[i].Repository)
+		}
+	}
+	tnf.ClaimFilePrintf("Container %s: image %s built for %s on %s, published in %v.", repository, entry.ImageID,
+		entry.Architecture, entry.CreationDate.Format(time.RFC3339), published)
+	reasons := []string{}
+	if entry.GetPublishedRepository(repository) == nil {
+		tnf.ClaimFilePrintf("Container %s: image %s is not published in repository %s.", repository, entry.ImageID, repository)
+		reasons = append(reasons, "not published in "+repository)
+	}
+	if grade := entry.GetFreshnessGrade(time.Now()); grade > lowestFreshnessGrade {
+		tnf.ClaimFilePrintf("Container %s: image %s has the low health index '%s'.", repository, entry.ImageID, grade)
+		reasons = append(reasons, fmt.Sprintf("low health index '%s'", grade))
+	}
+	cves, err := checkContainerVulnerabilities(repository, entry)
+	if len(cves) > 0 {
+		reasons = append(reasons, "affected by "+strings.Join(cves, ", "))
+	}
+	switch {
+	case len(reasons) > 0:
+		return tnf.RecordImage(c.Repository, c.Name, tnf.NonCompliant, strings.Join(reasons, "; "))
+	case err != nil:
+		return tnf.RecordImage(c.Repository, c.Name, tnf.CheckError, fmt.Sprintf("failed to get the vulnerabilities: %v", err))
+	}
+	return tnf.RecordImage(c.Repository, c.Name, tnf.Compliant, "")
+}
+
+// checkContainerVulnerabilities returns the Critical and Important vulnerabilities that affect the RPMs of the image of
+// the container of the given repository, or an error if the vulnerabilities of the image cannot be fetched.
+func checkContainerVulnerabilities(repository string, entry *api.ContainerCatalogEntry) ([]string, error) {
+	vulnerabilities, err := containerCatalog.GetVulnerabilities(entry)
+	if err != nil {
+		tnf.ClaimFilePrintf("ERROR: container %s: failed to get the vulnerabilities of image %s: %v", repository, entry.ImageID, err)
+		return nil, err
+	}
+	manifest, err := containerCatalog.GetRPMManifest(entry)
+	if err != nil {
+		log.Warnf("Failed to get the RPM manifest of image %s, all the RPMs affected by its vulnerabilities are reported: %v", entry.ImageID, err)
+	}
+	cves := []string{}
+	for i := range vulnerabilities {
+		v := &vulnerabilities[i]
+		if v.Severity != api.SeverityCritical && v.Severity != api.SeverityImportant {
+			continue
+		}
+		rpms := v.GetAffectedRPMs(manifest)
+		if len(v.Packages) > 0 && len(rpms) == 0 {
+			continue
+		}
+		tnf.ClaimFilePrintf("Container %s: %s (%s, advisory %s) affects image %s, RPMs %v.", repository, v.CveID, v.Severity,
+			v.AdvisoryID, entry.ImageID, rpms)
+		cves = append(cves, v.CveID)
+	}
+	return cves, nil
+}
+
 func testOperatorCertificationStatus() {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestOperatorIsCertifiedIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
@@ -162,8 +300,12 @@ func testOperatorCertificationStatus() {
 				}
 				allOperatorsToQueryEmpty = false
 				ginkgo.By(fmt.Sprintf("Should eventually be verified as certified (operator %s/%s)", operator.Organization, operator.Name))
-				isCertified := waitForCertificationRequestToSuccess(getOperatorCertificationRequestFunction(operator.Organization, operator.Name), apiRequestTimeout).(bool)
-				if !isCertified {
+				result, err := waitForCertificationRequestToSuccess(getOperatorCertificationRequestFunction(operator.Organization, operator.Name), apiRequestTimeout)
+				isCertified, _ := result.(bool)
+				if err != nil {
+					tnf.ClaimFilePrintf("ERROR: failed to get the certification status of operator %s (organization %s): %v", operator.Name, operator.Organization, err)
+					failedOperators = append(failedOperators, operator)
+				} else if !isCertified {
 					tnf.ClaimFilePrintf("Operator %s (organization %s) failed to be certified.", operator.Name, operator.Organization)
 					failedOperators = append(failedOperators, operator)
 				} else {
@@ -20,6 +20,7 @@ package common
 const (
 	ConfiguredTestFile        = "testconfigure.yml"
 	defaultTimeoutSeconds     = 10
+	defaultParallelWorkers    = 8
 	AccessControlTestKey      = "access-control"
 	DiagnosticTestKey         = "diagnostic"
 	LifecycleTestKey          = "lifecycle"
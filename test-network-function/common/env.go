@@ -26,6 +26,7 @@ import (
 
 	"github.com/onsi/ginkgo/v2"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config"
 )
 
 var (
@@ -59,12 +60,46 @@ func IsNonOcpCluster() bool {
 	return b
 }
 
+// ClusterCapabilities returns the capabilities of the cluster under test. The OpenShift capabilities are considered
+// missing when TNF_NON_OCP_CLUSTER is set.
+func ClusterCapabilities(env *config.TestEnvironment) config.Capabilities {
+	if IsNonOcpCluster() {
+		return env.Capabilities().Without(config.OpenShiftCapabilities...)
+	}
+	return env.Capabilities()
+}
+
+// SkipUnlessCapable skips the current test when the cluster lacks one of the required capabilities.
+func SkipUnlessCapable(env *config.TestEnvironment, required ...config.Capability) {
+	if missing := ClusterCapabilities(env).Missing(required...); len(missing) > 0 {
+		ginkgo.Skip(fmt.Sprintf("The cluster lacks the capabilities %v needed by this test", missing))
+	}
+}
+
 // Intrusive is for running tests that can impact the CNF or test environment in an intrusive way
 func Intrusive() bool {
 	b, _ := strconv.ParseBool(os.Getenv("TNF_NON_INTRUSIVE_ONLY"))
 	return !b
 }
 
+// SkipUnlessIntrusive skips the current test, which disrupts the CNF, when TNF_NON_INTRUSIVE_ONLY is set. The test
+// selection leaves such tests out already, this guards the runs that bypass it, e.g. with a plain ginkgo focus.
+func SkipUnlessIntrusive() {
+	if !Intrusive() {
+		ginkgo.Skip("Intrusive test skipped, TNF_NON_INTRUSIVE_ONLY is set")
+	}
+}
+
+// ParallelWorkers returns the number of per-target checks a test case may run at the same time, set with
+// TNF_PARALLEL_WORKERS. Setting it to 1 runs the checks one after the other.
+func ParallelWorkers() int {
+	n, err := strconv.Atoi(os.Getenv("TNF_PARALLEL_WORKERS"))
+	if err != nil || n < 1 {
+		return defaultParallelWorkers
+	}
+	return n
+}
+
 // logLevel retrieves the LOG_LEVEL environment variable
 func logLevel() string {
 	logLevel := os.Getenv("LOG_LEVEL")
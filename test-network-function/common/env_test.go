@@ -72,6 +72,25 @@ func TestIntrusive(t *testing.T) {
 	}
 }
 
+func TestParallelWorkers(t *testing.T) {
+	testCases := []struct {
+		envValue        string
+		expectedWorkers int
+	}{
+		{envValue: "", expectedWorkers: defaultParallelWorkers},
+		{envValue: "1", expectedWorkers: 1},
+		{envValue: "32", expectedWorkers: 32},
+		{envValue: "0", expectedWorkers: defaultParallelWorkers},
+		{envValue: "many", expectedWorkers: defaultParallelWorkers},
+	}
+
+	defer os.Unsetenv("TNF_PARALLEL_WORKERS")
+	for _, tc := range testCases {
+		os.Setenv("TNF_PARALLEL_WORKERS", tc.envValue)
+		assert.Equal(t, tc.expectedWorkers, ParallelWorkers())
+	}
+}
+
 func TestLogLevel(t *testing.T) {
 	testCases := []struct {
 		logLevel         string
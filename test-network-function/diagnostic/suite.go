@@ -17,8 +17,8 @@ import (
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/generic"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodedebug"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 const (
@@ -68,8 +68,7 @@ var (
 )
 
 var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, common.DiagnosticTestKey) {
+	if selection.IsSuiteSelected(common.DiagnosticTestKey) {
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
@@ -79,6 +78,7 @@ var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
 		ginkgo.AfterEach(env.CloseLocalShellContext)
 		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestClusterVersionIdentifier)
 		ginkgo.It(testID, ginkgo.Label(testID), func() {
+			common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestClusterVersionIdentifier)...)
 			testOcpVersion()
 		})
 
@@ -108,10 +108,12 @@ var _ = ginkgo.Describe(common.DiagnosticTestKey, func() {
 		})
 		testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestListCniPluginsIdentifier)
 		ginkgo.It(testID, ginkgo.Label(testID), func() {
+			common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestListCniPluginsIdentifier)...)
 			testCniPlugins()
 		})
 		testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestNodesHwInfoIdentifier)
 		ginkgo.It(testID, ginkgo.Label(testID), func() {
+			common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestNodesHwInfoIdentifier)...)
 			testNodesHwInfo()
 		})
 		testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestClusterCsiInfoIdentifier)
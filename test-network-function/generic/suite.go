@@ -19,8 +19,8 @@ package generic
 import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/test-network-function/test-network-function/pkg/config"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 const (
@@ -29,8 +29,7 @@ const (
 
 // Runs the "generic" CNF test cases.
 var _ = ginkgo.Describe(testsKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, testsKey) {
+	if selection.IsSuiteSelected(testsKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -0,0 +1,49 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package identifiers
+
+import (
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/config"
+)
+
+// requiredCapabilities are the cluster capabilities each test needs. A test missing from the map runs on any
+// Kubernetes cluster. Tests having a Kubernetes fallback for an OpenShift API, e.g. reading the hugepages kernel
+// arguments from the nodes instead of their MachineConfig, don't require it.
+var requiredCapabilities = map[claim.Identifier][]config.Capability{
+	TestClusterVersionIdentifier:             {config.ClusterVersionCapability},
+	TestListCniPluginsIdentifier:             {config.DebugPodsCapability},
+	TestNodesHwInfoIdentifier:                {config.DebugPodsCapability},
+	TestHugepagesNotManuallyManipulated:      {config.DebugPodsCapability},
+	TestICMPv4ConnectivityIdentifier:         {config.DebugPodsCapability},
+	TestICMPv4ConnectivityMultusIdentifier:   {config.DebugPodsCapability},
+	TestICMPv6ConnectivityIdentifier:         {config.DebugPodsCapability},
+	TestICMPv6ConnectivityMultusIdentifier:   {config.DebugPodsCapability},
+	TestServicePortReachabilityIdentifier:    {config.DebugPodsCapability},
+	TestNonTaintedNodeKernelsIdentifier:      {config.DebugPodsCapability},
+	TestOperatorInstallStatusIdentifier:      {config.OLMCapability},
+	TestOperatorIsInstalledViaOLMIdentifier:  {config.OLMCapability},
+	TestUnalteredBaseImageIdentifier:         {config.DebugPodsCapability},
+	TestUnalteredStartupBootParamsIdentifier: {config.DebugPodsCapability},
+	TestSysctlConfigsIdentifier:              {config.DebugPodsCapability},
+	TestPodSCCIdentifier:                     {config.SCCCapability},
+}
+
+// RequiredCapabilities returns the cluster capabilities the test identifier needs.
+func RequiredCapabilities(identifier claim.Identifier) []config.Capability {
+	return requiredCapabilities[identifier]
+}
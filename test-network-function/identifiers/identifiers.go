@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf/identifier"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 )
 
@@ -50,6 +51,9 @@ type TestCaseDescription struct {
 
 	// BestPracticeReference is a helpful best practice references of the test case.
 	BestPracticeReference string `json:"BestPracticeReference" yaml:"BestPracticeReference"`
+
+	// IntrusionSettings is used to specify test intrusion behavior into a target system.
+	IntrusionSettings identifier.IntrusionSettings `json:"intrusionSettings" yaml:"intrusionSettings"`
 }
 
 func formTestURL(suite, name string) string {
@@ -72,6 +76,11 @@ var (
 		Url:     formTestURL(common.AffiliatedCertTestKey, "container-is-certified"),
 		Version: versionOne,
 	}
+	// TestContainerVulnerabilitiesIdentifier tests the container images are fresh and free of severe vulnerabilities.
+	TestContainerVulnerabilitiesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AffiliatedCertTestKey, "container-vulnerabilities"),
+		Version: versionOne,
+	}
 	// TestExtractNodeInformationIdentifier is a test which extracts Node information.
 	TestExtractNodeInformationIdentifier = claim.Identifier{
 		Url:     formTestURL(common.DiagnosticTestKey, "extract-node-information"),
@@ -102,6 +111,36 @@ var (
 		Url:     formTestURL(common.NetworkingTestKey, "icmpv4-connectivity-multus"),
 		Version: versionOne,
 	}
+	// TestICMPv6ConnectivityIdentifier tests icmpv6 connectivity.
+	TestICMPv6ConnectivityIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "icmpv6-connectivity"),
+		Version: versionOne,
+	}
+	// TestICMPv6ConnectivityMultusIdentifier tests icmpv6 connectivity on multus networks.
+	TestICMPv6ConnectivityMultusIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "icmpv6-connectivity-multus"),
+		Version: versionOne,
+	}
+	// TestServicePortReachabilityIdentifier tests the declared container ports can be reached from other pods.
+	TestServicePortReachabilityIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "service-port-reachability"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyDenyAllIdentifier tests the namespaces under test deny all traffic by default.
+	TestNetworkPolicyDenyAllIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-deny-all"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyPodSelectedIdentifier tests each pod under test is selected by a NetworkPolicy.
+	TestNetworkPolicyPodSelectedIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-pod-selected"),
+		Version: versionOne,
+	}
+	// TestNetworkPolicyDeclaredPortsIdentifier tests the NetworkPolicies allow the traffic to the declared ports.
+	TestNetworkPolicyDeclaredPortsIdentifier = claim.Identifier{
+		Url:     formTestURL(common.NetworkingTestKey, "network-policy-declared-ports"),
+		Version: versionOne,
+	}
 	// TestNamespaceBestPracticesIdentifier ensures the namespace has followed best namespace practices.
 	TestNamespaceBestPracticesIdentifier = claim.Identifier{
 		Url:     formTestURL(common.AccessControlTestKey, "namespace"),
@@ -160,6 +199,21 @@ var (
 		Url:     formTestURL(common.LifecycleTestKey, "image-pull-policy"),
 		Version: versionOne,
 	}
+	// TestContainerProbesIdentifier ensures the containers define sound liveness, readiness and startup probes.
+	TestContainerProbesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "container-probes"),
+		Version: versionOne,
+	}
+	// TestPodResourcesIdentifier ensures the containers request resources and the pods are in the QoS class they need.
+	TestPodResourcesIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "pod-resources"),
+		Version: versionOne,
+	}
+	// TestPodDisruptionBudgetIdentifier ensures the podsets have a PodDisruptionBudget that lets a node be drained.
+	TestPodDisruptionBudgetIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "pod-disruption-budget"),
+		Version: versionOne,
+	}
 	// TestPodRecreationIdentifier ensures recreation best practices.
 	TestPodRecreationIdentifier = claim.Identifier{
 		Url:     formTestURL(common.LifecycleTestKey, "pod-recreation"),
@@ -180,6 +234,26 @@ var (
 		Url:     formTestURL(common.AccessControlTestKey, "pod-automount-service-account-token"),
 		Version: versionOne,
 	}
+	// TestPodSecurityBaselineIdentifier tests the pods meet the baseline profile of the Pod Security Standards.
+	TestPodSecurityBaselineIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "pod-security-baseline"),
+		Version: versionOne,
+	}
+	// TestPodSecurityRestrictedIdentifier tests the pods meet the restricted profile of the Pod Security Standards.
+	TestPodSecurityRestrictedIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "pod-security-restricted"),
+		Version: versionOne,
+	}
+	// TestPodSCCIdentifier tests the pods do not run under privileged SecurityContextConstraints.
+	TestPodSCCIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "pod-scc"),
+		Version: versionOne,
+	}
+	// TestServiceAccountRBACIdentifier tests the service accounts of the pods are not granted dangerous permissions.
+	TestServiceAccountRBACIdentifier = claim.Identifier{
+		Url:     formTestURL(common.AccessControlTestKey, "service-account-rbac"),
+		Version: versionOne,
+	}
 	// TestServicesDoNotUseNodeportsIdentifier ensures Services don't utilize NodePorts.
 	TestServicesDoNotUseNodeportsIdentifier = claim.Identifier{
 		Url:     formTestURL(common.NetworkingTestKey, "service-type"),
@@ -225,6 +299,16 @@ var (
 		Url:     formTestURL(common.LifecycleTestKey, "statefulset-scaling"),
 		Version: versionOne,
 	}
+	// TestRollingUpdateIdentifier ensures deployments and statefulsets roll out without an availability gap.
+	TestRollingUpdateIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "rolling-update"),
+		Version: versionOne,
+	}
+	// TestKillRecoveryIdentifier ensures deployments and statefulsets recover from pod deletions and container kills.
+	TestKillRecoveryIdentifier = claim.Identifier{
+		Url:     formTestURL(common.LifecycleTestKey, "kill-recovery"),
+		Version: versionOne,
+	}
 	// TestIsRedHatReleaseIdentifier ensures platform is defined
 	TestIsRedHatReleaseIdentifier = claim.Identifier{
 		Url:     formTestURL(common.PlatformAlterationTestKey, "isredhat-release"),
@@ -319,6 +403,21 @@ cannot be followed.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.7",
 	},
 
+	TestContainerVulnerabilitiesIdentifier: {
+		Identifier: TestContainerVulnerabilitiesIdentifier,
+		Type:       informativeResult,
+		Remediation: `Rebuild the container images on up to date base images and RPMs so that they are published with the
+fixes of the Critical and Important vulnerabilities affecting them.`,
+		Description: formDescription(TestContainerVulnerabilitiesIdentifier,
+			`looks the container images listed in the configuration file or used by test target Pods up in the Red Hat
+container catalog, or in its offline snapshot, and checks that each is published in its repository, that its current
+[health index](https://redhat-connect.gitbook.io/catalog-help/container-images/container-health) is C or above and that
+no Critical or Important vulnerability affects the RPMs it contains. Their architecture, creation date and repositories
+are reported. An image that cannot be looked up or checked, e.g. because the catalog is unreachable, fails the test as
+an error.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.3.7",
+	},
+
 	TestExtractNodeInformationIdentifier: {
 		Identifier: TestExtractNodeInformationIdentifier,
 		Type:       informativeResult,
@@ -339,7 +438,8 @@ underlying Node, and let OpenShift handle the heavy lifting of configuring advan
 underlying Node.  This test case applies only to Nodes that are configured with the "worker" MachineConfigSet.  First,
 the "worker" MachineConfig is polled, and the Hugepage settings are extracted.  Next, the underlying Nodes are polled
 for configured HugePages through inspection of /proc/meminfo.  The results are compared, and the test passes only if
-they are the same.`),
+they are the same.  On clusters without MachineConfigs, the Hugepage settings are extracted from the kernel command line
+of the Node instead.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 
@@ -367,6 +467,77 @@ test case requires the Deployment of the debug daemonset.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
 
+	TestICMPv6ConnectivityIdentifier: {
+		Identifier: TestICMPv6ConnectivityIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF is able to communicate via the Default OpenShift network. In some rare cases,
+CNFs may require routing table changes in order to communicate over the Default network. To exclude a particular pod
+from ICMPv6 connectivity tests, add the test-network-function.com/skip_connectivity_tests label to it. The label value is not important, only its presence.`,
+		Description: formDescription(TestICMPv6ConnectivityIdentifier,
+			`checks that each CNF Container is able to communicate via ICMPv6 on the Default OpenShift network.  This
+test case requires the Deployment of the debug daemonset and is skipped on single stack IPv4 clusters.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestICMPv6ConnectivityMultusIdentifier: {
+		Identifier: TestICMPv6ConnectivityMultusIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the CNF is able to communicate via the Multus network(s). In some rare cases,
+CNFs may require routing table changes in order to communicate over the Multus network(s). To exclude a particular pod
+from ICMPv6 connectivity tests, add the test-network-function.com/skip_connectivity_tests label to it. The label value is not important, only its presence.`,
+		Description: formDescription(TestICMPv6ConnectivityMultusIdentifier,
+			`checks that each CNF Container is able to communicate via ICMPv6 on the Multus network(s).  This
+test case requires the Deployment of the debug daemonset and is skipped when no Multus network has IPv6 addresses.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestServicePortReachabilityIdentifier: {
+		Identifier: TestServicePortReachabilityIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the ports declared by the CNF containers are listening and are not blocked, e.g. by a
+NetworkPolicy, for the other CNF pods. Remove the declaration of the ports that are not served. To exclude a particular pod
+from connectivity tests, add the test-network-function.com/skip_connectivity_tests label to it.`,
+		Description: formDescription(TestServicePortReachabilityIdentifier,
+			`checks that each TCP, UDP and SCTP port declared by the CNF containers can be reached on the Default OpenShift
+network from another CNF pod. A UDP port is found unreachable when it answers with an ICMP port unreachable message,
+and cannot be checked otherwise, as a dropped datagram gets no answer either. This test case requires the Deployment
+of the debug daemonset and the nc (ncat) command in the debug pods.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestNetworkPolicyDenyAllIdentifier: {
+		Identifier: TestNetworkPolicyDenyAllIdentifier,
+		Type:       normativeResult,
+		Remediation: `Add to each CNF namespace a NetworkPolicy with an empty podSelector, the Ingress and Egress policyTypes
+and no ingress or egress rules, then allow the traffic the CNF needs with additional NetworkPolicies.`,
+		Description: formDescription(TestNetworkPolicyDenyAllIdentifier,
+			`checks that each namespace under test has NetworkPolicies denying all ingress and all egress traffic
+by default, i.e. selecting all the pods of the namespace without allowing any traffic.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestNetworkPolicyPodSelectedIdentifier: {
+		Identifier: TestNetworkPolicyPodSelectedIdentifier,
+		Type:       normativeResult,
+		Remediation: `Ensure that the podSelector of at least one NetworkPolicy of its namespace matches the labels of
+each CNF pod.`,
+		Description: formDescription(TestNetworkPolicyPodSelectedIdentifier,
+			`checks that each CNF pod is selected by at least one NetworkPolicy of its namespace, so that its
+traffic is restricted.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestNetworkPolicyDeclaredPortsIdentifier: {
+		Identifier: TestNetworkPolicyDeclaredPortsIdentifier,
+		Type:       normativeResult,
+		Remediation: `Add an ingress rule allowing the port, by number or by name, and its protocol to a NetworkPolicy
+selecting the pod, or remove the declaration of the ports that are not served.`,
+		Description: formDescription(TestNetworkPolicyDeclaredPortsIdentifier,
+			`checks that the NetworkPolicies selecting each CNF pod allow ingress traffic to the ports declared by
+its containers from at least one peer. Only the policies are checked, not whether the network plugin enforces them.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
 	TestNamespaceBestPracticesIdentifier: {
 		Identifier: TestNamespaceBestPracticesIdentifier,
 		Type:       normativeResult,
@@ -431,7 +602,8 @@ with no resourceNames under its rules.`),
 		Type:        normativeResult,
 		Remediation: `Ensure that your Operator is installed via OLM.`,
 		Description: formDescription(TestOperatorIsInstalledViaOLMIdentifier,
-			`tests whether a CNF Operator is installed via OLM.`),
+			`tests whether a CNF Operator is installed via OLM, i.e. that its subscription exists and installed the CSV under
+test.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.12 and Section 6.3.3",
 	},
 
@@ -549,7 +721,8 @@ that there are no changes to the following directories:
 		Remediation: `Ensure that boot parameters are set directly through the MachineConfigOperator, or indirectly through the PerformanceAddonOperator.  Boot parameters should not be changed directly through the Node, as OpenShift should manage
 the changes for you.`,
 		Description: formDescription(TestUnalteredStartupBootParamsIdentifier,
-			`tests that boot parameters are set through the MachineConfigOperator, and not set manually on the Node.`),
+			`tests that boot parameters are set through the MachineConfigOperator, and not set manually on the Node. Without
+MachineConfigs, it tests that the boot loader entry of the Node holds the kernel arguments the Node booted with.`),
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2.13 and 6.2.14",
 	},
 	TestListCniPluginsIdentifier: {
@@ -589,6 +762,44 @@ the changes for you.`,
 			`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
+	TestContainerProbesIdentifier: {
+		Identifier: TestContainerProbesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Define liveness and readiness probes in each container, and a startup probe in the containers that
+take more than 30 seconds to start instead of delaying their liveness probe. Let the liveness probe fail for at least 10
+seconds (periodSeconds x failureThreshold) before restarting the container, and keep the timeout of the probes lower
+than their period.`,
+		Description: formDescription(TestContainerProbesIdentifier,
+			`checks that each container of the CNF pods defines liveness and readiness probes, and a startup probe if it
+took more than 30 seconds to start, as estimated from the pod status, or if its liveness probe is delayed by more than
+30 seconds. It also checks that the timing of the probes does not restart the containers as soon as they stop
+answering, and that the timeout of each probe is lower than its period.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestPodResourcesIdentifier: {
+		Identifier: TestPodResourcesIdentifier,
+		Type:       normativeResult,
+		Remediation: `Set CPU and memory requests in each container, and limits where the container must not use more. Give
+the pods requesting hugepages or exclusive CPUs, and the containers listed in guaranteedQosContainers, CPU and memory
+limits equal to their requests, with an integer number of CPUs, so that they are in the Guaranteed QoS class.`,
+		Description: formDescription(TestPodResourcesIdentifier,
+			`reports the QoS class of each CNF pod and the CPU and memory requests and limits its containers miss, and
+checks that every container sets CPU and memory requests. The pods requesting hugepages, the pods whose CRI-O annotations
+give them exclusive CPUs and the pods of the containers selected in the configuration must be in the Guaranteed QoS
+class, and their containers must request an integer number of CPUs.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+	TestPodDisruptionBudgetIdentifier: {
+		Identifier: TestPodDisruptionBudgetIdentifier,
+		Type:       normativeResult,
+		Remediation: `Create one PodDisruptionBudget selecting the pods of each deployment and statefulset, with a
+maxUnavailable above 0 or a minAvailable lower than the number of replicas, so that a node can be drained.`,
+		Description: formDescription(TestPodDisruptionBudgetIdentifier,
+			`checks that exactly one PodDisruptionBudget selects the pods of each CNF deployment and statefulset, and
+that it lets at least one of them be evicted: its maxUnavailable is not 0 and its minAvailable is lower than the number
+of replicas.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
 	TestPodRecreationIdentifier: {
 		Identifier: TestPodRecreationIdentifier,
 		Type:       normativeResult,
@@ -596,10 +807,13 @@ the changes for you.`,
 			`tests that a CNF is configured to support High Availability.  
 			First, this test cordons and drains a Node that hosts the CNF Pod.  
 			Next, the test ensures that OpenShift can re-instantiate the Pod on another Node, 
-			and that the actual replica count matches the desired replica count.`),
+			and that the actual replica count matches the desired replica count.
+			The drain evicts the pods, so it honors their PodDisruptionBudgets as a cluster upgrade does: a drain blocked
+			by a PodDisruptionBudget fails the test.`),
 		Remediation: `Ensure that CNF Pod(s) utilize a configuration that supports High Availability.  
 			Additionally, ensure that there are available Nodes in the OpenShift cluster that can be utilized in the event that a host Node fails.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+		IntrusionSettings:     identifier.IntrusionSettings{ModifiesSystem: true},
 	},
 	TestSysctlConfigsIdentifier: {
 		Identifier: TestSysctlConfigsIdentifier,
@@ -607,7 +821,8 @@ the changes for you.`,
 		Description: formDescription(TestSysctlConfigsIdentifier,
 			`tests that no one has changed the node's sysctl configs after the node
 			was created, the tests works by checking if the sysctl configs are consistent with the
-			MachineConfig CR which defines how the node should be configured`),
+			MachineConfig CR which defines how the node should be configured, or without MachineConfigs with the kernel
+			command line the node booted with`),
 		Remediation:           `You should recreate the node or change the sysctls, recreating is recommended because there might be other unknown changes`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
 	},
@@ -622,6 +837,7 @@ the changes for you.`,
 			original replicaCount again for both min/max during the scale-out stage. lastly its restoring the original min/max replica of the deployment/s`),
 		Remediation:           `Make sure CNF deployments/replica sets can scale in/out successfully.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+		IntrusionSettings:     identifier.IntrusionSettings{ModifiesSystem: true},
 	},
 	TestStateFulSetScalingIdentifier: {
 		Identifier: TestStateFulSetScalingIdentifier,
@@ -634,6 +850,36 @@ the changes for you.`,
 			original replicaCount again for both min/max during the scale-out stage. lastly its restoring the original min/max replica of the statefulset/s`),
 		Remediation:           `Make sure CNF statefulsets/replica sets can scale in/out successfully.`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+		IntrusionSettings:     identifier.IntrusionSettings{ModifiesSystem: true},
+	},
+	TestRollingUpdateIdentifier: {
+		Identifier: TestRollingUpdateIdentifier,
+		Type:       normativeResult,
+		Description: formDescription(TestRollingUpdateIdentifier,
+			`tests that CNF deployments and statefulsets can be upgraded without service interruption. The test rolls
+out each of them by setting an annotation of its pod template, which replaces its pods as an image upgrade does. While
+the rollout runs, it samples the number of ready pods and pings the pods from a debug pod. The rollout must complete
+within 5 minutes, and at every sample at least one pod must be ready and, when pods were pinged, answer. Lastly the
+annotation is removed, which rolls the pods out again, and the original replicaCount is restored.`),
+		Remediation: `Make sure CNF deployments and statefulsets use a RollingUpdate strategy keeping pods available, e.g.
+more than one replica and a maxUnavailable lower than the replica count, and readiness probes telling when a new pod
+can serve.`,
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+		IntrusionSettings:     identifier.IntrusionSettings{ModifiesSystem: true},
+	},
+	TestKillRecoveryIdentifier: {
+		Identifier: TestKillRecoveryIdentifier,
+		Type:       normativeResult,
+		Description: formDescription(TestKillRecoveryIdentifier,
+			`tests that CNF deployments and statefulsets recover from the loss of a pod or of a container. The test kills the
+process of a container under test of each of them with SIGKILL from the debug pod of its node, then deletes one of
+its pods. After each disruption, it samples the number of ready pods and pings the pods from the network namespace of
+a container under test outside the podset. All the replicas must be ready again, and answer, within 5 minutes. The
+recovery times are reported per podset.`),
+		Remediation: `Make sure CNF pods are managed by a deployment or a statefulset, run more than one replica, start
+quickly, and have readiness probes telling when a new or restarted container can serve.`,
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+		IntrusionSettings:     identifier.IntrusionSettings{ModifiesSystem: true},
 	},
 	TestIsRedHatReleaseIdentifier: {
 		Identifier: TestIsRedHatReleaseIdentifier,
@@ -681,4 +927,56 @@ the changes for you.`,
 		Remediation:           `check that pod has automountServiceAccountToken set to false or pod is attached to service account which has automountServiceAccountToken set to false`,
 		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 13.7",
 	},
+
+	TestPodSecurityBaselineIdentifier: {
+		Identifier: TestPodSecurityBaselineIdentifier,
+		Type:       normativeResult,
+		Remediation: `Remove the settings breaking the reported controls from the pod spec: host namespaces, hostPath
+volumes, host ports, privileged containers, capabilities outside of the baseline set, unmasked /proc mounts, unconfined
+seccomp or AppArmor profiles, custom SELinux options and unsafe sysctls.`,
+		Description: formDescription(TestPodSecurityBaselineIdentifier,
+			`checks that each CNF pod meets the baseline profile of the Kubernetes Pod Security Standards, and reports
+every control of the profile each pod and container breaks.`),
+		BestPracticeReference: "[Pod Security Standards](https://kubernetes.io/docs/concepts/security/pod-security-standards/)",
+	},
+
+	TestPodSecurityRestrictedIdentifier: {
+		Identifier: TestPodSecurityRestrictedIdentifier,
+		Type:       informativeResult,
+		Remediation: `Meet the baseline profile, then set allowPrivilegeEscalation to false, runAsNonRoot to true, a
+RuntimeDefault or Localhost seccomp profile and drop ALL capabilities in the securityContext of each container, add no
+capability but NET_BIND_SERVICE, do not run as user 0 and only use configMap, csi, downwardAPI, emptyDir, ephemeral,
+persistentVolumeClaim, projected and secret volumes.`,
+		Description: formDescription(TestPodSecurityRestrictedIdentifier,
+			`checks that each CNF pod meets the restricted profile of the Kubernetes Pod Security Standards, which
+includes the baseline one, and reports every control of the profile each pod and container breaks.`),
+		BestPracticeReference: "[Pod Security Standards](https://kubernetes.io/docs/concepts/security/pod-security-standards/)",
+	},
+
+	TestPodSCCIdentifier: {
+		Identifier: TestPodSCCIdentifier,
+		Type:       normativeResult,
+		Remediation: `Make the pod meet the requirements of the restricted SCC, or of a custom SCC granting only what it
+needs, and do not grant its service account the use of the privileged, anyuid, hostaccess, hostmount-anyuid,
+hostnetwork or hostnetwork-v2 SCCs. Deviations can be waived in the waivers section of the configuration.`,
+		Description: formDescription(TestPodSCCIdentifier,
+			`reports, for each CNF pod, the SecurityContextConstraints it was admitted under and the ones its service
+account can use through the SCC users and groups or RBAC, and checks that it does not run under the privileged, anyuid,
+hostaccess, hostmount-anyuid, hostnetwork or hostnetwork-v2 SCCs.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
+
+	TestServiceAccountRBACIdentifier: {
+		Identifier: TestServiceAccountRBACIdentifier,
+		Type:       normativeResult,
+		Remediation: `Bind the service accounts of the CNF pods to Roles granting only the verbs and resources they need,
+without wildcards, and do not grant them pods/exec, reading secrets other than their own by name, escalate, bind,
+impersonate or nodes/proxy.`,
+		Description: formDescription(TestServiceAccountRBACIdentifier,
+			`resolves the Roles and ClusterRoles bound to the service account of each CNF pod, directly or through the
+group of the service accounts of its namespace, expanding aggregated ClusterRoles and wildcards, and checks that none
+grants pods/exec, reading secrets, escalate, bind, impersonate, nodes/proxy or wildcard verbs or resources. Each finding
+is reported with the chain of bindings and roles granting it.`),
+		BestPracticeReference: bestPracticeDocV1dot2URL + " Section 6.2",
+	},
 }
@@ -20,19 +20,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/test-network-function/test-network-function/pkg/availability"
 	"github.com/test-network-function/test-network-function/pkg/config"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/pdb"
+	"github.com/test-network-function/test-network-function/pkg/probes"
+	"github.com/test-network-function/test-network-function/pkg/resources"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/kill"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ping"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/rollout"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/scaling"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
 	"github.com/test-network-function/test-network-function/pkg/utils"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 
+	expect "github.com/google/goexpect"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
@@ -43,6 +53,9 @@ import (
 	ps "github.com/test-network-function/test-network-function/pkg/tnf/handlers/podsets"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 )
 
 const (
@@ -50,6 +63,11 @@ const (
 	drainTimeoutMinutes           = 5
 	scalingTimeout                = 60 * time.Second
 	scalingPollingPeriod          = 1 * time.Second
+	rolloutTimeout                = 5 * time.Minute
+	rolloutPollingPeriod          = 5 * time.Second
+	rolloutPingCount              = 1
+	killRecoveryTimeout           = 5 * time.Minute
+	killRecoveryPollingPeriod     = 2 * time.Second
 )
 
 var (
@@ -88,8 +106,7 @@ var drainTimeout = time.Duration(drainTimeoutMinutes) * time.Minute
 // All actual test code belongs below here.  Utilities belong above.
 //
 var _ = ginkgo.Describe(common.LifecycleTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, common.LifecycleTestKey) {
+	if selection.IsSuiteSelected(common.LifecycleTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -109,14 +126,22 @@ var _ = ginkgo.Describe(common.LifecycleTestKey, func() {
 
 		testShutdown(env)
 
+		testProbes(env)
+
+		testPodResources(env)
+
+		testPodDisruptionBudgets(env)
+
 		testPodAntiAffinity(env)
 
-		if common.Intrusive() {
-			testPodsRecreation(env)
+		testPodsRecreation(env)
 
-			testScaling(env)
-			testStateFulSetScaling(env)
-		}
+		testScaling(env)
+		testStateFulSetScaling(env)
+
+		testRollingUpdate(env)
+
+		testKillRecovery(env)
 
 		testOwner(env)
 	}
@@ -226,6 +251,7 @@ func runHpaScalingTest(podset *configsections.PodSet, context *interactive.Conte
 func testScaling(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestDeploymentScalingIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessIntrusive()
 		ginkgo.By("Testing deployment scaling")
 		defer restoreDeployments(env)
 		defer env.SetNeedsRefresh()
@@ -241,6 +267,7 @@ func testScaling(env *config.TestEnvironment) {
 func testStateFulSetScaling(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestStateFulSetScalingIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessIntrusive()
 		ginkgo.By("Testing StatefulSet scaling")
 		defer restoreStateFulSet(env)
 		defer env.SetNeedsRefresh()
@@ -278,6 +305,468 @@ func runScalingfunc(podset *configsections.PodSet, env *config.TestEnvironment)
 	}
 }
 
+func testRollingUpdate(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestRollingUpdateIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessIntrusive()
+		ginkgo.By("Testing the rolling update of deployments and statefulsets")
+		defer restoreStateFulSet(env)
+		defer restoreDeployments(env)
+		defer env.SetNeedsRefresh()
+
+		if len(env.DeploymentsUnderTest) == 0 && len(env.StateFulSetUnderTest) == 0 {
+			ginkgo.Skip("No test deployments or statefulsets found.")
+		}
+		sessions := rolloutProbeSessions(env)
+		if sessions == nil {
+			tnf.ClaimFilePrintf("No debug pod to ping the pods from, only their readiness is sampled during the rollouts")
+		}
+		badPodSets := 0
+		for _, podsets := range [][]configsections.PodSet{env.DeploymentsUnderTest, env.StateFulSetUnderTest} {
+			for i := range podsets {
+				if runRollingUpdate(&podsets[i], env, sessions) {
+					badPodSets++
+				}
+			}
+		}
+		if badPodSets > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d deployments or statefulsets did not roll out within %s without an availability gap, or could not be rolled out.",
+				badPodSets, rolloutTimeout))
+		}
+	})
+}
+
+// rolloutProbeSessions returns the debug sessions of the first node with a debug pod, to ping the pods from during
+// the rollouts, or nil if there is none.
+func rolloutProbeSessions(env *config.TestEnvironment) *parallel.SessionPool {
+	names := make([]string, 0, len(env.NodesUnderTest))
+	for name := range env.NodesUnderTest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if n := env.NodesUnderTest[name]; n.HasDebugPod() {
+			return n.DebugSessions()
+		}
+	}
+	return nil
+}
+
+// runRollingUpdate rolls out podset, sampling its availability until the rollout completes, records it, and returns
+// whether it failed the test.  The rollout annotation is removed before returning, which rolls the pods out again.
+func runRollingUpdate(podset *configsections.PodSet, env *config.TestEnvironment, sessions *parallel.SessionPool) (failed bool) {
+	ginkgo.By(fmt.Sprintf("Rolling out %s=%s, Replicas=%d (ns=%s)", string(podset.Type), podset.Name, podset.Replicas, podset.Namespace))
+	objectType := podSetObjectType(podset)
+	closeOcSessionsByPodset(env.ContainersUnderTest, podset)
+	context := env.GetLocalShellContext()
+	if !patchRolloutAnnotation(podset, time.Now().UTC().Format(time.RFC3339), context) {
+		tnf.ClaimFilePrintf("ERROR: Could not roll out %s %s/%s", podset.Type, podset.Namespace, podset.Name)
+		tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.CheckError, "could not patch the pod template")
+		return true
+	}
+	defer undoRollout(podset, context)
+
+	samples, complete := watchRollout(podset, context, sessions)
+	var reasons []string
+	if !complete {
+		reasons = append(reasons, fmt.Sprintf("rollout did not complete within %s", rolloutTimeout))
+	}
+	if gaps := availability.Gaps(samples); len(gaps) > 0 {
+		reasons = append(reasons, availability.Reason(gaps))
+	}
+	if len(reasons) == 0 {
+		tnf.ClaimFilePrintf("%s %s/%s rolled out in %s", podset.Type, podset.Namespace, podset.Name,
+			samples[len(samples)-1].Time.Sub(samples[0].Time).Round(time.Second))
+		tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.Compliant, "")
+		return false
+	}
+	reason := strings.Join(reasons, "; ")
+	tnf.ClaimFilePrintf("FAILURE: %s %s/%s: %s", podset.Type, podset.Namespace, podset.Name, reason)
+	return tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.NonCompliant, reason).Failed()
+}
+
+// watchRollout samples the availability of podset until its rollout completes or rolloutTimeout elapses, and returns
+// the samples and whether the rollout completed.
+func watchRollout(podset *configsections.PodSet, context *interactive.Context, sessions *parallel.SessionPool) (samples []availability.Sample, complete bool) {
+	key := podset.Namespace + ":" + podset.Name
+	for start := time.Now(); time.Since(start) < rolloutTimeout; time.Sleep(rolloutPollingPeriod) {
+		sample := availability.Sample{Time: time.Now()}
+		podsets, _ := GetPodSets(podset.Namespace, podset.Type, context)
+		sample.Ready = podsets[key].Ready
+		if sessions != nil && len(podset.PodLabels) > 0 {
+			sample.Probed, sample.Reachable = pingPodSetPods(podset, sessions)
+		}
+		samples = append(samples, sample)
+		if isRolloutComplete(podset, context) {
+			return samples, true
+		}
+	}
+	return samples, false
+}
+
+// pingPodSetPods pings the pods of podset that are not being deleted from a debug pod, and returns the number of pods
+// pinged and of pods that answered.
+func pingPodSetPods(podset *configsections.PodSet, sessions *parallel.SessionPool) (probed, reachable int) {
+	pods, err := autodiscover.GetPodSetPods(podset.Namespace, podset.PodLabels)
+	if err != nil {
+		log.Warnf("Unable to get the pods of %s %s/%s: %v", podset.Type, podset.Namespace, podset.Name, err)
+		return 0, 0
+	}
+	for _, pod := range pods.Items {
+		ips := pod.GetIPs()
+		if pod.Metadata.DeletionTimestamp != "" || len(ips) == 0 {
+			continue
+		}
+		probed++
+		err = sessions.Use(func(nodeOc *interactive.Oc) bool {
+			answered, keepSession := pingFromNode(nodeOc, ips[0])
+			if answered {
+				reachable++
+			}
+			return keepSession
+		})
+		if err != nil {
+			log.Warnf("Unable to open a debug session to ping pod %s/%s: %v", pod.Metadata.Namespace, pod.Metadata.Name, err)
+		}
+	}
+	return probed, reachable
+}
+
+// pingFromNode pings ip from nodeOc, a session to a debug pod, and returns whether it answered and whether the session
+// can be reused.
+func pingFromNode(nodeOc *interactive.Oc, ip string) (answered, keepSession bool) {
+	pingTester := ping.NewPing(common.DefaultTimeout, ip, rolloutPingCount)
+	test, err := tnf.NewTest(nodeOc.GetExpecter(), pingTester, []reel.Handler{pingTester}, nodeOc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
+	if err != nil {
+		return false, !reel.IsTimeout(err)
+	}
+	transmitted, received, _ := pingTester.GetStats()
+	return result == tnf.SUCCESS && received > 0 && received == transmitted, true
+}
+
+// patchRolloutAnnotation sets the rollout annotation of the pod template of podset to value, or removes it when value
+// is empty, and returns whether it succeeded.
+func patchRolloutAnnotation(podset *configsections.PodSet, value string, context *interactive.Context) bool {
+	handler := rollout.NewRollout(common.DefaultTimeout, podset.Namespace, podset.Name, string(podset.Type), value)
+	test, err := tnf.NewTest(context.GetExpecter(), handler, []reel.Handler{handler}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
+	return err == nil && result == tnf.SUCCESS
+}
+
+// isRolloutComplete returns whether the rollout of podset completed.
+func isRolloutComplete(podset *configsections.PodSet, context *interactive.Context) bool {
+	handler := rollout.NewStatus(common.DefaultTimeout, podset.Namespace, podset.Name, string(podset.Type))
+	test, err := tnf.NewTest(context.GetExpecter(), handler, []reel.Handler{handler}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
+	if err != nil || result != tnf.SUCCESS {
+		return false
+	}
+	log.Debugf("Rollout of %s %s/%s: %s", podset.Type, podset.Namespace, podset.Name, handler.GetStatus())
+	return handler.IsComplete()
+}
+
+// undoRollout removes the rollout annotation of podset, which rolls its pods out again, and waits for the rollout to
+// complete.
+func undoRollout(podset *configsections.PodSet, context *interactive.Context) {
+	if !patchRolloutAnnotation(podset, "", context) {
+		log.Errorf("Could not remove the %s annotation of %s %s/%s", rollout.AnnotationName, podset.Type, podset.Namespace, podset.Name)
+		return
+	}
+	for start := time.Now(); time.Since(start) < rolloutTimeout; time.Sleep(rolloutPollingPeriod) {
+		if isRolloutComplete(podset, context) {
+			return
+		}
+	}
+	collectNodeAndPendingPodInfo(podset.Namespace, context)
+	log.Errorf("The rollout of %s %s/%s removing the %s annotation did not complete within %s", podset.Type, podset.Namespace,
+		podset.Name, rollout.AnnotationName, rolloutTimeout)
+}
+
+func testKillRecovery(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestKillRecoveryIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessIntrusive()
+		ginkgo.By("Testing the recovery of deployments and statefulsets from pod deletions and container kills")
+		defer restoreStateFulSet(env)
+		defer restoreDeployments(env)
+		defer env.SetNeedsRefresh()
+
+		if len(env.DeploymentsUnderTest) == 0 && len(env.StateFulSetUnderTest) == 0 {
+			ginkgo.Skip("No test deployments or statefulsets found.")
+		}
+		badPodSets := 0
+		for _, podsets := range [][]configsections.PodSet{env.DeploymentsUnderTest, env.StateFulSetUnderTest} {
+			for i := range podsets {
+				if runKillRecovery(&podsets[i], env) {
+					badPodSets++
+				}
+			}
+		}
+		if badPodSets > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d deployments or statefulsets did not recover within %s from a pod deletion or a container kill, or could not be disrupted.",
+				badPodSets, killRecoveryTimeout))
+		}
+	})
+}
+
+// peerProber pings the pods of a podset from the network namespace of a peer container, a container under test
+// outside the podset, through the debug pod of its node.
+type peerProber struct {
+	peer     configsections.ContainerIdentifier
+	sessions *parallel.SessionPool
+	pid      string
+}
+
+// newPeerProber returns a prober pinging from the first container under test that is not in one of pods and whose
+// node has a debug pod, or nil if there is none.
+func newPeerProber(env *config.TestEnvironment, pods []*autodiscover.PodResource) *peerProber {
+	inPodSet := map[string]bool{}
+	for _, pod := range pods {
+		inPodSet[pod.Metadata.Namespace+"/"+pod.Metadata.Name] = true
+	}
+	cids := make([]configsections.ContainerIdentifier, 0, len(env.ContainersUnderTest))
+	for cid := range env.ContainersUnderTest {
+		cids = append(cids, cid)
+	}
+	sort.Slice(cids, func(i, j int) bool {
+		return cids[i].String() < cids[j].String()
+	})
+	for _, cid := range cids {
+		n, ok := env.NodesUnderTest[cid.NodeName]
+		if inPodSet[cid.Namespace+"/"+cid.PodName] || !ok || !n.HasDebugPod() {
+			continue
+		}
+		return &peerProber{peer: cid, sessions: n.DebugSessions()}
+	}
+	return nil
+}
+
+// ping pings ip from the peer container and returns whether it answered.
+func (p *peerProber) ping(ip string) (answered bool) {
+	err := p.sessions.Use(func(nodeOc *interactive.Oc) bool {
+		if p.pid == "" {
+			p.pid = utils.GetContainerPID(p.peer.NodeName, nodeOc, p.peer.ContainerUID, p.peer.ContainerRuntime)
+		}
+		pingTester := ping.NewPingNsenter(common.DefaultTimeout, p.pid, ip, rolloutPingCount)
+		test, err := tnf.NewTest(nodeOc.GetExpecter(), pingTester, []reel.Handler{pingTester}, nodeOc.GetErrorChannel())
+		gomega.Expect(err).To(gomega.BeNil())
+		result, err := test.Run()
+		if err != nil {
+			return !reel.IsTimeout(err)
+		}
+		transmitted, received, _ := pingTester.GetStats()
+		answered = result == tnf.SUCCESS && received > 0 && received == transmitted
+		return true
+	})
+	if err != nil {
+		log.Warnf("Unable to open a debug session on node %s to ping %s: %v", p.peer.NodeName, ip, err)
+	}
+	return answered
+}
+
+// readyPodSetPods returns the ready pods of podset, sorted by name.
+func readyPodSetPods(podset *configsections.PodSet) ([]*autodiscover.PodResource, error) {
+	pods, err := autodiscover.GetPodSetPods(podset.Namespace, podset.PodLabels)
+	if err != nil {
+		return nil, err
+	}
+	var ready []*autodiscover.PodResource
+	for _, pod := range pods.Items {
+		if pod.IsReady() {
+			ready = append(ready, pod)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].Metadata.Name < ready[j].Metadata.Name
+	})
+	return ready, nil
+}
+
+// runKillRecovery kills a container of podset, then deletes a pod of it, measuring how long podset takes to recover
+// from each, records it, and returns whether it failed the test. A podset covered by a waiver is not disrupted.  The container is killed first, as the containers of
+// the pod replacing a deleted one are not under test.
+func runKillRecovery(podset *configsections.PodSet, env *config.TestEnvironment) (failed bool) {
+	ginkgo.By(fmt.Sprintf("Disrupting %s=%s, Replicas=%d (ns=%s)", string(podset.Type), podset.Name, podset.Replicas, podset.Namespace))
+	objectType := podSetObjectType(podset)
+	if justification, waived := tnf.WaiverOf(&tnf.CheckedObject{Type: objectType, Namespace: podset.Namespace, Name: podset.Name}); waived {
+		tnf.ClaimFilePrintf("%s %s/%s is not disrupted, a waiver covers it: %s", podset.Type, podset.Namespace, podset.Name, justification)
+		return tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.Waived, "not disrupted, waived: "+justification).Failed()
+	}
+	if len(podset.PodLabels) == 0 {
+		return tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.CheckError, "no pod template labels to find its pods by").Failed()
+	}
+	var recoveries []availability.Recovery
+	for _, disrupt := range []func(*configsections.PodSet, *config.TestEnvironment) (*availability.Recovery, error){killPodSetContainer, deletePodSetPod} {
+		recovery, err := disrupt(podset, env)
+		if err != nil {
+			tnf.ClaimFilePrintf("ERROR: %s %s/%s: %v", podset.Type, podset.Namespace, podset.Name, err)
+			return tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.CheckError, err.Error()).Failed()
+		}
+		recoveries = append(recoveries, *recovery)
+	}
+
+	status := tnf.Compliant
+	reasons := make([]string, len(recoveries))
+	for i := range recoveries {
+		reasons[i] = recoveries[i].String()
+		if !recoveries[i].Recovered() {
+			status = tnf.NonCompliant
+		}
+	}
+	reason := strings.Join(reasons, "; ")
+	tnf.ClaimFilePrintf("%s %s/%s: %s", podset.Type, podset.Namespace, podset.Name, reason)
+	return tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, status, reason).Failed()
+}
+
+// deletePodSetPod deletes the first ready pod of podset and measures how long podset takes to recover.
+func deletePodSetPod(podset *configsections.PodSet, env *config.TestEnvironment) (*availability.Recovery, error) {
+	pods, err := readyPodSetPods(podset)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no ready pod to delete")
+	}
+	victim := pods[0]
+	prober := newPeerProber(env, pods)
+	context := env.GetLocalShellContext()
+	handler := kill.NewPodDelete(common.DefaultTimeout, victim.Metadata.Namespace, victim.Metadata.Name)
+	start := time.Now()
+	if killed, _ := runKillHandler(handler, context.GetExpecter(), context.GetErrorChannel()); !killed {
+		return nil, fmt.Errorf("could not delete pod %s", victim.Metadata.Name)
+	}
+	closeOcSessionsByPod(env.ContainersUnderTest, victim.Metadata.Namespace, victim.Metadata.Name)
+
+	samples := watchRecovery(podset, func(pod *autodiscover.PodResource) bool {
+		return pod.Metadata.UID != victim.Metadata.UID
+	}, prober)
+	recovery := availability.NewRecovery(fmt.Sprintf("pod %s deleted", victim.Metadata.Name), start, samples, podset.Replicas, prober != nil)
+	return &recovery, nil
+}
+
+// killPodSetContainer kills the first container under test of the ready pods of podset whose node has a debug pod,
+// and measures how long podset takes to recover.
+func killPodSetContainer(podset *configsections.PodSet, env *config.TestEnvironment) (*availability.Recovery, error) {
+	pods, err := readyPodSetPods(podset)
+	if err != nil {
+		return nil, err
+	}
+	victim, pod := podSetContainer(env, pods)
+	if victim == nil {
+		return nil, fmt.Errorf("no container under test in a ready pod on a node with a debug pod to kill")
+	}
+	restarts := pod.GetRestartCount(victim.ContainerName)
+	prober := newPeerProber(env, pods)
+	var killed bool
+	start := time.Now()
+	err = env.NodesUnderTest[victim.NodeName].DebugSessions().Use(func(nodeOc *interactive.Oc) bool {
+		pid := utils.GetContainerPID(victim.NodeName, nodeOc, victim.ContainerUID, victim.ContainerRuntime)
+		start = time.Now()
+		var keepSession bool
+		killed, keepSession = runKillHandler(kill.NewContainerKill(common.DefaultTimeout, pid), nodeOc.GetExpecter(), nodeOc.GetErrorChannel())
+		return keepSession
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not open a debug session on node %s: %v", victim.NodeName, err)
+	}
+	if !killed {
+		return nil, fmt.Errorf("could not kill container %s/%s", victim.PodName, victim.ContainerName)
+	}
+	closeOcSessionsByPod(env.ContainersUnderTest, victim.Namespace, victim.PodName)
+
+	samples := watchRecovery(podset, func(p *autodiscover.PodResource) bool {
+		return p.Metadata.UID != pod.Metadata.UID || p.GetRestartCount(victim.ContainerName) > restarts
+	}, prober)
+	recovery := availability.NewRecovery(fmt.Sprintf("container %s/%s killed", victim.PodName, victim.ContainerName),
+		start, samples, podset.Replicas, prober != nil)
+	return &recovery, nil
+}
+
+// podSetContainer returns the first container under test of pods whose node has a debug pod, with its pod, or nil if
+// there is none.
+func podSetContainer(env *config.TestEnvironment, pods []*autodiscover.PodResource) (*configsections.ContainerIdentifier, *autodiscover.PodResource) {
+	var candidates []configsections.ContainerIdentifier
+	for cid := range env.ContainersUnderTest {
+		if n, ok := env.NodesUnderTest[cid.NodeName]; ok && n.HasDebugPod() {
+			candidates = append(candidates, cid)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].String() < candidates[j].String()
+	})
+	for _, pod := range pods {
+		for i := range candidates {
+			if candidates[i].Namespace == pod.Metadata.Namespace && candidates[i].PodName == pod.Metadata.Name {
+				return &candidates[i], pod
+			}
+		}
+	}
+	return nil, nil
+}
+
+// watchRecovery samples podset until it recovered from a disruption or killRecoveryTimeout elapsed, and returns the
+// samples.  A ready pod counts only when recovered returns true for it, e.g. when it is not the deleted pod.  When
+// prober is not nil, the pods are pinged once replicas of them are ready.
+func watchRecovery(podset *configsections.PodSet, recovered func(*autodiscover.PodResource) bool, prober *peerProber) []availability.Sample {
+	var samples []availability.Sample
+	for start := time.Now(); time.Since(start) < killRecoveryTimeout; time.Sleep(killRecoveryPollingPeriod) {
+		pods, err := readyPodSetPods(podset)
+		if err != nil {
+			log.Warnf("Unable to get the pods of %s %s/%s: %v", podset.Type, podset.Namespace, podset.Name, err)
+		}
+		sample := availability.Sample{Time: time.Now()}
+		for _, pod := range pods {
+			if recovered(pod) {
+				sample.Ready++
+			}
+		}
+		if prober != nil && sample.Ready >= podset.Replicas {
+			for _, pod := range pods {
+				if ips := pod.GetIPs(); len(ips) > 0 {
+					sample.Probed++
+					if prober.ping(ips[0]) {
+						sample.Reachable++
+					}
+				}
+			}
+		}
+		samples = append(samples, sample)
+		if sample.Ready >= podset.Replicas && sample.Reachable == sample.Probed {
+			break
+		}
+	}
+	return samples
+}
+
+// runKillHandler runs handler, a pod deletion or a container kill, and returns whether it succeeded and whether the
+// session it ran in can be reused.
+func runKillHandler(handler *kill.Kill, expecter *expect.Expecter, errorChannel <-chan error) (killed, keepSession bool) {
+	test, err := tnf.NewTest(expecter, handler, []reel.Handler{handler}, errorChannel)
+	gomega.Expect(err).To(gomega.BeNil())
+	result, err := test.Run()
+	if err != nil {
+		log.Warnf("%s failed: %v", strings.Join(handler.Args(), " "), err)
+		return false, !reel.IsTimeout(err)
+	}
+	return result == tnf.SUCCESS, true
+}
+
+// closeOcSessionsByPod closes the sessions to the containers of the pod called podName, which are lost when the pod
+// is deleted or one of its containers is killed.
+func closeOcSessionsByPod(containers map[configsections.ContainerIdentifier]*configsections.Container, namespace, podName string) {
+	for cid, c := range containers {
+		if cid.Namespace == namespace && cid.PodName == podName {
+			log.Infof("Closing session to %s %s", cid.PodName, cid.ContainerName)
+			c.CloseOc()
+			delete(containers, cid)
+		}
+	}
+}
+
 func testNodeSelector(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodNodeSelectorAndAffinityBestPractices)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
@@ -292,11 +781,16 @@ func testNodeSelector(env *config.TestEnvironment) {
 			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
 
-			test.RunWithCallbacks(nil, func() {
+			test.RunWithCallbacks(func() {
+				tnf.RecordPod(podNamespace, podName, tnf.Compliant, "")
+			}, func() {
 				tnf.ClaimFilePrintf("FAILURE: Pod %s/%s has nodeSelector/nodeAffinity rule", podNamespace, podName)
-				badPods = append(badPods, *podUnderTest)
+				if tnf.RecordPod(podNamespace, podName, tnf.NonCompliant, "nodeSelector/nodeAffinity rule found").Failed() {
+					badPods = append(badPods, *podUnderTest)
+				}
 			}, func(err error) {
 				tnf.ClaimFilePrintf("ERROR: Pod %s/%s, error: %v", podNamespace, podName, err)
+				tnf.RecordPod(podNamespace, podName, tnf.CheckError, err.Error())
 				badPods = append(badPods, *podUnderTest)
 			})
 		}
@@ -387,9 +881,12 @@ func testTerminationGracePeriodOnPods(pods []*configsections.Pod, context *inter
 		if lastAppliedConfig.Spec.TerminationGracePeriodSeconds == -1 {
 			tnf.ClaimFilePrintf("Pod %s (ns %s) spec does not have a terminationGracePeriodSeconds value set. Default value (%d) will be used.",
 				pod.Name, pod.Namespace, defaultTerminationGracePeriod)
-			badPods = append(badPods, *pod)
+			if tnf.RecordPod(pod.Namespace, pod.Name, tnf.NonCompliant, "terminationGracePeriodSeconds not set").Failed() {
+				badPods = append(badPods, *pod)
+			}
 		} else {
 			log.Infof("Pod %s (ns %s) last-applied-configuration's terminationGracePeriodSeconds: %d", pod.Name, pod.Namespace, lastAppliedConfig.Spec.TerminationGracePeriodSeconds)
+			tnf.RecordPod(pod.Namespace, pod.Name, tnf.Compliant, "")
 		}
 
 		log.Debugf("Number of unamanaged pods processed: %d", numUnmanagedPods)
@@ -447,6 +944,101 @@ func testShutdown(env *config.TestEnvironment) {
 	})
 }
 
+func testProbes(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestContainerProbesIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		badContainers := 0
+		for _, podUnderTest := range env.PodsUnderTest {
+			ginkgo.By(fmt.Sprintf("Checking the probes of the containers of pod %s (ns %s)", podUnderTest.Name, podUnderTest.Namespace))
+			pod, err := autodiscover.GetPod(podUnderTest.Namespace, podUnderTest.Name)
+			if err != nil {
+				tnf.ClaimFilePrintf("Failed to get pod %s/%s: %v", podUnderTest.Namespace, podUnderTest.Name, err)
+				tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, tnf.CheckError, err.Error())
+				badContainers++
+				continue
+			}
+			for i := range pod.Spec.Containers {
+				c := &pod.Spec.Containers[i]
+				status, reason := tnf.Compliant, ""
+				if findings := probes.Check(c, probes.StartTime(pod, c.Name)); len(findings) > 0 {
+					status, reason = tnf.NonCompliant, probes.Reason(findings)
+					tnf.ClaimFilePrintf("Container %s (Pod %s ns %s): %s", c.Name, pod.Name, pod.Namespace, reason)
+				}
+				if tnf.RecordContainer(pod.Namespace, pod.Name, c.Name, status, reason).Failed() {
+					badContainers++
+				}
+			}
+		}
+		if badContainers > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d containers have missing or unsound probes, or could not be checked.", badContainers))
+		}
+	})
+}
+
+func testPodResources(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodResourcesIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		badPods := 0
+		for _, podUnderTest := range env.PodsUnderTest {
+			ginkgo.By(fmt.Sprintf("Checking the resources of pod %s (ns %s)", podUnderTest.Name, podUnderTest.Namespace))
+			pod, err := autodiscover.GetPod(podUnderTest.Namespace, podUnderTest.Name)
+			if err != nil {
+				tnf.ClaimFilePrintf("Failed to get pod %s/%s: %v", podUnderTest.Namespace, podUnderTest.Name, err)
+				tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, tnf.CheckError, err.Error())
+				badPods++
+				continue
+			}
+			if recordPodResources(pod, env.Config.GuaranteedQoSContainers) {
+				badPods++
+			}
+		}
+		if badPods > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d pods miss resource requests or are not in the QoS class they need, or could not be checked.", badPods))
+		}
+	})
+}
+
+// recordPodResources records pod with its QoS class and each of its containers with the requests and limits it
+// misses, and returns whether any of them failed the test.
+func recordPodResources(pod *corev1.Pod, guaranteed []configsections.ContainerSelector) (failed bool) {
+	qos, findings := resources.Check(pod, func(container string) bool {
+		for i := range guaranteed {
+			if guaranteed[i].Selects(pod.Namespace, pod.Name, container) {
+				return true
+			}
+		}
+		return false
+	})
+	byContainer := map[string][]resources.Finding{}
+	for _, f := range findings {
+		byContainer[f.Container] = append(byContainer[f.Container], f)
+	}
+	compliance := func(findings []resources.Finding) tnf.ComplianceStatus {
+		if _, fails := resources.Reason(findings); fails {
+			return tnf.NonCompliant
+		}
+		return tnf.Compliant
+	}
+
+	reason := fmt.Sprintf("QoS class %s", qos)
+	if podReason, _ := resources.Reason(byContainer[""]); podReason != "" {
+		reason += "; " + podReason
+	}
+	tnf.ClaimFilePrintf("Pod %s/%s: %s", pod.Namespace, pod.Name, reason)
+	failed = tnf.RecordPod(pod.Namespace, pod.Name, compliance(byContainer[""]), reason).Failed()
+	for i := range pod.Spec.Containers {
+		name := pod.Spec.Containers[i].Name
+		containerReason, _ := resources.Reason(byContainer[name])
+		if containerReason != "" {
+			tnf.ClaimFilePrintf("Container %s (Pod %s ns %s): %s", name, pod.Name, pod.Namespace, containerReason)
+		}
+		if tnf.RecordContainer(pod.Namespace, pod.Name, name, compliance(byContainer[name]), containerReason).Failed() {
+			failed = true
+		}
+	}
+	return failed
+}
+
 func shutdownTest(podNamespace, podName string, context *interactive.Context) bool {
 	passed := true
 	values := make(map[string]interface{})
@@ -458,16 +1050,80 @@ func shutdownTest(podNamespace, podName string, context *interactive.Context) bo
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(test).ToNot(gomega.BeNil())
 
-	test.RunWithCallbacks(nil, func() {
+	test.RunWithCallbacks(func() {
+		tnf.RecordPod(podNamespace, podName, tnf.Compliant, "")
+	}, func() {
 		tnf.ClaimFilePrintf("FAILURE: Pod %s/%s does not have pre-stop configured", podNamespace, podName)
-		passed = false
+		passed = !tnf.RecordPod(podNamespace, podName, tnf.NonCompliant, "pre-stop not configured").Failed()
 	}, func(err error) {
 		tnf.ClaimFilePrintf("ERROR: Pod %s/%s, error: %v", podNamespace, podName, err)
+		tnf.RecordPod(podNamespace, podName, tnf.CheckError, err.Error())
 		passed = false
 	})
 	return passed
 }
 
+func testPodDisruptionBudgets(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodDisruptionBudgetIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		if len(env.DeploymentsUnderTest) == 0 && len(env.StateFulSetUnderTest) == 0 {
+			ginkgo.Skip("No test deployments or statefulsets found.")
+		}
+		pdbsByNamespace := map[string][]policyv1.PodDisruptionBudget{}
+		badPodSets := 0
+		podsets := append(append([]configsections.PodSet{}, env.DeploymentsUnderTest...), env.StateFulSetUnderTest...)
+		for i := range podsets {
+			podset := &podsets[i]
+			ginkgo.By(fmt.Sprintf("Checking the PodDisruptionBudget of %s %s (ns %s)", podset.Type, podset.Name, podset.Namespace))
+			pdbs, ok := pdbsByNamespace[podset.Namespace]
+			if !ok {
+				list, err := autodiscover.GetPodDisruptionBudgets(podset.Namespace)
+				if err != nil {
+					tnf.ClaimFilePrintf("Failed to get the PodDisruptionBudgets of namespace %s: %v", podset.Namespace, err)
+					tnf.RecordPodSet(podSetObjectType(podset), podset.Namespace, podset.Name, tnf.CheckError, err.Error())
+					badPodSets++
+					continue
+				}
+				pdbs = list.Items
+				pdbsByNamespace[podset.Namespace] = pdbs
+			}
+			if recordPodDisruptionBudget(podset, pdbs) {
+				badPodSets++
+			}
+		}
+		if badPodSets > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d deployments or statefulsets miss a PodDisruptionBudget letting them be evicted, or could not be checked.", badPodSets))
+		}
+	})
+}
+
+// podSetObjectType returns the type podset is recorded as.
+func podSetObjectType(podset *configsections.PodSet) tnf.ObjectType {
+	if podset.Type == configsections.StateFulSet {
+		return tnf.StatefulSetObject
+	}
+	return tnf.DeploymentObject
+}
+
+// recordPodDisruptionBudget records podset with the findings about its PodDisruptionBudget among pdbs, and returns
+// whether it failed the test.
+func recordPodDisruptionBudget(podset *configsections.PodSet, pdbs []policyv1.PodDisruptionBudget) (failed bool) {
+	objectType := podSetObjectType(podset)
+	findings, err := pdb.Check(pdbs, podset.PodLabels, podset.Replicas)
+	if err != nil {
+		tnf.ClaimFilePrintf("ERROR: %s %s/%s, error: %v", podset.Type, podset.Namespace, podset.Name, err)
+		tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.CheckError, err.Error())
+		return true
+	}
+	if len(findings) == 0 {
+		tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.Compliant, "")
+		return false
+	}
+	reason := pdb.Reason(findings)
+	tnf.ClaimFilePrintf("FAILURE: %s %s/%s: %s", podset.Type, podset.Namespace, podset.Name, reason)
+	return tnf.RecordPodSet(objectType, podset.Namespace, podset.Name, tnf.NonCompliant, reason).Failed()
+}
+
 func cleanupNodeDrain(env *config.TestEnvironment, nodeName string) {
 	uncordonNode(nodeName, env.GetLocalShellContext())
 	for _, ns := range env.NameSpacesUnderTest {
@@ -515,6 +1171,7 @@ func testPodsRecreation(env *config.TestEnvironment) {
 
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodRecreationIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessIntrusive()
 		ginkgo.By("Testing node draining effect of deployment")
 		ginkgo.By(fmt.Sprintf("test deployment in namespace %s", env.NameSpacesUnderTest))
 		for _, ns := range env.NameSpacesUnderTest {
@@ -589,6 +1246,14 @@ func drainNode(node string, context *interactive.Context) {
 	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 	result, err := test.Run()
+	if result == tnf.FAILURE {
+		if blocked := tester.GetPDBBlockedPods(); len(blocked) > 0 {
+			tnf.ClaimFilePrintf("FAILURE: Drain of node %s was blocked by the PodDisruptionBudgets of pods %s", node, strings.Join(blocked, ", "))
+		} else {
+			tnf.ClaimFilePrintf("FAILURE: Drain of node %s was blocked by a PodDisruptionBudget", node)
+		}
+		ginkgo.Fail(fmt.Sprintf("Drain of node %s was blocked by a PodDisruptionBudget.", node))
+	}
 	if err != nil || result == tnf.ERROR {
 		log.Fatalf("Test skipped because of draining node failure - platform issue")
 	}
@@ -675,11 +1340,16 @@ func testOwner(env *config.TestEnvironment) {
 			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
 			gomega.Expect(err).To(gomega.BeNil())
 
-			test.RunWithCallbacks(nil, func() {
+			test.RunWithCallbacks(func() {
+				tnf.RecordPod(podNamespace, podName, tnf.Compliant, "")
+			}, func() {
 				tnf.ClaimFilePrintf("FAILURE: Pod %s/%s is not owned by a replica set", podNamespace, podName)
-				failedPods = append(failedPods, podUnderTest)
+				if tnf.RecordPod(podNamespace, podName, tnf.NonCompliant, "not owned by a replica set").Failed() {
+					failedPods = append(failedPods, podUnderTest)
+				}
 			}, func(err error) {
 				tnf.ClaimFilePrintf("ERROR: Pod %s/%s, error: %v", podNamespace, podName, err)
+				tnf.RecordPod(podNamespace, podName, tnf.CheckError, err.Error())
 				failedPods = append(failedPods, podUnderTest)
 			})
 		}
@@ -700,6 +1370,7 @@ func testImagePolicy(env *config.TestEnvironment) {
 			ContainerCount := podUnderTest.ContainerCount
 			values["POD_NAMESPACE"] = podUnderTest.Namespace
 			values["POD_NAME"] = podUnderTest.Name
+			status, reason := tnf.Compliant, ""
 			for i := 0; i < ContainerCount; i++ {
 				values["CONTAINER_NUM"] = i
 				tester, handlers := utils.NewGenericTesterAndValidate(relativeimagepullpolicyTestPath, common.RelativeSchemaPath, values)
@@ -709,12 +1380,17 @@ func testImagePolicy(env *config.TestEnvironment) {
 
 				test.RunWithCallbacks(nil, func() {
 					tnf.ClaimFilePrintf("FAILURE: Pod %s/%s does not set imagePullPolicy to IfNotPresent", podUnderTest.Namespace, podUnderTest.Name)
-					failedPods = append(failedPods, podUnderTest)
+					if status == tnf.Compliant {
+						status, reason = tnf.NonCompliant, "imagePullPolicy is not IfNotPresent"
+					}
 				}, func(err error) {
 					tnf.ClaimFilePrintf("ERROR: Pod %s/%s, error: %v", podUnderTest.Namespace, podUnderTest.Name, err)
-					failedPods = append(failedPods, podUnderTest)
+					status, reason = tnf.CheckError, err.Error()
 				})
 			}
+			if tnf.RecordPod(podUnderTest.Namespace, podUnderTest.Name, status, reason).Failed() {
+				failedPods = append(failedPods, podUnderTest)
+			}
 		}
 		if n := len(failedPods); n > 0 {
 			log.Debugf("Pods with incorrect image pull policy: %+v", failedPods)
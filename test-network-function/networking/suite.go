@@ -19,12 +19,13 @@ package networking
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/test-network-function/test-network-function/pkg/config"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
@@ -32,15 +33,21 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function/pkg/config/autodiscover"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
+	"github.com/test-network-function/test-network-function/pkg/networkpolicy"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/nodeport"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/ping"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/podnodename"
+	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/portprobe"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/utils"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
+	networkingv1 "k8s.io/api/networking/v1"
 )
 
 const (
@@ -50,8 +57,31 @@ const (
 	ocCommandTimeOut    = time.Second * 10
 	indexprotocolname   = 0
 	indexport           = 4
+
+	// portProbeConnectTimeout is how long nc waits for a port to accept a connection.
+	portProbeConnectTimeout = 5 * time.Second
 )
 
+// ipVersion is the IP family a connectivity test runs on.
+type ipVersion string
+
+const (
+	ipv4 ipVersion = "IPv4"
+	ipv6 ipVersion = "IPv6"
+)
+
+// filterIPs returns the addresses of ips of the given version.
+func (v ipVersion) filterIPs(ips []string) []string {
+	var filtered []string
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		if parsed != nil && (parsed.To4() != nil) == (v == ipv4) {
+			filtered = append(filtered, ip)
+		}
+	}
+	return filtered
+}
+
 type key struct {
 	port     int
 	protocol string
@@ -119,8 +149,7 @@ func printNetTestContextMap(netsUnderTest map[string]netTestContext) string {
 
 // Runs the "generic" CNF test cases.
 var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, common.NetworkingTestKey) {
+	if selection.IsSuiteSelected(common.NetworkingTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -132,11 +161,13 @@ var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
 		ginkgo.AfterEach(env.CloseLocalShellContext)
 
 		ginkgo.Context("Both Pods are on the Default network", func() {
-			testDefaultNetworkConnectivity(env, defaultNumPings)
+			testDefaultNetworkConnectivity(env, defaultNumPings, ipv4)
+			testDefaultNetworkConnectivity(env, defaultNumPings, ipv6)
 		})
 
 		ginkgo.Context("Both Pods are connected via a Multus Overlay Network", func() {
-			testMultusNetworkConnectivity(env, defaultNumPings)
+			testMultusNetworkConnectivity(env, defaultNumPings, ipv4)
+			testMultusNetworkConnectivity(env, defaultNumPings, ipv6)
 		})
 		ginkgo.Context("Should not have type of nodePort", func() {
 			testNodePort(env)
@@ -144,6 +175,12 @@ var _ = ginkgo.Describe(common.NetworkingTestKey, func() {
 		ginkgo.Context("Should not have type of listen port and declared port", func() {
 			testListenAndDeclared(env)
 		})
+		ginkgo.Context("Declared ports are reachable from other pods", func() {
+			testPortReachability(env)
+		})
+		ginkgo.Context("NetworkPolicies", func() {
+			testNetworkPolicies(env)
+		})
 	}
 })
 
@@ -189,44 +226,78 @@ func processContainerIpsPerNet(containerID *configsections.ContainerIdentifier,
 }
 
 // runNetworkingTests takes a map netTestContext, e.g. one context per network attachment
-// and runs pings test with it. Returns a network name to a slice of bad target IPs map.
-func runNetworkingTests(netsUnderTest map[string]netTestContext, count int) map[string][]string {
+// and runs pings test with it. Returns a network name to a slice of bad target IPs map. The test is skipped when there
+// is no address of the given version to ping, rather than passing without having pinged anything.
+func runNetworkingTests(netsUnderTest map[string]netTestContext, count int, version ipVersion) map[string][]string {
 	tnf.ClaimFilePrintf("%s", printNetTestContextMap(netsUnderTest))
 	log.Debugf("%s", printNetTestContextMap(netsUnderTest))
 	if len(netsUnderTest) == 0 {
-		ginkgo.Skip("There are no networks to test, skipping test")
+		ginkgo.Skip(fmt.Sprintf("There are no networks with %s addresses to test, skipping test", version))
 	}
 
-	badNets := map[string][]string{} // maps a net name to a list of failed destination IPs
-	for netName, netUnderTest := range netsUnderTest {
+	// Sort the networks so that the pings are reported in the same order from one run to the other.
+	netNames := make([]string, 0, len(netsUnderTest))
+	for netName := range netsUnderTest {
+		netNames = append(netNames, netName)
+	}
+	sort.Strings(netNames)
+
+	env := config.GetTestEnvironment()
+	runner := parallel.NewRunner(common.ParallelWorkers())
+	var destIPs []string
+	for _, netName := range netNames {
+		netUnderTest := netsUnderTest[netName]
 		if len(netUnderTest.destTargets) == 0 {
-			ginkgo.Skip(fmt.Sprintf("There are no containers to ping for network %s. A minimum of 2 containers is needed to run a ping test (a source and a destination) Skipping test", netName))
+			tnf.ClaimFilePrintf("There are no containers to ping for network %s. A minimum of 2 containers with %s addresses is needed to run a ping test (a source and a destination) Skipping network", netName, version)
+			continue
 		}
 		ginkgo.By(fmt.Sprintf("Ping tests on network %s. Number of target IPs: %d", netName, len(netUnderTest.destTargets)))
+		sourceContainerID := netUnderTest.testerSource.containerIdentifier
+		gomega.Expect(env.NodesUnderTest[sourceContainerID.NodeName]).To(gomega.Not(gomega.BeNil()))
+		debugSessions := env.NodesUnderTest[sourceContainerID.NodeName].DebugSessions()
 		for _, aDestIP := range netUnderTest.destTargets {
 			ginkgo.By(fmt.Sprintf("a Ping is issued from %s(%s) %s to %s(%s) %s",
-				netUnderTest.testerSource.containerIdentifier.PodName,
-				netUnderTest.testerSource.containerIdentifier.ContainerName,
+				sourceContainerID.PodName,
+				sourceContainerID.ContainerName,
 				netUnderTest.testerSource.ip, aDestIP.containerIdentifier.PodName,
 				aDestIP.containerIdentifier.ContainerName,
 				aDestIP.ip))
-			testPass := testPing(netUnderTest.testerContainerNodeOc, netUnderTest.testerSource.containerIdentifier, aDestIP, count)
-			if !testPass {
-				if failedDestIps, netFound := badNets[netName]; netFound {
-					badNets[netName] = append(failedDestIps, aDestIP.ip)
-				} else {
-					badNets[netName] = []string{aDestIP.ip}
+			aDestIP := aDestIP
+			destIPs = append(destIPs, aDestIP.ip)
+			runner.Add(netName, func(result *parallel.Result) {
+				err := debugSessions.Use(func(nodeOc *interactive.Oc) bool {
+					return testPing(nodeOc, sourceContainerID, aDestIP, count, result)
+				})
+				if err != nil {
+					result.Errorf(err, "ERROR: unable to open a session to node %s to ping %s: %v", sourceContainerID.NodeName, aDestIP.ip, err)
 				}
-			}
+			})
 		}
 	}
+	if len(destIPs) == 0 {
+		ginkgo.Skip(fmt.Sprintf("There are no %s addresses to ping, skipping test", version))
+	}
+	results := runner.Run()
+	results.Print(tnf.ClaimFilePrintf)
 
+	badNets := map[string][]string{} // maps a net name to a list of failed destination IPs
+	for i, result := range results {
+		status, reason := result.Compliance()
+		if tnf.RecordIP(result.Key, destIPs[i], status, reason).Failed() {
+			badNets[result.Key] = append(badNets[result.Key], destIPs[i])
+		}
+	}
 	return badNets
 }
-func testDefaultNetworkConnectivity(env *config.TestEnvironment, count int) {
-	ginkgo.When("Testing Default network connectivity", func() {
-		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestICMPv4ConnectivityIdentifier)
+func testDefaultNetworkConnectivity(env *config.TestEnvironment, count int, version ipVersion) {
+	ginkgo.When(fmt.Sprintf("Testing Default network %s connectivity", version), func() {
+		id := identifiers.TestICMPv4ConnectivityIdentifier
+		if version == ipv6 {
+			id = identifiers.TestICMPv6ConnectivityIdentifier
+		}
+		testID := identifiers.XformToGinkgoItIdentifier(id)
 		ginkgo.It(testID, ginkgo.Label(testID), func() {
+			common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(id)...)
 			netsUnderTest := make(map[string]netTestContext)
 			for _, pod := range env.PodsUnderTest {
 				// The first container is used to get the network namespace
@@ -237,25 +308,37 @@ func testDefaultNetworkConnectivity(env *config.TestEnvironment, count int) {
 				}
 				netKey := "default" //nolint:goconst // only used once
 				defaultIPAddress := []string{pod.DefaultNetworkIPAddress}
+				if version == ipv6 {
+					defaultIPAddress = []string{pod.DefaultNetworkIPv6Address}
+				}
+				if defaultIPAddress[0] == "" {
+					tnf.ClaimFilePrintf("Skipping pod %s because it has no %s address (default interface)", pod.Name, version)
+					continue
+				}
 				gomega.Expect(env).To(gomega.Not(gomega.BeNil()))
 				gomega.Expect(env.NodesUnderTest[aContainerInPod.NodeName]).To(gomega.Not(gomega.BeNil()))
 				gomega.Expect(env.NodesUnderTest[aContainerInPod.NodeName].DebugContainer.GetOc()).To(gomega.Not(gomega.BeNil()))
 				nodeOc := env.NodesUnderTest[aContainerInPod.NodeName].DebugContainer.GetOc()
 				processContainerIpsPerNet(&aContainerInPod.ContainerIdentifier, netKey, defaultIPAddress, netsUnderTest, nodeOc)
 			}
-			badNets := runNetworkingTests(netsUnderTest, count)
+			badNets := runNetworkingTests(netsUnderTest, count, version)
 
 			if n := len(badNets); n > 0 {
 				log.Warnf("Failed nets: %+v", badNets)
-				ginkgo.Fail(fmt.Sprintf("%d nets failed the default network ping test.", n))
+				ginkgo.Fail(fmt.Sprintf("%d nets failed the default network %s ping test.", n, version))
 			}
 		})
 	})
 }
-func testMultusNetworkConnectivity(env *config.TestEnvironment, count int) {
-	ginkgo.When("Testing Multus network connectivity", func() {
-		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestICMPv4ConnectivityMultusIdentifier)
+func testMultusNetworkConnectivity(env *config.TestEnvironment, count int, version ipVersion) {
+	ginkgo.When(fmt.Sprintf("Testing Multus network %s connectivity", version), func() {
+		id := identifiers.TestICMPv4ConnectivityMultusIdentifier
+		if version == ipv6 {
+			id = identifiers.TestICMPv6ConnectivityMultusIdentifier
+		}
+		testID := identifiers.XformToGinkgoItIdentifier(id)
 		ginkgo.It(testID, ginkgo.Label(testID), func() {
+			common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(id)...)
 			netsUnderTest := make(map[string]netTestContext)
 			for _, pod := range env.PodsUnderTest {
 				// The first container is used to get the network namespace
@@ -269,58 +352,297 @@ func testMultusNetworkConnectivity(env *config.TestEnvironment, count int) {
 					gomega.Expect(env.NodesUnderTest[aContainerInPod.NodeName]).To(gomega.Not(gomega.BeNil()))
 					gomega.Expect(env.NodesUnderTest[aContainerInPod.NodeName].DebugContainer.GetOc()).To(gomega.Not(gomega.BeNil()))
 					nodeOc := env.NodesUnderTest[aContainerInPod.NodeName].DebugContainer.GetOc()
-					processContainerIpsPerNet(&aContainerInPod.ContainerIdentifier, netKey, multusIPAddress, netsUnderTest, nodeOc)
+					processContainerIpsPerNet(&aContainerInPod.ContainerIdentifier, netKey, version.filterIPs(multusIPAddress), netsUnderTest, nodeOc)
 				}
 			}
-			badNets := runNetworkingTests(netsUnderTest, count)
+			badNets := runNetworkingTests(netsUnderTest, count, version)
 
 			if n := len(badNets); n > 0 {
 				log.Warnf("Failed nets: %+v", badNets)
-				ginkgo.Fail(fmt.Sprintf("%d nets failed the multus ping test.", n))
+				ginkgo.Fail(fmt.Sprintf("%d nets failed the multus %s ping test.", n, version))
 			}
 		})
 	})
 }
 
-// Test that a container can ping a target IP address.
-func testPing(initiatingPodNodeOc *interactive.Oc, sourceContainerID *configsections.ContainerIdentifier, targetContainerIP containerIP, count int) bool {
-	log.Infof("Sending ICMP traffic(%s to %s)", initiatingPodNodeOc.GetPodName(), targetContainerIP.ip)
-	env := config.GetTestEnvironment()
-	gomega.Expect(env).To(gomega.Not(gomega.BeNil()))
-	gomega.Expect(env.NodesUnderTest[sourceContainerID.NodeName]).To(gomega.Not(gomega.BeNil()))
-	gomega.Expect(env.NodesUnderTest[sourceContainerID.NodeName].DebugContainer.GetOc()).To(gomega.Not(gomega.BeNil()))
-	nodeOc := env.NodesUnderTest[sourceContainerID.NodeName].DebugContainer.GetOc()
+// Test that a container can ping a target IP address. The ping is run from nodeOc, a session to the debug pod
+// of the node running the source container, and reported to result. Returns false if nodeOc timed out and
+// should not be reused.
+func testPing(nodeOc *interactive.Oc, sourceContainerID *configsections.ContainerIdentifier, targetContainerIP containerIP, count int, result *parallel.Result) bool {
+	log.Infof("Sending ICMP traffic(%s to %s)", nodeOc.GetPodName(), targetContainerIP.ip)
 	containerPID := utils.GetContainerPID(sourceContainerID.NodeName, nodeOc, sourceContainerID.ContainerUID, sourceContainerID.ContainerRuntime)
 	pingTester := ping.NewPingNsenter(common.DefaultTimeout, containerPID, targetContainerIP.ip, count)
-	test, err := tnf.NewTest(initiatingPodNodeOc.GetExpecter(), pingTester, []reel.Handler{pingTester}, initiatingPodNodeOc.GetErrorChannel())
+	test, err := tnf.NewTest(nodeOc.GetExpecter(), pingTester, []reel.Handler{pingTester}, nodeOc.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
 
-	sourcePodName := initiatingPodNodeOc.GetPodName()
+	sourcePodName := nodeOc.GetPodName()
 	targetPodName := targetContainerIP.containerIdentifier.PodName
 
-	testResult := false
+	keepSession := true
 	test.RunWithCallbacks(func() {
 		transmitted, received, errors := pingTester.GetStats()
 		if received == transmitted && errors == 0 {
 			log.Infof("Ping test from pod %s to pod %s (ip %s) succeeded. Tx/Rx/Err: %d/%d/%d",
 				sourcePodName, targetPodName, targetContainerIP.ip, transmitted, received, errors)
-			testResult = true
 		} else {
-			tnf.ClaimFilePrintf("Ping test from pod %s to pod %s (ip: %s) failed. Tx/Rx/Err: %d/%d/%d",
+			result.Failf("Ping test from pod %s to pod %s (ip: %s) failed. Tx/Rx/Err: %d/%d/%d",
 				sourcePodName, targetPodName, targetContainerIP.ip, transmitted, received, errors)
 		}
 	}, func() {
-		tnf.ClaimFilePrintf("FAILURE: Ping test from pod %s to pod %s (ip: %s) failed.",
+		result.Failf("FAILURE: Ping test from pod %s to pod %s (ip: %s) failed.",
 			sourcePodName, targetPodName, targetContainerIP.ip)
 	}, func(err error) {
-		tnf.ClaimFilePrintf("ERROR: Ping test from pod %s to pod %s (ip: %s) failed. Error: %v",
+		result.Errorf(err, "ERROR: Ping test from pod %s to pod %s (ip: %s) failed. Error: %v",
 			sourcePodName, targetPodName, targetContainerIP.ip, err)
-		if reel.IsTimeout(err) {
-			env.NodesUnderTest[sourceContainerID.NodeName].DebugContainer.CloseOc()
+		keepSession = !reel.IsTimeout(err)
+	})
+
+	return keepSession
+}
+
+// portTarget is a declared port of a pod, probed from a container of another pod.
+type portTarget struct {
+	pod    *configsections.Pod
+	ip     string
+	port   key
+	source *configsections.ContainerIdentifier
+}
+
+// testPortReachability probes the ports declared by the containers of each pod from the next pod under test, on
+// the default network.
+func testPortReachability(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestServicePortReachabilityIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestServicePortReachabilityIdentifier)...)
+		var pods []*configsections.Pod
+		for _, pod := range env.PodsUnderTest {
+			if _, ok := env.ContainersToExcludeFromConnectivityTests[pod.ContainerList[0].ContainerIdentifier]; ok {
+				tnf.ClaimFilePrintf("Skipping pod %s because it is excluded from connectivity tests", pod.Name)
+				continue
+			}
+			pods = append(pods, pod)
+		}
+		if len(pods) < 2 { //nolint:gomnd // a source and a target pod
+			ginkgo.Skip("A minimum of 2 pods is needed to probe ports (a source and a target). Skipping test")
+		}
+
+		badPods := 0
+		var targets []portTarget
+		runner := parallel.NewRunner(common.ParallelWorkers())
+		for i, pod := range pods {
+			declaredPorts := make(map[key]string)
+			var err error
+			for c := 0; c < pod.ContainerCount && err == nil; c++ {
+				err = declaredPortList(c, pod.Name, pod.Namespace, declaredPorts)
+			}
+			if err != nil {
+				tnf.ClaimFilePrintf("Failed to get the declared ports of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+				tnf.RecordPod(pod.Namespace, pod.Name, tnf.CheckError, err.Error())
+				badPods++
+				continue
+			}
+			ip := pod.DefaultNetworkIPAddress
+			if net.ParseIP(ip) == nil {
+				ip = pod.DefaultNetworkIPv6Address
+			}
+			if net.ParseIP(ip) == nil {
+				tnf.ClaimFilePrintf("Failed to probe the declared ports of pod %s/%s: no default network IP address", pod.Namespace, pod.Name)
+				tnf.RecordPod(pod.Namespace, pod.Name, tnf.CheckError, "no default network IP address")
+				badPods++
+				continue
+			}
+			source := &pods[(i+1)%len(pods)].ContainerList[0].ContainerIdentifier
+			gomega.Expect(env.NodesUnderTest[source.NodeName]).To(gomega.Not(gomega.BeNil()))
+			debugSessions := env.NodesUnderTest[source.NodeName].DebugSessions()
+			for _, port := range sortedPorts(declaredPorts) {
+				target := portTarget{pod: pod, ip: ip, port: port, source: source}
+				targets = append(targets, target)
+				runner.Add(fmt.Sprintf("%s/%s:%d/%s", pod.Namespace, pod.Name, port.port, port.protocol), func(result *parallel.Result) {
+					err := debugSessions.Use(func(nodeOc *interactive.Oc) bool {
+						return testPort(nodeOc, &target, result)
+					})
+					if err != nil {
+						result.Errorf(err, "ERROR: unable to open a session to node %s to probe %s: %v", target.source.NodeName, result.Key, err)
+					}
+				})
+			}
+		}
+		results := runner.Run()
+		results.Print(tnf.ClaimFilePrintf)
+
+		badPorts := 0
+		for i, result := range results {
+			status, reason := result.Compliance()
+			t := targets[i]
+			if tnf.RecordPort(t.pod.Namespace, t.pod.Name, t.port.port, t.port.protocol, status, reason).Failed() {
+				badPorts++
+			}
+		}
+		if badPorts > 0 || badPods > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d declared ports are not reachable, %d pods could not be checked.", badPorts, badPods))
+		}
+	})
+}
+
+// getNetworkPolicies returns the NetworkPolicies of each namespace under test. A namespace whose policies could not be
+// listed is missing from the map and is recorded as an error.
+func getNetworkPolicies(env *config.TestEnvironment) map[string][]networkingv1.NetworkPolicy {
+	policies := make(map[string][]networkingv1.NetworkPolicy)
+	for _, ns := range env.NameSpacesUnderTest {
+		list, err := autodiscover.GetNetworkPolicies(ns)
+		if err != nil {
+			tnf.ClaimFilePrintf("Failed to get the NetworkPolicies of namespace %s: %v", ns, err)
+			tnf.RecordNamespace(ns, tnf.CheckError, err.Error())
+			continue
+		}
+		policies[ns] = list.Items
+	}
+	return policies
+}
+
+func testNetworkPolicies(env *config.TestEnvironment) {
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestNetworkPolicyDenyAllIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		policies := getNetworkPolicies(env)
+		bad := len(env.NameSpacesUnderTest) - len(policies)
+		for _, ns := range env.NameSpacesUnderTest {
+			nsPolicies, ok := policies[ns]
+			if !ok {
+				continue
+			}
+			var missing []string
+			ingress, egress := networkpolicy.DefaultDeny(nsPolicies)
+			if !ingress {
+				missing = append(missing, "ingress")
+			}
+			if !egress {
+				missing = append(missing, "egress")
+			}
+			if len(missing) == 0 {
+				tnf.RecordNamespace(ns, tnf.Compliant, "")
+				continue
+			}
+			reason := fmt.Sprintf("no NetworkPolicy denies all %s traffic", strings.Join(missing, " and "))
+			tnf.ClaimFilePrintf("Namespace %s: %s", ns, reason)
+			if tnf.RecordNamespace(ns, tnf.NonCompliant, reason).Failed() {
+				bad++
+			}
+		}
+		if bad > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d namespaces do not deny all traffic by default.", bad))
+		}
+	})
+
+	testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestNetworkPolicyPodSelectedIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		policies := getNetworkPolicies(env)
+		badPods := 0
+		for _, pod := range env.PodsUnderTest {
+			nsPolicies, ok := policies[pod.Namespace]
+			if !ok {
+				tnf.RecordPod(pod.Namespace, pod.Name, tnf.CheckError, "the NetworkPolicies of the namespace could not be listed")
+				badPods++
+				continue
+			}
+			selecting, err := networkpolicy.Selecting(nsPolicies, pod.Labels)
+			status, reason := tnf.Compliant, ""
+			switch {
+			case err != nil:
+				status, reason = tnf.CheckError, err.Error()
+			case len(selecting) == 0:
+				status, reason = tnf.NonCompliant, "not selected by any NetworkPolicy"
+			}
+			if reason != "" {
+				tnf.ClaimFilePrintf("Pod %s/%s: %s", pod.Namespace, pod.Name, reason)
+			}
+			if tnf.RecordPod(pod.Namespace, pod.Name, status, reason).Failed() {
+				badPods++
+			}
+		}
+		if badPods > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d pods are not selected by a NetworkPolicy or could not be checked.", badPods))
+		}
+	})
+
+	testID = identifiers.XformToGinkgoItIdentifier(identifiers.TestNetworkPolicyDeclaredPortsIdentifier)
+	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		policies := getNetworkPolicies(env)
+		badPorts, badPods := 0, 0
+		for _, pod := range env.PodsUnderTest {
+			if len(pod.DeclaredPorts) == 0 {
+				continue
+			}
+			nsPolicies, ok := policies[pod.Namespace]
+			if !ok {
+				tnf.RecordPod(pod.Namespace, pod.Name, tnf.CheckError, "the NetworkPolicies of the namespace could not be listed")
+				badPods++
+				continue
+			}
+			selecting, err := networkpolicy.Selecting(nsPolicies, pod.Labels)
+			if err != nil {
+				tnf.RecordPod(pod.Namespace, pod.Name, tnf.CheckError, err.Error())
+				badPods++
+				continue
+			}
+			for i := range pod.DeclaredPorts {
+				port := &pod.DeclaredPorts[i]
+				status, reason := tnf.Compliant, ""
+				if !networkpolicy.AllowsIngress(selecting, port) {
+					status, reason = tnf.NonCompliant, fmt.Sprintf("ingress to the port of container %s is not allowed by any NetworkPolicy", port.Container)
+					tnf.ClaimFilePrintf("Pod %s/%s port %d/%s: %s", pod.Namespace, pod.Name, port.Port, port.Protocol, reason)
+				}
+				if tnf.RecordPort(pod.Namespace, pod.Name, port.Port, port.Protocol, status, reason).Failed() {
+					badPorts++
+				}
+			}
+		}
+		if badPorts > 0 || badPods > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d declared ports are not allowed by the NetworkPolicies, %d pods could not be checked.", badPorts, badPods))
 		}
 	})
+}
 
-	return testResult
+// sortedPorts returns the ports so that they are probed, and reported, in the same order from one run to the other.
+func sortedPorts(ports map[key]string) []key {
+	keys := make([]key, 0, len(ports))
+	for k := range ports {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].port != keys[j].port {
+			return keys[i].port < keys[j].port
+		}
+		return keys[i].protocol < keys[j].protocol
+	})
+	return keys
+}
+
+// testPort probes a declared port from the source container of target. The probe is run from nodeOc, a session to
+// the debug pod of the node running the source container, and reported to result. Returns false if nodeOc timed
+// out and should not be reused.
+func testPort(nodeOc *interactive.Oc, target *portTarget, result *parallel.Result) bool {
+	source := target.source
+	containerPID := utils.GetContainerPID(source.NodeName, nodeOc, source.ContainerUID, source.ContainerRuntime)
+	probe := portprobe.NewPortProbeNsenter(common.DefaultTimeout, containerPID, target.ip, target.port.port, target.port.protocol, portProbeConnectTimeout)
+	test, err := tnf.NewTest(nodeOc.GetExpecter(), probe, []reel.Handler{probe}, nodeOc.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+
+	description := fmt.Sprintf("%s probe from pod %s to pod %s (ip: %s, port: %d)",
+		target.port.protocol, source.PodName, target.pod.Name, target.ip, target.port.port)
+	keepSession := true
+	test.RunWithCallbacks(func() {
+		log.Infof("%s succeeded.", description)
+	}, func() {
+		result.Failf("FAILURE: %s failed: %s", description, probe.Reason())
+	}, func(err error) {
+		if err != nil {
+			result.Errorf(err, "ERROR: %s failed: %s %v", description, probe.Reason(), err)
+			keepSession = !reel.IsTimeout(err)
+			return
+		}
+		result.Errorf(nil, "ERROR: %s failed: %s", description, probe.Reason())
+	})
+	return keepSession
 }
 
 func testNodePort(env *config.TestEnvironment) {
@@ -27,11 +27,11 @@ import (
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/pkg/utils"
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 //
@@ -53,9 +53,7 @@ var (
 	env *config.TestEnvironment = config.GetTestEnvironment()
 )
 var _ = ginkgo.Describe(common.ObservabilityTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-
-	if testcases.IsInFocus(conf.FocusStrings, common.ObservabilityTestKey) {
+	if selection.IsSuiteSelected(common.ObservabilityTestKey) {
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
 			gomega.Expect(len(env.PodsUnderTest)).ToNot(gomega.Equal(0))
@@ -87,13 +85,18 @@ func testLogging() {
 			gomega.Expect(err).To(gomega.BeNil())
 			gomega.Expect(test).ToNot(gomega.BeNil())
 
-			test.RunWithCallbacks(nil, func() {
+			test.RunWithCallbacks(func() {
+				tnf.RecordContainer(cutIdentifier.Namespace, cutIdentifier.PodName, cutIdentifier.ContainerName, tnf.Compliant, "")
+			}, func() {
 				tnf.ClaimFilePrintf("FAILURE: Container: %s (Pod %s ns %s) does not have any line of log to stderr/stdout",
 					cutIdentifier.ContainerName, cutIdentifier.PodName, cutIdentifier.Namespace)
-				failedCutIds = append(failedCutIds, cutIdentifier)
+				if tnf.RecordContainer(cutIdentifier.Namespace, cutIdentifier.PodName, cutIdentifier.ContainerName, tnf.NonCompliant, "no log lines to stderr/stdout").Failed() {
+					failedCutIds = append(failedCutIds, cutIdentifier)
+				}
 			}, func(err error) {
 				tnf.ClaimFilePrintf("ERROR: Container: %s (Pod %s) does not have any line of log to stderr/stdout. Error: %v",
 					cutIdentifier.ContainerName, cutIdentifier.PodName, cutIdentifier.Namespace, err)
+				tnf.RecordContainer(cutIdentifier.Namespace, cutIdentifier.PodName, cutIdentifier.ContainerName, tnf.CheckError, err.Error())
 				failedCutIds = append(failedCutIds, cutIdentifier)
 			})
 		}
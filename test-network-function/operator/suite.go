@@ -17,13 +17,12 @@
 package operator
 
 import (
+	"errors"
 	"fmt"
-	"path"
-	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
-	"github.com/test-network-function/test-network-function/pkg/utils"
+	"github.com/test-network-function/test-network-function/pkg/olm"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
@@ -32,10 +31,9 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
-	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/operator"
-	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 const (
@@ -45,26 +43,13 @@ const (
 )
 
 var (
-
-	// checkSubscriptionTestPath is the file location of the uncordon.json test case relative to the project root.
-	checkSubscriptionTestPath = path.Join("pkg", "tnf", "handlers", "checksubscription", "check-subscription.json")
-
-	// pathRelativeToRoot is used to calculate relative filepaths for the `test-network-function` executable entrypoint.
-	pathRelativeToRoot = path.Join("..")
-
-	// relativecheckSubscriptionTestPath is the relative path to the nodes.json test case.
-	relativecheckSubscriptionTestPath = path.Join(pathRelativeToRoot, checkSubscriptionTestPath)
-
-	// relativeSchemaPath is the relative path to the generic-test.schema.json JSON schema.
-	relativeSchemaPath = path.Join(pathRelativeToRoot, schemaPath)
-
-	// schemaPath is the path to the generic-test.schema.json JSON schema relative to the project root.
-	schemaPath = path.Join("schemas", "generic-test.schema.json")
+	// fetchedOperators holds the OLM resources of the operators under test, by namespace and CSV name, so that they
+	// are read once for all the checks.
+	fetchedOperators = map[string]*olm.Operator{}
 )
 
 var _ = ginkgo.Describe(testSpecName, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, testSpecName) {
+	if selection.IsSuiteSelected(testSpecName) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -82,29 +67,32 @@ var _ = ginkgo.Describe(testSpecName, func() {
 	}
 })
 
-// testOperatorsAreInstalledViaOLM ensures all configured operators have a proper OLM subscription.
+// testOperatorsAreInstalledViaOLM ensures all configured operators were installed by an OLM subscription.
 func testOperatorsAreInstalledViaOLM(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestOperatorIsInstalledViaOLMIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestOperatorIsInstalledViaOLMIdentifier)...)
 		badOperators := []configsections.Operator{}
-		for _, operatorInTest := range env.OperatorsUnderTest {
-			ginkgo.By(fmt.Sprintf("%s in namespace %s Should have a valid subscription", operatorInTest.SubscriptionName, operatorInTest.Namespace))
-			values := make(map[string]interface{})
-			values["SUBSCRIPTION_NAME"] = operatorInTest.SubscriptionName
-			values["SUBSCRIPTION_NAMESPACE"] = operatorInTest.Namespace
-			tester, handlers := utils.NewGenericTesterAndValidate(relativecheckSubscriptionTestPath, relativeSchemaPath, values)
-			context := env.GetLocalShellContext()
-			test, err := tnf.NewTest(context.GetExpecter(), *tester, handlers, context.GetErrorChannel())
-			gomega.Expect(err).To(gomega.BeNil())
-			gomega.Expect(test).ToNot(gomega.BeNil())
-
-			test.RunWithCallbacks(nil, func() {
-				tnf.ClaimFilePrintf("Operator %s doesn't have a proper OLM subscription.", operatorInTest.Name)
-				badOperators = append(badOperators, operatorInTest)
-			}, func(err error) {
-				tnf.ClaimFilePrintf("Operator %s doesn't have a proper OLM subscription. Error: %v", operatorInTest.Name, err)
-				badOperators = append(badOperators, operatorInTest)
-			})
+		getter := olm.NewOcGetter(env.GetLocalShellContext(), common.DefaultTimeout)
+		for i := range env.OperatorsUnderTest {
+			op := env.OperatorsUnderTest[i]
+			ginkgo.By(fmt.Sprintf("%s in namespace %s Should have a valid subscription", op.SubscriptionName, op.Namespace))
+			o := fetchOperator(getter, &env.OperatorsUnderTest[i])
+			if err := o.Err(olm.InstalledViaOLM); err != nil && !errors.Is(err, olm.ErrNotFound) {
+				tnf.ClaimFilePrintf("Operator %s doesn't have a proper OLM subscription. Error: %v", op.Name, err)
+				tnf.RecordOperator(op.Namespace, op.Name, tnf.CheckError, err.Error())
+				badOperators = append(badOperators, op)
+				continue
+			}
+			failures := olm.CheckInstalledViaOLM(o)
+			if len(failures) == 0 {
+				tnf.RecordOperator(op.Namespace, op.Name, tnf.Compliant, "")
+				continue
+			}
+			tnf.ClaimFilePrintf("Operator %s doesn't have a proper OLM subscription: %s", op.Name, failures)
+			if tnf.RecordOperator(op.Namespace, op.Name, tnf.NonCompliant, failures.String()).Failed() {
+				badOperators = append(badOperators, op)
+			}
 		}
 
 		if n := len(badOperators); n > 0 {
@@ -115,56 +103,69 @@ func testOperatorsAreInstalledViaOLM(env *config.TestEnvironment) {
 }
 
 func itRunsTestsOnOperator(env *config.TestEnvironment) {
+	testFile, err := testcases.LoadConfiguredTestFile(configuredTestFile)
+	gomega.Expect(testFile).ToNot(gomega.BeNil())
+	gomega.Expect(err).To(gomega.BeNil())
 	for _, testType := range testcases.GetConfiguredOperatorTests() {
-		testFile, err := testcases.LoadConfiguredTestFile(configuredTestFile)
-		gomega.Expect(testFile).ToNot(gomega.BeNil())
-		gomega.Expect(err).To(gomega.BeNil())
 		testConfigure := testcases.ContainsConfiguredTest(testFile.OperatorTest, testType)
-		renderedTestCase, err := testConfigure.RenderTestCaseSpec(testcases.Operator, testType)
-		gomega.Expect(err).To(gomega.BeNil())
-		gomega.Expect(renderedTestCase).ToNot(gomega.BeNil())
-		for _, testCase := range renderedTestCase.TestCase {
-			if testCase.SkipTest {
+		for _, checkName := range testConfigure.Tests {
+			check, ok := olm.Checks[checkName]
+			if !ok {
+				log.Warnf("Unknown operator test %s, known tests are %v", checkName, olm.CheckNames())
 				continue
 			}
-			runTestsOnOperator(env, testCase)
+			runTestsOnOperator(env, checkName, check)
 		}
 	}
 }
 
-//nolint:gocritic // ignore hugeParam error. Pointers to loop iterator vars are bad and `testCmd` is likely to be such.
-func runTestsOnOperator(env *config.TestEnvironment, testCase testcases.BaseTestCase) {
-	testID := identifiers.XformToGinkgoItIdentifierExtended(identifiers.TestOperatorInstallStatusIdentifier, testCase.Name)
+func runTestsOnOperator(env *config.TestEnvironment, checkName string, check olm.Check) {
+	testID := identifiers.XformToGinkgoItIdentifierExtended(identifiers.TestOperatorInstallStatusIdentifier, checkName)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestOperatorInstallStatusIdentifier)...)
 		badOperators := []configsections.Operator{}
-		for _, op := range env.OperatorsUnderTest {
-			if testCase.ExpectedType == testcases.Function {
-				for _, val := range testCase.ExpectedStatus {
-					testCase.ExpectedStatusFn(op.Name, testcases.StatusFunctionType(val))
+		getter := olm.NewOcGetter(env.GetLocalShellContext(), common.DefaultTimeout)
+		for i := range env.OperatorsUnderTest {
+			op := env.OperatorsUnderTest[i]
+			o := fetchOperator(getter, &env.OperatorsUnderTest[i])
+			if err := o.Err(checkName); err != nil {
+				tnf.ClaimFilePrintf("Operator %s failed TC: %s. Error: %v", op.Name, checkName, err)
+				if tnf.RecordOperator(op.Namespace, op.Name, tnf.CheckError, err.Error()).Failed() {
+					badOperators = append(badOperators, op)
 				}
+				continue
 			}
-			name := op.Name
-			args := []interface{}{name, op.Namespace}
-			cmdArgs := strings.Split(fmt.Sprintf(testCase.Command, args...), " ")
-			opInTest := operator.NewOperator(cmdArgs, name, op.Namespace, testCase.ExpectedStatus, testCase.ResultType, testCase.Action, common.DefaultTimeout)
-			gomega.Expect(opInTest).ToNot(gomega.BeNil())
-			context := env.GetLocalShellContext()
-			test, err := tnf.NewTest(context.GetExpecter(), opInTest, []reel.Handler{opInTest}, context.GetErrorChannel())
-			gomega.Expect(err).To(gomega.BeNil())
-			gomega.Expect(test).ToNot(gomega.BeNil())
-
-			test.RunWithCallbacks(nil, func() {
-				tnf.ClaimFilePrintf("Operator %s failed TC: %s", name, testCase.Name)
-				badOperators = append(badOperators, op)
-			}, func(err error) {
-				tnf.ClaimFilePrintf("Operator %s failed TC: %s. Error: %v", name, testCase.Name, err)
+			failures := check(o)
+			if len(failures) == 0 {
+				tnf.RecordOperator(op.Namespace, op.Name, tnf.Compliant, "")
+				continue
+			}
+			tnf.ClaimFilePrintf("Operator %s failed TC %s: %s", op.Name, checkName, failures)
+			if tnf.RecordOperator(op.Namespace, op.Name, tnf.NonCompliant, failures.String()).Failed() {
 				badOperators = append(badOperators, op)
-			})
+			}
 		}
 
 		if n := len(badOperators); n > 0 {
-			log.Warnf("Operators that failed TC %s: %+v", testCase.Name, badOperators)
-			ginkgo.Fail(fmt.Sprintf("%d operators failed TC %s", n, testCase.Name))
+			log.Warnf("Operators that failed TC %s: %+v", checkName, badOperators)
+			ginkgo.Fail(fmt.Sprintf("%d operators failed TC %s", n, checkName))
 		}
 	})
 }
+
+// fetchOperator returns the OLM resources of op, reading them on the first call only.
+func fetchOperator(getter olm.Getter, op *configsections.Operator) *olm.Operator {
+	key := op.Namespace + "/" + op.Name
+	if o, ok := fetchedOperators[key]; ok {
+		return o
+	}
+	subscriptionName := op.SubscriptionName
+	if subscriptionName == "" {
+		subscriptionName = op.Name
+	}
+	o := olm.Fetch(getter, op.Namespace, op.Name, subscriptionName)
+	o.ExpectedChannel = op.Channel
+	o.ExpectedInstallPlanApproval = op.InstallPlanApproval
+	fetchedOperators[key] = o
+	return o
+}
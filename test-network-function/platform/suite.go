@@ -29,13 +29,13 @@ import (
 
 	"github.com/test-network-function/test-network-function/pkg/config"
 	"github.com/test-network-function/test-network-function/pkg/config/configsections"
-	"github.com/test-network-function/test-network-function/pkg/tnf/testcases"
 
 	"github.com/test-network-function/test-network-function/test-network-function/common"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"github.com/test-network-function/test-network-function/pkg/containerruntime"
 	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/base/redhat"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/cnffsdiff"
@@ -47,9 +47,11 @@ import (
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/readbootconfig"
 	"github.com/test-network-function/test-network-function/pkg/tnf/handlers/sysctlallconfigsargs"
 	"github.com/test-network-function/test-network-function/pkg/tnf/interactive"
+	"github.com/test-network-function/test-network-function/pkg/tnf/parallel"
 	"github.com/test-network-function/test-network-function/pkg/tnf/reel"
 	utils "github.com/test-network-function/test-network-function/pkg/utils"
 	"github.com/test-network-function/test-network-function/test-network-function/results"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 const (
@@ -136,8 +138,7 @@ func getTaintedBitValues() []string {
 }
 
 var _ = ginkgo.Describe(common.PlatformAlterationTestKey, func() {
-	conf, _ := ginkgo.GinkgoConfiguration()
-	if testcases.IsInFocus(conf.FocusStrings, common.PlatformAlterationTestKey) {
+	if selection.IsSuiteSelected(common.PlatformAlterationTestKey) {
 		env := config.GetTestEnvironment()
 		ginkgo.BeforeEach(func() {
 			env.LoadAndRefresh()
@@ -146,13 +147,10 @@ var _ = ginkgo.Describe(common.PlatformAlterationTestKey, func() {
 		})
 		ginkgo.ReportAfterEach(results.RecordResult)
 		ginkgo.AfterEach(env.CloseLocalShellContext)
-		// use this boolean to turn off tests that require OS packages
-		if !common.IsNonOcpCluster() {
-			testContainersFsDiff(env)
-			testHugepages(env)
-			testBootParams(env)
-			testSysctlConfigs(env)
-		}
+		testContainersFsDiff(env)
+		testHugepages(env)
+		testBootParams(env)
+		testSysctlConfigs(env)
 		testTainted(env) // minikube tainted kernels are allowed via config
 		testIsRedHatRelease(env)
 	}
@@ -163,14 +161,20 @@ func testIsRedHatRelease(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestIsRedHatReleaseIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
 		ginkgo.By("should report a proper Red Hat version")
+		failedContainers := 0
 		for _, cut := range env.ContainersUnderTest {
-			testContainerIsRedHatRelease(cut)
+			if !testContainerIsRedHatRelease(cut) {
+				failedContainers++
+			}
+		}
+		if failedContainers > 0 {
+			ginkgo.Fail(fmt.Sprintf("%d containers are not Red Hat based.", failedContainers))
 		}
 	})
 }
 
 // testContainerIsRedHatRelease tests whether the container attached to oc is Red Hat based.
-func testContainerIsRedHatRelease(cut *configsections.Container) {
+func testContainerIsRedHatRelease(cut *configsections.Container) bool {
 	podName := cut.GetOc().GetPodName()
 	containerName := cut.GetOc().GetPodContainerName()
 	context := cut.GetOc()
@@ -178,7 +182,18 @@ func testContainerIsRedHatRelease(cut *configsections.Container) {
 	versionTester := redhat.NewRelease(common.DefaultTimeout)
 	test, err := tnf.NewTest(context.GetExpecter(), versionTester, []reel.Handler{versionTester}, context.GetErrorChannel())
 	gomega.Expect(err).To(gomega.BeNil())
-	test.RunAndValidate()
+	passed := true
+	test.RunWithCallbacks(func() {
+		tnf.RecordContainer(cut.Namespace, podName, containerName, tnf.Compliant, "")
+	}, func() {
+		tnf.ClaimFilePrintf("FAILURE: Container %s (pod %s ns %s) is not Red Hat based", containerName, podName, cut.Namespace)
+		passed = !tnf.RecordContainer(cut.Namespace, podName, containerName, tnf.NonCompliant, "not Red Hat based").Failed()
+	}, func(err error) {
+		tnf.ClaimFilePrintf("ERROR: Container %s (pod %s ns %s), error: %v", containerName, podName, cut.Namespace, err)
+		tnf.RecordContainer(cut.Namespace, podName, containerName, tnf.CheckError, err.Error())
+		passed = false
+	})
+	return passed
 }
 
 // testContainersFsDiff test that all CUT didn't install new packages are starting
@@ -186,35 +201,52 @@ func testContainersFsDiff(env *config.TestEnvironment) {
 	ginkgo.Context("Container does not have additional packages installed", func() {
 		testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestUnalteredBaseImageIdentifier)
 		ginkgo.It(testID, ginkgo.Label(testID), func() {
-			var badContainers []string
-			var errContainers []string
+			common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestUnalteredBaseImageIdentifier)...)
+			runner := parallel.NewRunner(common.ParallelWorkers())
+			var cutIDs []configsections.ContainerIdentifier
 			for _, cut := range env.ContainersUnderTest {
+				cutIDs = append(cutIDs, cut.ContainerIdentifier)
 				podName := cut.GetOc().GetPodName()
 				containerName := cut.GetOc().GetPodContainerName()
+				containerUID := cut.ContainerUID
+				containerRuntime := cut.ContainerRuntime
 				nodeName := cut.NodeName
 				ginkgo.By(fmt.Sprintf("%s(%s) should not install new packages after starting", podName, containerName))
-				nodeOc := env.NodesUnderTest[nodeName].DebugContainer.GetOc()
-				fsDiffTester := cnffsdiff.NewFsDiff(common.DefaultTimeout, cut.ContainerUID, nodeName)
-				test, err := tnf.NewTest(nodeOc.GetExpecter(), fsDiffTester, []reel.Handler{fsDiffTester}, nodeOc.GetErrorChannel())
-				gomega.Expect(err).To(gomega.BeNil())
-				var message string
-				test.RunWithCallbacks(nil, func() {
-					badContainers = append(badContainers, containerName)
-					message = fmt.Sprintf("pod %s container %s did update/install/modify additional packages", podName, containerName)
-				}, func(err error) {
-					errContainers = append(errContainers, containerName)
-					if reel.IsTimeout(err) {
-						env.NodesUnderTest[nodeName].DebugContainer.CloseOc()
+				debugSessions := env.NodesUnderTest[nodeName].DebugSessions()
+				runner.Add(containerName, func(result *parallel.Result) {
+					rt, err := containerruntime.Get(containerRuntime)
+					if err != nil {
+						result.Errorf(err, "Failed to check pod %s container %s for additional packages: %v", podName, containerName, err)
+						return
+					}
+					err = debugSessions.Use(func(nodeOc *interactive.Oc) bool {
+						fsDiffTester := cnffsdiff.NewFsDiff(common.DefaultTimeout, containerUID, rt)
+						test, err := tnf.NewTest(nodeOc.GetExpecter(), fsDiffTester, []reel.Handler{fsDiffTester}, nodeOc.GetErrorChannel())
+						gomega.Expect(err).To(gomega.BeNil())
+						keepSession := true
+						test.RunWithCallbacks(nil, func() {
+							result.Failf("pod %s container %s did update/install/modify additional packages", podName, containerName)
+						}, func(err error) {
+							keepSession = !reel.IsTimeout(err)
+							result.Errorf(err, "Failed to check pod %s container %s for additional packages due to: %v", podName, containerName, err)
+						})
+						return keepSession
+					})
+					if err != nil {
+						result.Errorf(err, "Failed to open a session to node %s to check pod %s container %s: %v", nodeName, podName, containerName, err)
 					}
-					message = fmt.Sprintf("Failed to check pod %s container %s for additional packages due to: %v", podName, containerName, err)
 				})
-				_, err = ginkgo.GinkgoWriter.Write([]byte(message))
-				if err != nil {
-					log.Errorf("Ginkgo writer could not write because: %s", err)
+			}
+			results := runner.Run()
+			results.Print(tnf.ClaimFilePrintf)
+			for i, result := range results {
+				status, reason := result.Compliance()
+				if !tnf.RecordContainer(cutIDs[i].Namespace, cutIDs[i].PodName, cutIDs[i].ContainerName, status, reason).Failed() {
+					result.Failed = false
 				}
 			}
-			gomega.Expect(badContainers).To(gomega.BeNil())
-			gomega.Expect(errContainers).To(gomega.BeNil())
+			gomega.Expect(results.FailedKeys()).To(gomega.BeNil())
+			gomega.Expect(results.ErroredKeys()).To(gomega.BeNil())
 		})
 	})
 }
@@ -314,31 +346,73 @@ func getSysctlConfigArgs(context *interactive.Oc) map[string]string {
 func testBootParams(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestUnalteredStartupBootParamsIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
-		context := env.GetLocalShellContext()
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestUnalteredStartupBootParamsIdentifier)...)
+		runner := parallel.NewRunner(common.ParallelWorkers())
+		var cutIDs []configsections.ContainerIdentifier
 		for _, cut := range env.ContainersUnderTest {
+			cutIDs = append(cutIDs, cut.ContainerIdentifier)
 			podName := cut.GetOc().GetPodName()
 			podNameSpace := cut.GetOc().GetPodNamespace()
 			targetContainerOc := cut.GetOc()
-			testBootParamsHelper(context, podName, podNameSpace, targetContainerOc)
+			ginkgo.By(fmt.Sprintf("Testing boot params for the pod's node %s/%s", podNameSpace, podName))
+			runner.Add(podNameSpace+"/"+podName, func(result *parallel.Result) {
+				// Each check needs its own local shell, the environment one can't be shared between goroutines.
+				context := interactive.GetContext(common.LogLevelTraceEnabled)
+				defer func() {
+					if err := (*context.GetExpecter()).Close(); err != nil {
+						log.Warnf("Failed to close local shell context due to %v", err)
+					}
+				}()
+				testBootParamsHelper(context, podName, podNameSpace, targetContainerOc, result)
+			})
+		}
+		results := runner.Run()
+		results.Print(tnf.ClaimFilePrintf)
+		for i, result := range results {
+			status, reason := result.Compliance()
+			if !tnf.RecordContainer(cutIDs[i].Namespace, cutIDs[i].PodName, cutIDs[i].ContainerName, status, reason).Failed() {
+				result.Failed = false
+			}
 		}
+		gomega.Expect(results.FailedKeys()).To(gomega.BeNil())
+		gomega.Expect(results.ErroredKeys()).To(gomega.BeNil())
 	})
 }
-func testBootParamsHelper(context *interactive.Context, podName, podNamespace string, targetContainerOc *interactive.Oc) {
-	ginkgo.By(fmt.Sprintf("Testing boot params for the pod's node %s/%s", podNamespace, podName))
+func testBootParamsHelper(context *interactive.Context, podName, podNamespace string, targetContainerOc *interactive.Oc, result *parallel.Result) {
 	nodeName := getPodNodeName(context, podName, podNamespace)
+	env := config.GetTestEnvironment()
+	hasMachineConfigs := common.ClusterCapabilities(env)[config.MachineConfigCapability]
+	var grubKernelConfigMap, nodeKernelArgsMap map[string]string
+	err := env.NodesUnderTest[nodeName].DebugSessions().Use(func(nodeOc *interactive.Oc) bool {
+		grubKernelConfigMap = getGrubKernelArgs(nodeOc)
+		if !hasMachineConfigs {
+			nodeKernelArgsMap = getCurrentKernelCmdlineArgs(nodeOc)
+		}
+		return true
+	})
+	if err != nil {
+		result.Errorf(err, "Failed to open a session to node %s to read the boot config: %v", nodeName, err)
+		return
+	}
+	if !hasMachineConfigs {
+		// Without MachineConfigs, the boot config is expected to hold the kernel arguments the node booted with.
+		for key, grubVal := range grubKernelConfigMap {
+			if nodeVal, ok := nodeKernelArgsMap[key]; ok && nodeVal != grubVal {
+				result.Failf("FAILURE: node %s grub kernel argument %s is %s, the node booted with %s", nodeName, key, grubVal, nodeVal)
+			}
+		}
+		return
+	}
+
 	mcName := getMcName(context, nodeName)
 	mcKernelArgumentsMap := getMcKernelArguments(context, mcName)
 	currentKernelArgsMap := getCurrentKernelCmdlineArgs(targetContainerOc)
-	env := config.GetTestEnvironment()
-	nodeOC := env.NodesUnderTest[nodeName].DebugContainer.GetOc()
-	grubKernelConfigMap := getGrubKernelArgs(nodeOC)
-
 	for key, mcVal := range mcKernelArgumentsMap {
-		if currentVal, ok := currentKernelArgsMap[key]; ok {
-			gomega.Expect(currentVal).To(gomega.Equal(mcVal))
+		if currentVal, ok := currentKernelArgsMap[key]; ok && currentVal != mcVal {
+			result.Failf("FAILURE: pod %s/%s kernel argument %s is %s, machine config %s has %s", podNamespace, podName, key, currentVal, mcName, mcVal)
 		}
-		if grubVal, ok := grubKernelConfigMap[key]; ok {
-			gomega.Expect(grubVal).To(gomega.Equal(mcVal))
+		if grubVal, ok := grubKernelConfigMap[key]; ok && grubVal != mcVal {
+			result.Failf("FAILURE: node %s grub kernel argument %s is %s, machine config %s has %s", nodeName, key, grubVal, mcName, mcVal)
 		}
 	}
 }
@@ -346,6 +420,7 @@ func testBootParamsHelper(context *interactive.Context, podName, podNamespace st
 func testSysctlConfigs(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestSysctlConfigsIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestSysctlConfigsIdentifier)...)
 		for _, podUnderTest := range env.PodsUnderTest {
 			podName := podUnderTest.Name
 			podNameSpace := podUnderTest.Namespace
@@ -360,11 +435,16 @@ func testSysctlConfigsHelper(podName, podNamespace string, context *interactive.
 	env := config.GetTestEnvironment()
 	nodeOc := env.NodesUnderTest[nodeName].DebugContainer.GetOc()
 	combinedSysctlSettings := getSysctlConfigArgs(nodeOc)
-	mcName := getMcName(context, nodeName)
-	mcKernelArgumentsMap := getMcKernelArguments(context, mcName)
+	var kernelArgumentsMap map[string]string
+	if common.ClusterCapabilities(env)[config.MachineConfigCapability] {
+		kernelArgumentsMap = getMcKernelArguments(context, getMcName(context, nodeName))
+	} else {
+		// Without MachineConfigs, the sysctls are expected to be the ones of the kernel command line the node booted with.
+		kernelArgumentsMap = getCurrentKernelCmdlineArgs(nodeOc)
+	}
 	for key, sysctlConfigVal := range combinedSysctlSettings {
-		if mcVal, ok := mcKernelArgumentsMap[key]; ok {
-			gomega.Expect(mcVal).To(gomega.Equal(sysctlConfigVal))
+		if kernelVal, ok := kernelArgumentsMap[key]; ok {
+			gomega.Expect(kernelVal).To(gomega.Equal(sysctlConfigVal))
 		}
 	}
 }
@@ -389,82 +469,101 @@ func decodeKernelTaints(bitmap uint64) (string, []string) {
 func testTainted(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestNonTaintedNodeKernelsIdentifier)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestNonTaintedNodeKernelsIdentifier)...)
 		ginkgo.By("Testing tainted nodes in cluster")
 
-		var taintedNodes []string
-		var errNodes []string
+		runner := parallel.NewRunner(common.ParallelWorkers())
+		var nodeNames []string
 		for _, node := range env.NodesUnderTest {
 			if !node.HasDebugPod() {
 				continue
 			}
+			nodeNames = append(nodeNames, node.Name)
 			log.Debug("Node has a debug pod")
-			context := node.DebugContainer.GetOc()
-			tester := nodetainted.NewNodeTainted(common.DefaultTimeout)
-			test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
-			gomega.Expect(err).To(gomega.BeNil())
-
-			var message string
-			test.RunWithCallbacks(func() {
-				message = fmt.Sprintf("Decoded tainted kernel causes (code=0) for node %s : None\n", node.Name)
-			}, func() {
-				var taintedBitmap uint64
-				nodeTaintsAccepted := true
-				taintedBitmap, err = strconv.ParseUint(tester.Match, 10, 32) //nolint:gomnd // base 10 and uint32
+			nodeName := node.Name
+			debugSessions := node.DebugSessions()
+			runner.Add(nodeName, func(result *parallel.Result) {
+				err := debugSessions.Use(func(context *interactive.Oc) bool {
+					testTaintedNode(env, nodeName, context, result)
+					return true
+				})
 				if err != nil {
-					message = fmt.Sprintf("Could not decode tainted kernel causes (code=%d) for node %s\n", taintedBitmap, node.Name)
-					return
-				}
-				taintMsg, individualTaints := decodeKernelTaints(taintedBitmap)
-
-				// We only will fail the tainted kernel check if the reason for the taint
-				// only pertains to `module was loaded`.
-				log.Debug("Checking for 'module was loaded' taints")
-				moduleCheck := false
-				for _, it := range individualTaints {
-					if strings.Contains(it, `module was loaded`) {
-						moduleCheck = true
-						break
-					}
-				}
-
-				if moduleCheck {
-					// Retrieve the modules from the node.
-					modules := utils.GetModulesFromNode(node.Name, context)
-					log.Debug("Got the modules from node")
-
-					// Loop through the modules looking for `InTree: Y`.
-					// If the module info does not contain this string, the module is "tainted".
-					taintedModules := getOutOfTreeModules(modules, node.Name, context)
-					log.Debug("Collected all of the tainted modules: ", taintedModules)
-					tnf.ClaimFilePrintf("Kernel Modules loaded that cause taints: ", taintedModules)
-					tnf.ClaimFilePrintf("Modules allowed via configuration: ", env.Config.AcceptedKernelTaints)
-
-					// Looks through the accepted taints listed in the tnf-config file.
-					// If all of the tainted modules show up in the configuration file, don't fail the test.
-					nodeTaintsAccepted = taintsAccepted(env.Config.AcceptedKernelTaints, taintedModules)
-				}
-
-				message = fmt.Sprintf("Decoded tainted kernel causes (code=%d) for node %s : %s\n", taintedBitmap, node.Name, taintMsg)
-				// Only add the tainted node to the slice if the taint is acceptable.
-				if !nodeTaintsAccepted {
-					taintedNodes = append(taintedNodes, node.Name)
+					result.Errorf(err, "Failed to retrieve tainted kernel code for node %s", nodeName)
 				}
-			}, func(e error) {
-				message = fmt.Sprintf("Failed to retrieve tainted kernel code for node %s\n", node.Name)
-				errNodes = append(errNodes, node.Name)
 			})
-
-			_, err = ginkgo.GinkgoWriter.Write([]byte(message))
-			if err != nil {
-				log.Errorf("Ginkgo writer could not write because: %s", err)
+		}
+		results := runner.Run()
+		results.Print(tnf.ClaimFilePrintf)
+		for i, result := range results {
+			status, reason := result.Compliance()
+			if !tnf.RecordNode(nodeNames[i], status, reason).Failed() {
+				result.Failed = false
 			}
 		}
 
 		// We are expecting tainted nodes to be Nil, but only if:
 		// 1) The reason for the tainted node is contains(`module was loaded`)
 		// 2) The modules loaded are all whitelisted.
-		gomega.Expect(taintedNodes).To(gomega.BeNil())
-		gomega.Expect(errNodes).To(gomega.BeNil())
+		gomega.Expect(results.FailedKeys()).To(gomega.BeNil())
+		gomega.Expect(results.ErroredKeys()).To(gomega.BeNil())
+	})
+}
+
+// testTaintedNode checks the kernel taints of a single node, reporting to result.
+func testTaintedNode(env *config.TestEnvironment, nodeName string, context *interactive.Oc, result *parallel.Result) {
+	tester := nodetainted.NewNodeTainted(common.DefaultTimeout)
+	test, err := tnf.NewTest(context.GetExpecter(), tester, []reel.Handler{tester}, context.GetErrorChannel())
+	gomega.Expect(err).To(gomega.BeNil())
+
+	test.RunWithCallbacks(func() {
+		result.Printf("Decoded tainted kernel causes (code=0) for node %s : None", nodeName)
+	}, func() {
+		var taintedBitmap uint64
+		nodeTaintsAccepted := true
+		taintedBitmap, err = strconv.ParseUint(tester.Match, 10, 32) //nolint:gomnd // base 10 and uint32
+		if err != nil {
+			result.Printf("Could not decode tainted kernel causes (code=%d) for node %s", taintedBitmap, nodeName)
+			return
+		}
+		taintMsg, individualTaints := decodeKernelTaints(taintedBitmap)
+
+		// We only will fail the tainted kernel check if the reason for the taint
+		// only pertains to `module was loaded`.
+		log.Debug("Checking for 'module was loaded' taints")
+		moduleCheck := false
+		for _, it := range individualTaints {
+			if strings.Contains(it, `module was loaded`) {
+				moduleCheck = true
+				break
+			}
+		}
+
+		if moduleCheck {
+			// Retrieve the modules from the node.
+			modules := utils.GetModulesFromNode(nodeName, context)
+			log.Debug("Got the modules from node")
+
+			// Loop through the modules looking for `InTree: Y`.
+			// If the module info does not contain this string, the module is "tainted".
+			taintedModules := getOutOfTreeModules(modules, nodeName, context)
+			log.Debug("Collected all of the tainted modules: ", taintedModules)
+			result.Printf("Kernel Modules loaded that cause taints: %v", taintedModules)
+			result.Printf("Modules allowed via configuration: %v", env.Config.AcceptedKernelTaints)
+
+			// Looks through the accepted taints listed in the tnf-config file.
+			// If all of the tainted modules show up in the configuration file, don't fail the test.
+			nodeTaintsAccepted = taintsAccepted(env.Config.AcceptedKernelTaints, taintedModules)
+		}
+
+		message := fmt.Sprintf("Decoded tainted kernel causes (code=%d) for node %s : %s", taintedBitmap, nodeName, taintMsg)
+		// Only fail the node if the taint is not acceptable.
+		if nodeTaintsAccepted {
+			result.Printf("%s", message)
+		} else {
+			result.Failf("%s", message)
+		}
+	}, func(e error) {
+		result.Errorf(e, "Failed to retrieve tainted kernel code for node %s", nodeName)
 	})
 }
 
@@ -754,9 +853,24 @@ func getNodeMachineConfig(nodeName string, machineconfigs map[string]machineConf
 	return mc
 }
 
+// getNodeKernelArguments returns a machineConfig holding the kernel arguments of the running kernel of a node only.
+func getNodeKernelArguments(node *config.NodeConfig) (machineConfig, error) {
+	var commandErr error
+	cmdline := utils.ExecuteCommandAndValidate("cat /proc/cmdline", commandTimeout, node.DebugContainer.GetOc().Context, func() {
+		commandErr = fmt.Errorf("failed to get the kernel command line of node %s", node.Name)
+	})
+	if commandErr != nil {
+		return machineConfig{}, commandErr
+	}
+	var mc machineConfig
+	mc.Spec.KernelArguments = strings.Fields(cmdline)
+	return mc, nil
+}
+
 func testHugepages(env *config.TestEnvironment) {
 	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestHugepagesNotManuallyManipulated)
 	ginkgo.It(testID, ginkgo.Label(testID), func() {
+		common.SkipUnlessCapable(env, identifiers.RequiredCapabilities(identifiers.TestHugepagesNotManuallyManipulated)...)
 		// Map to save already retrieved and parsed machineconfigs.
 		machineconfigs := map[string]machineConfig{}
 		var badNodes []string
@@ -772,8 +886,17 @@ func testHugepages(env *config.TestEnvironment) {
 				ginkgo.Fail(fmt.Sprintf("Unable to get node hugepages values from node %s", node.Name))
 			}
 
-			// Get and parse node's machineconfig, in case it's not already parsed.
-			mc := getNodeMachineConfig(node.Name, machineconfigs, env.GetLocalShellContext())
+			// Get and parse node's machineconfig, in case it's not already parsed. Without MachineConfigs, the
+			// hugepages are expected to be the ones of the kernel command line the node booted with.
+			var mc machineConfig
+			if common.ClusterCapabilities(env)[config.MachineConfigCapability] {
+				mc = getNodeMachineConfig(node.Name, machineconfigs, env.GetLocalShellContext())
+			} else {
+				mc, err = getNodeKernelArguments(node)
+				if err != nil {
+					ginkgo.Fail(fmt.Sprintf("Unable to get the kernel arguments of node %s: %v", node.Name, err))
+				}
+			}
 
 			ginkgo.By("Should parse machineconfig's kernelArguments and systemd's hugepages units.")
 			mcSystemdHugepages, err := getMcSystemdUnitsHugepagesConfig(&mc)
@@ -787,13 +910,21 @@ func testHugepages(env *config.TestEnvironment) {
 				hugepagesPerSize, _ := getMcHugepagesFromMcKernelArguments(&mc)
 				if pass, err := testNodeHugepagesWithKernelArgs(node.Name, nodeNumaHugePages, hugepagesPerSize); !pass {
 					log.Error(err)
-					badNodes = append(badNodes, node.Name)
+					if tnf.RecordNode(node.Name, tnf.NonCompliant, err.Error()).Failed() {
+						badNodes = append(badNodes, node.Name)
+					}
+				} else {
+					tnf.RecordNode(node.Name, tnf.Compliant, "")
 				}
 			} else {
 				ginkgo.By("Comparing MC Systemd hugepages info against node values.")
 				if pass, err := testNodeHugepagesWithMcSystemd(node.Name, nodeNumaHugePages, mcSystemdHugepages); !pass {
 					log.Error(err)
-					badNodes = append(badNodes, node.Name)
+					if tnf.RecordNode(node.Name, tnf.NonCompliant, err.Error()).Failed() {
+						badNodes = append(badNodes, node.Name)
+					}
+				} else {
+					tnf.RecordNode(node.Name, tnf.Compliant, "")
 				}
 			}
 		}
@@ -0,0 +1,184 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	ginkgoTypes "github.com/onsi/ginkgo/v2/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+const (
+	// TestsExtraInfoKey is the key of tnf.TestsExtraInfo in the raw results of the claim.
+	TestsExtraInfoKey = "testsExtraInfo"
+	// TestSelectionKey is the key of the selection of the tests run in the raw results of the claim.  The claim schema
+	// allows no other claim metadata than the start and end times, and the configurations are the CNF ones.
+	TestSelectionKey = "testSelection"
+
+	checkpointFilePermissions = 0644
+)
+
+var (
+	// checkpointFile is the partial claim the results are saved to after each spec, none when empty.
+	checkpointFile string
+	// checkpointClaim holds the claim fields known before the specs run, e.g. the start time.
+	checkpointClaim *claim.Claim
+	// resumedTestIDs are the test IDs whose results were restored from a previous run.
+	resumedTestIDs = map[string]bool{}
+)
+
+// UnmarshalJSON reads the output of MarshalJSON back.
+func (r *Result) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if objects, ok := fields[checkedObjectsKey]; ok {
+		if err := json.Unmarshal(objects, &r.CheckedObjects); err != nil {
+			return err
+		}
+		delete(fields, checkedObjectsKey)
+	}
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return r.Result.UnmarshalJSON(rest)
+}
+
+// EnableCheckpoints saves the results and tnf.TestsExtraInfo recorded so far as a partial claim in file after each
+// spec, so that an interrupted run can be resumed.  The partial claim is c with these results and no end time.
+func EnableCheckpoints(file string, c *claim.Claim) {
+	checkpointFile = file
+	checkpointClaim = c
+}
+
+// DisableCheckpoints stops saving the partial claim.
+func DisableCheckpoints() {
+	checkpointFile = ""
+	checkpointClaim = nil
+}
+
+// saveCheckpoint writes the partial claim.  It is written to a temporary file first so that a run dying while writing
+// it leaves the previous one.
+func saveCheckpoint() error {
+	c := *checkpointClaim
+	c.Results = GetReconciledResults()
+	c.RawResults = map[string]interface{}{TestsExtraInfoKey: tnf.TestsExtraInfo}
+	if c.Versions == nil {
+		c.Versions = &claim.Versions{}
+	}
+	payload, err := json.MarshalIndent(&claim.Root{Claim: &c}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := checkpointFile + ".tmp"
+	if err := os.WriteFile(tmpFile, payload, checkpointFilePermissions); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, checkpointFile)
+}
+
+// partialClaim is the part of a partial claim a run is resumed from.
+type partialClaim struct {
+	Claim struct {
+		Metadata   claim.Metadata      `json:"metadata"`
+		Results    map[string][]Result `json:"results"`
+		RawResults struct {
+			TestsExtraInfo []map[string][]string `json:"testsExtraInfo"`
+		} `json:"rawResults"`
+	} `json:"claim"`
+}
+
+// isCompleted returns whether a test ended in a state it is not run again for when resuming.
+func isCompleted(state string) bool {
+	return state == ginkgoTypes.SpecStatePassed.String() || state == ginkgoTypes.SpecStateFailed.String()
+}
+
+// leafTestID returns the test ID, extended or not, a result of the given key is recorded for.  The key ends with it.
+func leafTestID(key string, r *Result) (string, error) {
+	if r.TestID == nil {
+		return "", fmt.Errorf("result %s has no test ID", key)
+	}
+	testID := identifiers.XformToGinkgoItIdentifier(*r.TestID)
+	i := strings.LastIndex(key, testID)
+	if i < 0 {
+		return "", fmt.Errorf("result %s is not recorded for test %s", key, testID)
+	}
+	return key[i:], nil
+}
+
+// resumedExtraInfo returns the messages of extraInfo recorded by the resumed tests, the tests run again record theirs
+// anew.
+func resumedExtraInfo(extraInfo []map[string][]string) []map[string][]string {
+	resumed := []map[string][]string{}
+	for _, messages := range extraInfo {
+		kept := map[string][]string{}
+		for testID, m := range messages {
+			if resumedTestIDs[testID] {
+				kept[testID] = m
+			}
+		}
+		if len(kept) > 0 {
+			resumed = append(resumed, kept)
+		}
+	}
+	return resumed
+}
+
+// Resume restores the results of the tests that passed or failed in the partial claim file written by a previous run,
+// so that they are part of the claim of this run, and returns their test IDs along with the start time of that run.
+// The skipped reports of these tests are not recorded.  The other results, and the extra information they recorded in
+// tnf.TestsExtraInfo, are dropped so that the tests run again.
+func Resume(file string) (startTime string, testIDs []string, err error) {
+	payload, err := os.ReadFile(file)
+	if err != nil {
+		return "", nil, err
+	}
+	var partial partialClaim
+	if err = json.Unmarshal(payload, &partial); err != nil {
+		return "", nil, fmt.Errorf("error reading the partial claim %s: %v", file, err)
+	}
+	for key, vals := range partial.Claim.Results {
+		for i := range vals {
+			if !isCompleted(vals[i].State) {
+				continue
+			}
+			testID, idErr := leafTestID(key, &vals[i])
+			if idErr != nil {
+				return "", nil, idErr
+			}
+			if !resumedTestIDs[testID] {
+				resumedTestIDs[testID] = true
+				testIDs = append(testIDs, testID)
+			}
+			results[key] = append(results[key], vals[i])
+		}
+	}
+	sort.Strings(testIDs)
+	tnf.TestsExtraInfo = append(resumedExtraInfo(partial.Claim.RawResults.TestsExtraInfo), tnf.TestsExtraInfo...)
+	log.Infof("Resuming the run started at %s, %d tests already ran", partial.Claim.Metadata.StartTime, len(testIDs))
+	return partial.Claim.Metadata.StartTime, testIDs, nil
+}
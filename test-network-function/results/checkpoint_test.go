@@ -0,0 +1,90 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package results
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	ginkgoTypes "github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+func TestResultUnmarshalJSON(t *testing.T) {
+	r := Result{Result: claim.Result{State: "failed", TestText: "text"}, CheckedObjects: []tnf.CheckedObject{
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod1", Status: tnf.NonCompliant, Reason: "no pre-stop"},
+	}}
+	out, err := json.Marshal(r)
+	assert.Nil(t, err)
+	var decoded Result
+	assert.Nil(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, r, decoded)
+}
+
+func TestCheckpointAndResume(t *testing.T) {
+	defer func() {
+		results = map[string][]Result{}
+		resumedTestIDs = map[string]bool{}
+		tnf.TestsExtraInfo = []map[string][]string{}
+		DisableCheckpoints()
+	}()
+	file := filepath.Join(t.TempDir(), "claim.partial.json")
+	EnableCheckpoints(file, &claim.Claim{
+		Metadata:       &claim.Metadata{StartTime: "2022-03-01T10:00:00+00:00"},
+		Configurations: map[string]interface{}{},
+		Nodes:          map[string]interface{}{},
+	})
+	shutdownID := identifiers.XformToGinkgoItIdentifier(identifiers.TestShudtownIdentifier)
+	hostResourceID := identifiers.XformToGinkgoItIdentifierExtended(identifiers.TestHostResourceIdentifier, "PRIVILEGED_POD")
+	recreationID := identifiers.XformToGinkgoItIdentifier(identifiers.TestPodRecreationIdentifier)
+	tnf.TestsExtraInfo = append(tnf.TestsExtraInfo, map[string][]string{shutdownID: {"extra"}},
+		map[string][]string{recreationID: {"interrupted"}})
+	RecordResult(ginkgoTypes.SpecReport{ContainerHierarchyTexts: []string{"lifecycle"}, LeafNodeText: shutdownID,
+		State: ginkgoTypes.SpecStatePassed})
+	RecordResult(ginkgoTypes.SpecReport{ContainerHierarchyTexts: []string{"access-control", "Host resource"},
+		LeafNodeText: hostResourceID, State: ginkgoTypes.SpecStateFailed})
+	RecordResult(ginkgoTypes.SpecReport{ContainerHierarchyTexts: []string{"lifecycle"}, LeafNodeText: recreationID,
+		State: ginkgoTypes.SpecStateInterrupted})
+
+	// A new run resumes from the partial claim: the interrupted test is run again, without its extra information.
+	DisableCheckpoints()
+	results = map[string][]Result{}
+	tnf.TestsExtraInfo = []map[string][]string{}
+	startTime, testIDs, err := Resume(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "2022-03-01T10:00:00+00:00", startTime)
+	assert.Equal(t, []string{hostResourceID, shutdownID}, testIDs)
+	assert.Equal(t, []map[string][]string{{shutdownID: {"extra"}}}, tnf.TestsExtraInfo)
+
+	RecordResult(ginkgoTypes.SpecReport{ContainerHierarchyTexts: []string{"lifecycle"}, LeafNodeText: shutdownID,
+		State: ginkgoTypes.SpecStateSkipped})
+	RecordResult(ginkgoTypes.SpecReport{ContainerHierarchyTexts: []string{"lifecycle"}, LeafNodeText: recreationID,
+		State: ginkgoTypes.SpecStatePassed})
+	reconciled := GetReconciledResults()
+	assert.Len(t, reconciled, 3)
+	assert.Equal(t, "passed", reconciled["lifecycle-"+shutdownID].([]Result)[0].State)
+	assert.Len(t, reconciled["lifecycle-"+shutdownID], 1)
+	assert.Equal(t, "failed", reconciled["access-control-Host_resource-"+hostResourceID].([]Result)[0].State)
+	assert.Equal(t, "passed", reconciled["lifecycle-"+recreationID].([]Result)[0].State)
+
+	_, _, err = Resume(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(t, err)
+}
@@ -17,20 +17,54 @@
 package results
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	ginkgoTypes "github.com/onsi/ginkgo/v2/types"
+	log "github.com/sirupsen/logrus"
 	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
 	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
 )
 
+const checkedObjectsKey = "checkedObjects"
+
+// Result is a claim.Result extended with the objects checked by the test and the outcome for each of them.
+type Result struct {
+	claim.Result
+	CheckedObjects []tnf.CheckedObject
+}
+
+// MarshalJSON emits the claim.Result fields followed by the checkedObjects array, when there is one.
+func (r Result) MarshalJSON() ([]byte, error) { //nolint:gocritic // a value receiver is needed to marshal slice elements
+	out, err := r.Result.MarshalJSON()
+	if err != nil || len(r.CheckedObjects) == 0 {
+		return out, err
+	}
+	objects, err := json.Marshal(r.CheckedObjects)
+	if err != nil {
+		return nil, err
+	}
+	// claim.Result always marshals to a non-empty object, so the array can be appended before its closing brace.
+	out = bytes.TrimSuffix(out, []byte("}"))
+	out = append(out, fmt.Sprintf(",%q: ", checkedObjectsKey)...)
+	out = append(out, objects...)
+	return append(out, '}'), nil
+}
+
 // results is the results map
-var results = map[string][]claim.Result{}
+var results = map[string][]Result{}
 
 // RecordResult is a hook provided to save aspects of the ginkgo.GinkgoTestDescription for a given claim.Identifier.
-// Multiple results for a given identifier are aggregated as an array under the same key.
+// Multiple results for a given identifier are aggregated as an array under the same key.  The objects recorded through
+// the tnf.Record* functions since the previous spec are attached to the result.  The results are saved to the partial
+// claim when checkpoints are enabled.
 func RecordResult(report ginkgoTypes.SpecReport) { //nolint:gocritic // From Ginkgo
+	if resumedTestIDs[report.LeafNodeText] && report.State == ginkgoTypes.SpecStateSkipped {
+		return
+	}
 	if claimID, ok := identifiers.TestIDToClaimID[report.LeafNodeText]; ok {
 		var key string
 		for _, level := range report.ContainerHierarchyTexts {
@@ -39,7 +73,7 @@ func RecordResult(report ginkgoTypes.SpecReport) { //nolint:gocritic // From Gin
 		}
 		key = strings.TrimLeft(key, "-") + "-" + report.LeafNodeText
 		testText := identifiers.Catalog[claimID].Description
-		results[key] = append(results[key], claim.Result{
+		results[key] = append(results[key], Result{Result: claim.Result{
 			Duration:           int(report.RunTime.Nanoseconds()),
 			FailureLocation:    report.FailureLocation().String(),
 			FailureLineContent: report.FailureLocation().ContentsOfLine(),
@@ -50,10 +84,15 @@ func RecordResult(report ginkgoTypes.SpecReport) { //nolint:gocritic // From Gin
 			EndTime:            report.EndTime.String(),
 			CapturedTestOutput: report.CapturedGinkgoWriterOutput,
 			TestID:             &claimID,
-		})
+		}, CheckedObjects: tnf.TakeCheckedObjects()})
 	} else {
 		panic(fmt.Sprintf("TestID %s has no corresponding Claim ID", report.LeafNodeText))
 	}
+	if checkpointFile != "" {
+		if err := saveCheckpoint(); err != nil {
+			log.Errorf("Unable to save the partial claim %s: %v", checkpointFile, err)
+		}
+	}
 }
 
 // GetReconciledResults is a function added to aggregate a Claim's results.  Due to the limitations of
@@ -65,10 +104,10 @@ func GetReconciledResults() map[string]interface{} {
 	for key, vals := range results {
 		// initializes the result map, if necessary
 		if _, ok := resultMap[key]; !ok {
-			resultMap[key] = make([]claim.Result, 0)
+			resultMap[key] = make([]Result, 0)
 		}
 		for _, val := range vals { //nolint:gocritic // Only done once at the end
-			resultMap[key] = append(resultMap[key].([]claim.Result), val)
+			resultMap[key] = append(resultMap[key].([]Result), val)
 		}
 	}
 	return resultMap
@@ -0,0 +1,79 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package results
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	ginkgoTypes "github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/test-network-function/test-network-function-claim/pkg/claim"
+	"github.com/test-network-function/test-network-function/pkg/tnf"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+func TestResultMarshalJSON(t *testing.T) {
+	r := Result{Result: claim.Result{State: "failed", TestText: "text"}}
+
+	// Without checked objects the output is the plain claim.Result.
+	plain, err := json.Marshal(&r.Result)
+	assert.Nil(t, err)
+	out, err := json.Marshal([]Result{r})
+	assert.Nil(t, err)
+	assert.JSONEq(t, "["+string(plain)+"]", string(out))
+
+	r.CheckedObjects = []tnf.CheckedObject{
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod1", Status: tnf.NonCompliant, Reason: "no pre-stop"},
+		{Type: tnf.IPObject, Network: "net1", Name: "10.0.0.1", Status: tnf.Compliant},
+	}
+	out, err = json.Marshal(r)
+	assert.Nil(t, err)
+	var decoded map[string]interface{}
+	assert.Nil(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, "failed", decoded["state"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "pod", "namespace": "ns", "name": "pod1", "status": "non-compliant", "reason": "no pre-stop"},
+		map[string]interface{}{"type": "ip", "network": "net1", "name": "10.0.0.1", "status": "compliant"},
+	}, decoded[checkedObjectsKey])
+}
+
+func TestRecordResultTakesCheckedObjects(t *testing.T) {
+	defer func() { results = map[string][]Result{} }()
+	testID := identifiers.XformToGinkgoItIdentifier(identifiers.TestShudtownIdentifier)
+
+	tnf.RecordPod("ns", "pod1", tnf.Compliant, "")
+	tnf.RecordPod("ns", "pod2", tnf.CheckError, "timeout")
+	RecordResult(ginkgoTypes.SpecReport{
+		ContainerHierarchyTexts: []string{"lifecycle"},
+		LeafNodeText:            testID,
+		State:                   ginkgoTypes.SpecStatePassed,
+		RunTime:                 time.Second,
+	})
+
+	reconciled := GetReconciledResults()
+	recorded := reconciled["lifecycle-"+testID].([]Result)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, "passed", recorded[0].State)
+	assert.Equal(t, []tnf.CheckedObject{
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod1", Status: tnf.Compliant},
+		{Type: tnf.PodObject, Namespace: "ns", Name: "pod2", Status: tnf.CheckError, Reason: "timeout"},
+	}, recorded[0].CheckedObjects)
+	// The objects are attached to a single spec only.
+	assert.Empty(t, tnf.TakeCheckedObjects())
+}
@@ -0,0 +1,299 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package selection
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/test-network-function/test-network-function/test-network-function/identifiers"
+)
+
+const (
+	// intrusiveLabel is the label of the tests modifying the CNF or the cluster.
+	intrusiveLabel = "intrusive"
+	// extensionPattern matches the suffix of the extended test IDs, e.g. the check name of the host-resource test.
+	extensionPattern = `(-\S+)?`
+)
+
+// Criteria are the choices of the tests to run. Empty criteria select every test of the catalog.
+type Criteria struct {
+	// Focus lists the suite keys and test IDs to run, all of them when empty. An extended test ID, e.g.
+	// access-control-host-resource-PRIVILEGED_POD, runs this check only.
+	Focus []string `json:"focus,omitempty"`
+	// Skip lists the suite keys, test IDs and extended test IDs not to run.
+	Skip []string `json:"skip,omitempty"`
+	// LabelFilter is a Ginkgo label filter expression over the labels of a test: its test ID, its suite key, its
+	// result type and "intrusive" for the tests modifying the CNF or the cluster.
+	LabelFilter string `json:"labelFilter,omitempty"`
+	// Types lists the result types to run, e.g. normative, all of them when empty.
+	Types []string `json:"types,omitempty"`
+	// NonIntrusiveOnly leaves out the tests modifying the CNF or the cluster.
+	NonIntrusiveOnly bool `json:"nonIntrusiveOnly"`
+}
+
+// Test is a test of the catalog with the properties it is selected on.
+type Test struct {
+	ID        string `json:"id"`
+	Suite     string `json:"suite"`
+	Type      string `json:"type"`
+	Intrusive bool   `json:"intrusive"`
+}
+
+// Labels returns the labels the label filter of the criteria is evaluated on.
+func (t *Test) Labels() []string {
+	labels := []string{t.ID, t.Suite, t.Type}
+	if t.Intrusive {
+		labels = append(labels, intrusiveLabel)
+	}
+	return labels
+}
+
+// Selection is the outcome of applying criteria to the test catalog. It is recorded in the claim so that reviewers
+// know what was run.
+type Selection struct {
+	Criteria
+	// Tests are the selected tests, sorted by ID.
+	Tests []Test `json:"tests"`
+
+	focus []string
+	skip  []string
+}
+
+// current is the selection of the running test suites.
+var current *Selection
+
+// Set makes s the selection of the running test suites. It must be called before the suites are built.
+func Set(s *Selection) {
+	current = s
+}
+
+// IsSuiteSelected returns whether a test of suite is selected. Every suite is selected until Set is called.
+func IsSuiteSelected(suite string) bool {
+	if current == nil {
+		return true
+	}
+	for i := range current.Tests {
+		if current.Tests[i].Suite == suite {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogTests returns the tests of the catalog, sorted by ID.
+func catalogTests() []Test {
+	tests := make([]Test, 0, len(identifiers.Catalog))
+	for id := range identifiers.Catalog {
+		description := identifiers.Catalog[id]
+		tests = append(tests, Test{
+			ID:        identifiers.XformToGinkgoItIdentifier(id),
+			Suite:     identifiers.GetSuiteAndTestFromIdentifier(id)[0],
+			Type:      description.Type,
+			Intrusive: description.IntrusionSettings.ModifiesSystem,
+		})
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		return tests[i].ID < tests[j].ID
+	})
+	return tests
+}
+
+// resolve returns the IDs of the tests an entry of the focus or skip lists names, and whether it is an extended test
+// ID naming a single check of the returned test.
+func resolve(entry string, tests []Test) (ids []string, extended bool, err error) {
+	var extendedID string
+	for i := range tests {
+		switch {
+		case entry == tests[i].ID || entry == tests[i].Suite:
+			ids = append(ids, tests[i].ID)
+		case strings.HasPrefix(entry, tests[i].ID+"-") && len(tests[i].ID) > len(extendedID):
+			extendedID = tests[i].ID
+		}
+	}
+	if len(ids) > 0 {
+		return ids, false, nil
+	}
+	if extendedID != "" {
+		return []string{extendedID}, true, nil
+	}
+	return nil, false, fmt.Errorf("unknown suite or test ID %q", entry)
+}
+
+// checkTypes returns an error when one of types is not the result type of a test.
+func checkTypes(types []string, tests []Test) error {
+	known := map[string]bool{}
+	for i := range tests {
+		known[tests[i].Type] = true
+	}
+	for _, t := range types {
+		if !known[t] {
+			return fmt.Errorf("unknown result type %q", t)
+		}
+	}
+	return nil
+}
+
+// testPattern returns the Ginkgo focus or skip pattern matching the spec of a test, or its specs when its ID is
+// extended at run time.
+func testPattern(id string) string {
+	return "(^| )" + regexp.QuoteMeta(id) + extensionPattern + "$"
+}
+
+// exactPattern returns the Ginkgo focus or skip pattern matching the spec of an extended test ID.
+func exactPattern(id string) string {
+	return "(^| )" + regexp.QuoteMeta(id) + "$"
+}
+
+// New returns the selection of the catalog tests meeting criteria. It fails when an entry of the criteria is unknown
+// or when no test is selected.
+//
+//nolint:funlen,gocyclo // the criteria are applied one after the other
+func New(criteria *Criteria) (*Selection, error) {
+	tests := catalogTests()
+	labelFilter := func([]string) bool { return true }
+	if criteria.LabelFilter != "" {
+		var err error
+		labelFilter, err = types.ParseLabelFilter(criteria.LabelFilter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label filter %q: %v", criteria.LabelFilter, err)
+		}
+	}
+	if err := checkTypes(criteria.Types, tests); err != nil {
+		return nil, err
+	}
+
+	focused := map[string]bool{}
+	extendedFocus := map[string][]string{}
+	for _, entry := range criteria.Focus {
+		ids, extended, err := resolve(entry, tests)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if extended {
+				extendedFocus[id] = append(extendedFocus[id], entry)
+			} else {
+				focused[id] = true
+			}
+		}
+	}
+	if len(criteria.Focus) == 0 {
+		for i := range tests {
+			focused[tests[i].ID] = true
+		}
+	}
+	skipped := map[string]bool{}
+	s := &Selection{Criteria: *criteria}
+	for _, entry := range criteria.Skip {
+		ids, extended, err := resolve(entry, tests)
+		if err != nil {
+			return nil, err
+		}
+		if extended {
+			s.skip = append(s.skip, exactPattern(entry))
+			continue
+		}
+		for _, id := range ids {
+			skipped[id] = true
+		}
+	}
+
+	selectedTypes := map[string]bool{}
+	for _, t := range criteria.Types {
+		selectedTypes[t] = true
+	}
+	for i := range tests {
+		t := &tests[i]
+		switch {
+		case !focused[t.ID] && len(extendedFocus[t.ID]) == 0:
+		case skipped[t.ID]:
+		case len(selectedTypes) > 0 && !selectedTypes[t.Type]:
+		case criteria.NonIntrusiveOnly && t.Intrusive:
+		case !labelFilter(t.Labels()):
+		default:
+			s.Tests = append(s.Tests, *t)
+			if focused[t.ID] {
+				s.focus = append(s.focus, testPattern(t.ID))
+			} else {
+				for _, entry := range extendedFocus[t.ID] {
+					s.focus = append(s.focus, exactPattern(entry))
+				}
+			}
+		}
+	}
+	if len(s.Tests) == 0 {
+		return nil, fmt.Errorf("no test matches the selection")
+	}
+
+	// The pattern of a selected test also matches the tests whose ID it prefixes, e.g. icmpv4-connectivity and
+	// icmpv4-connectivity-multus, so the ones that are not selected are skipped.
+	for i := range tests {
+		if s.isSelected(tests[i].ID) {
+			continue
+		}
+		for j := range s.Tests {
+			if focused[s.Tests[j].ID] && strings.HasPrefix(tests[i].ID, s.Tests[j].ID+"-") {
+				s.skip = append(s.skip, testPattern(tests[i].ID))
+				break
+			}
+		}
+	}
+	return s, nil
+}
+
+// isSelected returns whether the test with the given ID is selected.
+func (s *Selection) isSelected(id string) bool {
+	for i := range s.Tests {
+		if s.Tests[i].ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipSpecs skips the specs of the given test IDs and extended test IDs, e.g. the ones that already ran in a resumed
+// run, while keeping them in the selection.  It must be called before Apply.
+func (s *Selection) SkipSpecs(testIDs ...string) {
+	for _, id := range testIDs {
+		s.skip = append(s.skip, exactPattern(id))
+	}
+}
+
+// Apply sets the Ginkgo focus and skip patterns running the selected tests only. The skip patterns already in conf are
+// kept, its focus patterns are replaced.
+func (s *Selection) Apply(conf *types.SuiteConfig) {
+	conf.FocusStrings = s.focus
+	conf.SkipStrings = append(conf.SkipStrings, s.skip...)
+}
+
+// List writes the selected tests, one per line, for a dry run.
+func (s *Selection) List(w io.Writer) error {
+	const padding = 2
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, "TEST ID\tSUITE\tTYPE\tINTRUSIVE")
+	for i := range s.Tests {
+		t := &s.Tests[i]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", t.ID, t.Suite, t.Type, t.Intrusive)
+	}
+	return tw.Flush()
+}
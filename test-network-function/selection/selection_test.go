@@ -0,0 +1,170 @@
+// Copyright (C) 2022 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package selection
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testIDs(s *Selection) []string {
+	var ids []string
+	for i := range s.Tests {
+		ids = append(ids, s.Tests[i].ID)
+	}
+	return ids
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		criteria    Criteria
+		expectedIDs []string
+		expectedErr bool
+	}{
+		{
+			criteria:    Criteria{Focus: []string{"operator"}},
+			expectedIDs: []string{"operator-install-source", "operator-install-status"},
+		},
+		{
+			criteria:    Criteria{Focus: []string{"operator", "diagnostic-clusterversion"}, Skip: []string{"operator-install-status"}},
+			expectedIDs: []string{"diagnostic-clusterversion", "operator-install-source"},
+		},
+		{
+			criteria:    Criteria{Focus: []string{"lifecycle"}, NonIntrusiveOnly: true, LabelFilter: "!lifecycle-pod-owner-type"},
+			expectedIDs: []string{"lifecycle-container-probes", "lifecycle-container-shutdown", "lifecycle-image-pull-policy", "lifecycle-pod-disruption-budget", "lifecycle-pod-high-availability", "lifecycle-pod-resources", "lifecycle-pod-scheduling", "lifecycle-pod-termination-grace-period"},
+		},
+		{
+			criteria:    Criteria{LabelFilter: "intrusive"},
+			expectedIDs: []string{"lifecycle-deployment-scaling", "lifecycle-kill-recovery", "lifecycle-pod-recreation", "lifecycle-rolling-update", "lifecycle-statefulset-scaling"},
+		},
+		{
+			criteria:    Criteria{Focus: []string{"diagnostic"}, Types: []string{"normative"}},
+			expectedIDs: []string{"diagnostic-list-cni-plugins", "diagnostic-nodes-hw-info"},
+		},
+		{
+			criteria:    Criteria{Focus: []string{"diagnostic-clusterversion"}, Types: []string{"normative"}},
+			expectedErr: true,
+		},
+		{
+			criteria:    Criteria{Focus: []string{"access-control-host-resource-PRIVILEGED_POD"}},
+			expectedIDs: []string{"access-control-host-resource"},
+		},
+		{criteria: Criteria{Focus: []string{"no-such-suite"}}, expectedErr: true},
+		{criteria: Criteria{Skip: []string{"no-such-test"}}, expectedErr: true},
+		{criteria: Criteria{Types: []string{"mandatory"}}, expectedErr: true},
+		{criteria: Criteria{LabelFilter: "a &&"}, expectedErr: true},
+	}
+	for i := range testCases {
+		s, err := New(&testCases[i].criteria)
+		assert.Equal(t, testCases[i].expectedErr, err != nil, "%+v", testCases[i].criteria)
+		if err == nil {
+			assert.Equal(t, testCases[i].expectedIDs, testIDs(s))
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		criteria  Criteria
+		specTexts map[string]bool
+	}{
+		{
+			criteria: Criteria{Focus: []string{"networking-icmpv4-connectivity"}},
+			specTexts: map[string]bool{
+				"networking Testing Default network IPv4 connectivity networking-icmpv4-connectivity":       true,
+				"networking Testing Multus network IPv4 connectivity networking-icmpv4-connectivity-multus": false,
+				"networking Testing Default network IPv6 connectivity networking-icmpv6-connectivity":       false,
+			},
+		},
+		{
+			criteria: Criteria{Focus: []string{"access-control"}, Skip: []string{"access-control-host-resource-PRIVILEGED_POD"}},
+			specTexts: map[string]bool{
+				"access-control access-control-host-resource-HOST_NETWORK_CHECK": true,
+				"access-control access-control-host-resource-PRIVILEGED_POD":     false,
+				"access-control access-control-namespace":                        true,
+				"lifecycle lifecycle-pod-owner-type":                             false,
+			},
+		},
+		{
+			criteria: Criteria{Focus: []string{"operator-install-status-CSV_INSTALLED"}},
+			specTexts: map[string]bool{
+				"operator operator-install-status-CSV_INSTALLED": true,
+				"operator operator-install-status-CSV_SCC":       false,
+				"operator operator-install-source":               false,
+			},
+		},
+	}
+	for i := range testCases {
+		s, err := New(&testCases[i].criteria)
+		assert.Nil(t, err)
+		conf := types.NewDefaultSuiteConfig()
+		s.Apply(&conf)
+		focus := regexp.MustCompile(strings.Join(conf.FocusStrings, "|"))
+		var skip *regexp.Regexp
+		if len(conf.SkipStrings) > 0 {
+			skip = regexp.MustCompile(strings.Join(conf.SkipStrings, "|"))
+		}
+		for text, expected := range testCases[i].specTexts {
+			runs := focus.MatchString(text) && (skip == nil || !skip.MatchString(text))
+			assert.Equal(t, expected, runs, text)
+		}
+	}
+}
+
+func TestIsSuiteSelected(t *testing.T) {
+	defer Set(nil)
+	assert.True(t, IsSuiteSelected("lifecycle"))
+	s, err := New(&Criteria{Focus: []string{"operator", "diagnostic-nodes-hw-info"}})
+	assert.Nil(t, err)
+	Set(s)
+	assert.True(t, IsSuiteSelected("operator"))
+	assert.True(t, IsSuiteSelected("diagnostic"))
+	assert.False(t, IsSuiteSelected("lifecycle"))
+	assert.False(t, IsSuiteSelected("generic"))
+}
+
+func TestList(t *testing.T) {
+	s, err := New(&Criteria{Focus: []string{"lifecycle-pod-recreation", "operator-install-source"}})
+	assert.Nil(t, err)
+	var out bytes.Buffer
+	assert.Nil(t, s.List(&out))
+	assert.Equal(t, `TEST ID                   SUITE      TYPE       INTRUSIVE
+lifecycle-pod-recreation  lifecycle  normative  true
+operator-install-source   operator   normative  false
+`, out.String())
+}
+
+func TestSkipSpecs(t *testing.T) {
+	s, err := New(&Criteria{Focus: []string{"access-control", "lifecycle-pod-recreation"}})
+	assert.Nil(t, err)
+	selected := len(s.Tests)
+	s.SkipSpecs("access-control-host-resource-PRIVILEGED_POD", "access-control-namespace")
+	conf := types.NewDefaultSuiteConfig()
+	s.Apply(&conf)
+	skip := regexp.MustCompile(strings.Join(conf.SkipStrings, "|"))
+	assert.True(t, skip.MatchString("access-control access-control-host-resource-PRIVILEGED_POD"))
+	assert.True(t, skip.MatchString("access-control access-control-namespace"))
+	assert.False(t, skip.MatchString("access-control access-control-host-resource-HOST_NETWORK_CHECK"))
+	assert.False(t, skip.MatchString("lifecycle lifecycle-pod-recreation"))
+	// The skipped tests are still part of the selection, their results come from the resumed run.
+	assert.Len(t, s.Tests, selected)
+}
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,28 +46,41 @@ import (
 	_ "github.com/test-network-function/test-network-function/test-network-function/observability"
 	_ "github.com/test-network-function/test-network-function/test-network-function/operator"
 	_ "github.com/test-network-function/test-network-function/test-network-function/platform"
+	"github.com/test-network-function/test-network-function/test-network-function/selection"
 )
 
 const (
 	claimFileName                        = "claim.json"
+	partialClaimFileName                 = "claim.partial.json"
 	claimFilePermissions                 = 0644
 	claimPathFlagKey                     = "claimloc"
 	CnfCertificationTestSuiteName        = "CNF Certification Test Suite"
 	defaultClaimPath                     = ".."
 	defaultCliArgValue                   = ""
 	junitFlagKey                         = "junit"
+	focusFlagKey                         = "focus"
+	skipFlagKey                          = "skip"
+	labelFilterFlagKey                   = "label-filter"
+	typesFlagKey                         = "types"
+	dryRunFlagKey                        = "dry-run"
+	resumeFlagKey                        = "resume"
 	TNFJunitXMLFileName                  = "cnf-certification-tests_junit.xml"
 	TNFReportKey                         = "cnf-certification-test"
 	CNFFeatureValidationJunitXMLFileName = "validation_junit.xml"
 	CNFFeatureValidationReportKey        = "cnf-feature-validation"
 	// dateTimeFormatDirective is the directive used to format date/time according to ISO 8601.
 	dateTimeFormatDirective = "2006-01-02T15:04:05+00:00"
-	extraInfoKey            = "testsExtraInfo"
 )
 
 var (
-	claimPath *string
-	junitPath *string
+	claimPath   *string
+	junitPath   *string
+	focus       *string
+	skip        *string
+	labelFilter *string
+	testTypes   *string
+	dryRun      *bool
+	resume      *bool
 	// GitCommit is the latest commit in the current git branch
 	GitCommit string
 	// GitRelease is the list of tags (if any) applied to the latest commit
@@ -85,6 +99,46 @@ func init() {
 		"the path where the claimfile will be output")
 	junitPath = flag.String(junitFlagKey, defaultCliArgValue,
 		"the path for the junit format report")
+	focus = flag.String(focusFlagKey, defaultCliArgValue,
+		"the comma separated suite keys and test IDs to run, all the tests are run when empty")
+	skip = flag.String(skipFlagKey, defaultCliArgValue,
+		"the comma separated suite keys and test IDs not to run")
+	labelFilter = flag.String(labelFilterFlagKey, defaultCliArgValue,
+		"the label filter expression over the test IDs, suite keys, result types and the \"intrusive\" label")
+	testTypes = flag.String(typesFlagKey, defaultCliArgValue,
+		"the comma separated result types to run, e.g. normative")
+	dryRun = flag.Bool(dryRunFlagKey, false,
+		"list the selected tests without running them")
+	resume = flag.Bool(resumeFlagKey, false,
+		"resume an interrupted run, skipping the tests that passed or failed in its partial claim")
+}
+
+// splitList returns the non-empty elements of a comma separated list.
+func splitList(list string) []string {
+	var elements []string
+	for _, e := range strings.Split(list, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+// selectTests returns the selection of the tests made with the command line flags, and makes it the one of the
+// suites.  In the event of an error, this method fatally fails.
+func selectTests() *selection.Selection {
+	s, err := selection.New(&selection.Criteria{
+		Focus:            splitList(*focus),
+		Skip:             splitList(*skip),
+		LabelFilter:      *labelFilter,
+		Types:            splitList(*testTypes),
+		NonIntrusiveOnly: !common.Intrusive(),
+	})
+	if err != nil {
+		log.Fatalf("error selecting the tests: %v", err)
+	}
+	selection.Set(s)
+	return s
 }
 
 // createClaimRoot creates the claim based on the model created in
@@ -142,15 +196,41 @@ func TestTest(t *testing.T) {
 	}
 	log.Info("Version: ", gitDisplayRelease, " ( ", GitCommit, " )")
 
+	testSelection := selectTests()
+	if *dryRun {
+		if err := testSelection.List(os.Stdout); err != nil {
+			log.Fatalf("error listing the selected tests: %v", err)
+		}
+		return
+	}
+	suiteConfig, reporterConfig := ginkgo.GinkgoConfiguration()
+	if len(suiteConfig.FocusStrings) > 0 {
+		log.Warnf("The ginkgo focus is ignored, the tests to run are selected with -%s", focusFlagKey)
+	}
+
 	// Initialize the claim with the start time, tnf version, etc.
 	claimRoot := createClaimRoot()
 	claimData := claimRoot.Claim
 	claimData.Configurations = make(map[string]interface{})
 	claimData.Nodes = make(map[string]interface{})
 
+	// Save the results to the partial claim after each test, and restore the ones of the interrupted run if resuming.
+	partialClaimFile := filepath.Join(*claimPath, partialClaimFileName)
+	if *resume {
+		startTime, testIDs, err := results.Resume(partialClaimFile)
+		if err != nil {
+			log.Fatalf("error resuming from the partial claim: %v", err)
+		}
+		claimData.Metadata.StartTime = startTime
+		testSelection.SkipSpecs(testIDs...)
+	}
+	testSelection.Apply(&suiteConfig)
+	results.EnableCheckpoints(partialClaimFile, claimData)
+
 	// run the test suite
-	ginkgo.RunSpecs(t, CnfCertificationTestSuiteName)
+	ginkgo.RunSpecs(t, CnfCertificationTestSuiteName, suiteConfig, reporterConfig)
 	endTime := time.Now()
+	results.DisableCheckpoints()
 
 	incorporateVersions(claimData)
 	// process the test results from this test suite, the cnf-features-deploy test suite, and any extra informational
@@ -159,7 +239,8 @@ func TestTest(t *testing.T) {
 	cnfCertificationJUnitFilename := filepath.Join(*junitPath, TNFJunitXMLFileName)
 	loadJUnitXMLIntoMap(junitMap, cnfCertificationJUnitFilename, TNFReportKey)
 	appendCNFFeatureValidationReportResults(junitPath, junitMap)
-	junitMap[extraInfoKey] = tnf.TestsExtraInfo
+	junitMap[results.TestsExtraInfoKey] = tnf.TestsExtraInfo
+	junitMap[results.TestSelectionKey] = testSelection
 
 	// fill out the remaining claim information.
 	claimData.RawResults = junitMap
@@ -173,6 +254,11 @@ func TestTest(t *testing.T) {
 	payload := marshalClaimOutput(claimRoot)
 	claimOutputFile := filepath.Join(*claimPath, claimFileName)
 	writeClaimOutput(claimOutputFile, payload)
+
+	// The complete claim supersedes the partial one.
+	if err := os.Remove(partialClaimFile); err != nil && !os.IsNotExist(err) {
+		log.Errorf("Unable to remove the partial claim %s: %v", partialClaimFile, err)
+	}
 }
 
 // incorporateTNFVersion adds the TNF version to the claim.